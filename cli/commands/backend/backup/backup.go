@@ -0,0 +1,73 @@
+// Package backup provides the ability to back up remote state to local files.
+package backup
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	remoteState, err := config.ParseRemoteState(ctx, l, opts)
+	if err != nil || remoteState == nil {
+		return err
+	}
+
+	var version *backend.StateVersion
+
+	if opts.BackendStateVersion != "" {
+		versions, err := remoteState.ListStateVersions(ctx, l, opts)
+		if err != nil {
+			return err
+		}
+
+		if version, err = versions.Find(opts.BackendStateVersion); err != nil {
+			return err
+		}
+	}
+
+	dir, err := BackupDir(opts)
+	if err != nil {
+		return err
+	}
+
+	path, err := remoteState.Backup(ctx, l, opts, version, dir)
+	if err != nil {
+		return err
+	}
+
+	if path == "" {
+		l.Infof("No state found for %s, nothing to back up", opts.WorkingDir)
+
+		return nil
+	}
+
+	l.Infof("Backed up state to %s", path)
+
+	return nil
+}
+
+// BackupDir returns the directory the state backups of the unit are written to. A relative backup dir is resolved
+// against the unit directory, while an absolute one is shared by all units and gets a subdirectory per unit.
+func BackupDir(opts *options.TerragruntOptions) (string, error) {
+	dir := opts.BackendBackupDir
+	if dir == "" {
+		dir = DefaultBackupDir
+	}
+
+	if !filepath.IsAbs(dir) {
+		return filepath.Join(opts.WorkingDir, dir), nil
+	}
+
+	unitPath, err := filepath.Rel(opts.RootWorkingDir, opts.WorkingDir)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	return filepath.Join(dir, unitPath), nil
+}
@@ -0,0 +1,57 @@
+package backup
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/common/runall"
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "backup"
+
+	StateVersionFlagName = "state-version"
+	BackupDirFlagName    = "backup-dir"
+
+	// DefaultBackupDir is the directory the state backups are written to, relative to the unit directory.
+	DefaultBackupDir = ".terragrunt-state-backup"
+)
+
+func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	flags := cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        StateVersionFlagName,
+			Aliases:     []string{"version"},
+			EnvVars:     tgPrefix.EnvVars(StateVersionFlagName),
+			Usage:       "ID or timestamp of the prior state version to back up, instead of the current state.",
+			Destination: &opts.BackendStateVersion,
+		}),
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        BackupDirFlagName,
+			EnvVars:     tgPrefix.EnvVars(BackupDirFlagName),
+			Usage:       "The path to write state backups into. Default is " + DefaultBackupDir + " in the unit directory.",
+			Destination: &opts.BackendBackupDir,
+		}),
+	}
+
+	return append(flags, run.NewFlags(l, opts, nil).Filter(run.ConfigFlagName, run.DownloadDirFlagName)...)
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	cmd := &cli.Command{
+		Name:  CommandName,
+		Usage: "Back up OpenTofu/Terraform state to local files.",
+		Flags: NewFlags(l, opts, nil),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, opts.OptionsFromContext(ctx))
+		},
+	}
+
+	cmd = runall.WrapCommand(l, opts, cmd, run.Run, true)
+
+	return cmd
+}
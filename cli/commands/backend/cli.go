@@ -2,9 +2,11 @@
 package backend
 
 import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/backend/backup"
 	"github.com/gruntwork-io/terragrunt/cli/commands/backend/bootstrap"
 	"github.com/gruntwork-io/terragrunt/cli/commands/backend/delete"
 	"github.com/gruntwork-io/terragrunt/cli/commands/backend/migrate"
+	"github.com/gruntwork-io/terragrunt/cli/commands/backend/restore"
 	"github.com/gruntwork-io/terragrunt/internal/cli"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
@@ -20,6 +22,8 @@ func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
 			bootstrap.NewCommand(l, opts),
 			delete.NewCommand(l, opts),
 			migrate.NewCommand(l, opts),
+			backup.NewCommand(l, opts),
+			restore.NewCommand(l, opts),
 		},
 		Action: cli.ShowCommandHelp,
 	}
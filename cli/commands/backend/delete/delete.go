@@ -19,7 +19,7 @@ func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) err
 
 	if !opts.ForceBackendDelete {
 		enabled, err := remoteState.IsVersionControlEnabled(ctx, l, opts)
-		if err != nil && !errors.As(err, new(backend.BucketDoesNotExistError)) {
+		if err != nil && !errors.As(err, new(backend.BucketDoesNotExistError)) && !errors.As(err, new(backend.VersionControlNotSupportedError)) && !errors.As(err, new(backend.VersionControlUnknownError)) {
 			return err
 		}
 
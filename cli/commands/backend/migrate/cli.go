@@ -12,7 +12,8 @@ import (
 const (
 	CommandName = "migrate"
 
-	ForceBackendMigrateFlagName = "force"
+	ForceBackendMigrateFlagName  = "force"
+	DryRunBackendMigrateFlagName = "dry-run"
 
 	usageText = "terragrunt backend migrate [options] <src-unit> <dst-unit>"
 )
@@ -27,6 +28,13 @@ func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix
 			Usage:       "Force the backend to be migrated, even if the bucket is not versioned.",
 			Destination: &opts.ForceBackendMigrate,
 		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        DryRunBackendMigrateFlagName,
+			EnvVars:     tgPrefix.EnvVars(DryRunBackendMigrateFlagName),
+			Usage:       "Print the state that would be migrated, without migrating it.",
+			Destination: &opts.BackendMigrateDryRun,
+		}),
 	}
 
 	return append(flags, run.NewFlags(l, opts, nil).Filter(run.ConfigFlagName, run.DownloadDirFlagName)...)
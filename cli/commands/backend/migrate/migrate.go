@@ -55,13 +55,17 @@ func Run(ctx context.Context, l log.Logger, srcPath, dstPath string, opts *optio
 		return err
 	}
 
-	if !opts.ForceBackendMigrate {
+	if !opts.ForceBackendMigrate && !opts.BackendMigrateDryRun {
 		enabled, err := srcRemoteState.IsVersionControlEnabled(ctx, l, srcModule.TerragruntOptions)
-		if err != nil && !errors.As(err, new(backend.BucketDoesNotExistError)) {
-			return err
-		}
 
-		if !enabled {
+		switch {
+		case errors.As(err, new(backend.VersionControlNotSupportedError)), errors.As(err, new(backend.VersionControlUnknownError)):
+			// There is no versioning to check for state that cannot be versioned, such as local state being migrated to a
+			// remote backend, or that does not exist.
+			l.Debugf("%v, skipping the versioning check", err)
+		case err != nil && !errors.As(err, new(backend.BucketDoesNotExistError)):
+			return err
+		case !enabled:
 			return errors.Errorf("src bucket is not versioned, refusing to migrate backend state. If you are sure you want to migrate the backend state anyways, use the --%s flag", ForceBackendMigrateFlagName)
 		}
 	}
@@ -0,0 +1,47 @@
+package restore
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/common/runall"
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "restore"
+
+	StateVersionFlagName = "state-version"
+)
+
+func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	flags := cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        StateVersionFlagName,
+			Aliases:     []string{"version"},
+			EnvVars:     tgPrefix.EnvVars(StateVersionFlagName),
+			Usage:       "ID or timestamp of the prior state version to restore. If not set, the available versions are listed.",
+			Destination: &opts.BackendStateVersion,
+		}),
+	}
+
+	return append(flags, run.NewFlags(l, opts, nil).Filter(run.ConfigFlagName, run.DownloadDirFlagName)...)
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	cmd := &cli.Command{
+		Name:  CommandName,
+		Usage: "Restore OpenTofu/Terraform state to a prior version.",
+		Flags: NewFlags(l, opts, nil),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, opts.OptionsFromContext(ctx))
+		},
+	}
+
+	cmd = runall.WrapCommand(l, opts, cmd, run.Run, true)
+
+	return cmd
+}
@@ -0,0 +1,85 @@
+// Package restore provides the ability to restore remote state to a prior version.
+package restore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
+)
+
+func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	remoteState, err := config.ParseRemoteState(ctx, l, opts)
+	if err != nil || remoteState == nil {
+		return err
+	}
+
+	enabled, err := remoteState.IsVersionControlEnabled(ctx, l, opts)
+	if err != nil && !errors.As(err, new(backend.BucketDoesNotExistError)) && !errors.As(err, new(backend.VersionControlNotSupportedError)) && !errors.As(err, new(backend.VersionControlUnknownError)) {
+		return err
+	}
+
+	if !enabled {
+		return errors.Errorf("bucket is not versioned, there are no prior state versions to restore for %s", opts.WorkingDir)
+	}
+
+	versions, err := remoteState.ListStateVersions(ctx, l, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.BackendStateVersion == "" {
+		return writeVersions(opts.Writer, opts.WorkingDir, versions)
+	}
+
+	version, err := versions.Find(opts.BackendStateVersion)
+	if err != nil {
+		return err
+	}
+
+	if version.IsLatest {
+		l.Infof("State version %s is already the current state of %s", version.ID, opts.WorkingDir)
+
+		return nil
+	}
+
+	prompt := fmt.Sprintf("State of %s will be restored to version %s from %s. Do you want to continue?", opts.WorkingDir, version.ID, version.LastModified.Format(time.RFC3339))
+	if yes, err := shell.PromptUserForYesNo(ctx, l, prompt, opts); err != nil || !yes {
+		return err
+	}
+
+	if err := remoteState.Restore(ctx, l, opts, version); err != nil {
+		return err
+	}
+
+	l.Infof("Restored state of %s to version %s", opts.WorkingDir, version.ID)
+
+	return nil
+}
+
+func writeVersions(w io.Writer, unitPath string, versions backend.StateVersions) error {
+	if _, err := fmt.Fprintf(w, "State versions of %s:\n", unitPath); err != nil {
+		return errors.New(err)
+	}
+
+	for _, version := range versions {
+		line := fmt.Sprintf("  %s  %s  %d bytes", version.LastModified.Format(time.RFC3339), version.ID, version.Size)
+
+		if version.IsLatest {
+			line += "  (current)"
+		}
+
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
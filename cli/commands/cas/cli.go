@@ -0,0 +1,29 @@
+// Package cas provides commands for managing the content-addressable storage (CAS) of Terragrunt.
+package cas
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/cas/gc"
+	"github.com/gruntwork-io/terragrunt/cli/commands/cas/verify"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "cas"
+)
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	prefix := flags.Prefix{CommandName}
+
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "Manage the content-addressable storage used to clone repositories.",
+		Subcommands: cli.Commands{
+			gc.NewCommand(l, opts, prefix),
+			verify.NewCommand(l, opts, prefix),
+		},
+		Action: cli.ShowCommandHelp,
+	}
+}
@@ -0,0 +1,47 @@
+package gc
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "gc"
+
+	DryRunFlagName = "dry-run"
+)
+
+func NewFlags(l log.Logger, opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	flags := cli.Flags{
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        DryRunFlagName,
+			EnvVars:     tgPrefix.EnvVars(DryRunFlagName),
+			Destination: &opts.DryRun,
+			Usage:       "Report the content that would be removed, without removing it.",
+		}),
+	}
+
+	return append(flags, run.NewFlags(l, opts.TerragruntOptions, nil).Filter(run.CASMaxSizeFlagName)...)
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) *cli.Command {
+	cmdOpts := NewOptions(opts)
+	prefix = prefix.Append(CommandName)
+
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "Remove the content of the CAS store no longer used, and evict the least recently used content beyond the max size.",
+		UsageText: "terragrunt cas gc [options]",
+		Description: "Access is recorded per cloned repository or ingested source, not per blob or tree, and content is evicted along with the last repository or source referencing it.\n\n" +
+			"Stores created by versions of Terragrunt without garbage collection have no recorded repositories, so the first garbage collection removes all their content older than an hour that isn't linked into a working directory. It is cloned again when it is next used.",
+		Flags: NewFlags(l, cmdOpts, prefix),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, cmdOpts)
+		},
+	}
+}
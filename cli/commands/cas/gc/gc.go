@@ -0,0 +1,36 @@
+// Package gc implements the terragrunt cas gc command, which garbage collects the CAS store.
+package gc
+
+import (
+	"context"
+
+	"github.com/gruntwork-io/terragrunt/internal/cas"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+func Run(_ context.Context, l log.Logger, opts *Options) error {
+	c, err := cas.New(cas.Options{})
+	if err != nil {
+		return err
+	}
+
+	result, err := c.GC(&l, cas.GCOptions{
+		MaxSize: opts.CASMaxSize,
+		DryRun:  opts.DryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		l.Infof("%d objects (%s) would be removed and %d roots evicted", result.RemovedObjects, util.FormatByteSize(result.RemovedSize), result.EvictedRoots)
+
+		return nil
+	}
+
+	l.Infof("Removed %d objects (%s) and evicted %d roots", result.RemovedObjects, util.FormatByteSize(result.RemovedSize), result.EvictedRoots)
+	l.Infof("CAS store has %d objects (%s) in %d roots", result.Objects, util.FormatByteSize(result.Size), result.Roots)
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package gc
+
+import (
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+type Options struct {
+	*options.TerragruntOptions
+
+	// DryRun reports the content that would be removed, without removing it.
+	DryRun bool
+}
+
+func NewOptions(opts *options.TerragruntOptions) *Options {
+	return &Options{
+		TerragruntOptions: opts,
+	}
+}
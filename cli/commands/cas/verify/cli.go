@@ -0,0 +1,50 @@
+package verify
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "verify"
+
+	RepairFlagName          = "repair"
+	EnforceReadOnlyFlagName = "enforce-read-only"
+)
+
+func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        RepairFlagName,
+			EnvVars:     tgPrefix.EnvVars(RepairFlagName),
+			Destination: &opts.Repair,
+			Usage:       "Remove the corrupted content from the CAS store, so that it is fetched again.",
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        EnforceReadOnlyFlagName,
+			EnvVars:     tgPrefix.EnvVars(EnforceReadOnlyFlagName),
+			Destination: &opts.EnforceReadOnly,
+			Usage:       "Make the writable content of the CAS store read-only.",
+		}),
+	}
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) *cli.Command {
+	cmdOpts := NewOptions(opts)
+	prefix = prefix.Append(CommandName)
+
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "Verify that the content of the CAS store matches its hashes.",
+		UsageText: "terragrunt cas verify [options]",
+		Flags:     NewFlags(cmdOpts, prefix),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, cmdOpts)
+		},
+	}
+}
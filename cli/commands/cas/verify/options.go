@@ -0,0 +1,21 @@
+package verify
+
+import (
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+type Options struct {
+	*options.TerragruntOptions
+
+	// Repair removes the corrupted content from the CAS store, so that it is fetched again.
+	Repair bool
+
+	// EnforceReadOnly makes the writable content of the CAS store read-only.
+	EnforceReadOnly bool
+}
+
+func NewOptions(opts *options.TerragruntOptions) *Options {
+	return &Options{
+		TerragruntOptions: opts,
+	}
+}
@@ -0,0 +1,52 @@
+// Package verify implements the terragrunt cas verify command, which verifies that the content of the CAS store
+// matches its hashes.
+package verify
+
+import (
+	"context"
+
+	"github.com/gruntwork-io/terragrunt/internal/cas"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+func Run(ctx context.Context, l log.Logger, opts *Options) error {
+	c, err := cas.New(cas.Options{})
+	if err != nil {
+		return err
+	}
+
+	result, err := c.Verify(ctx, &l, cas.VerifyOptions{
+		Repair:          opts.Repair,
+		EnforceReadOnly: opts.EnforceReadOnly,
+	})
+	if err != nil {
+		return err
+	}
+
+	l.Infof("Verified %d objects of the CAS store", result.Objects)
+
+	if len(result.Unverified) > 0 {
+		l.Warnf("%d root trees have no recorded tree hash and were only checked to be valid trees", len(result.Unverified))
+	}
+
+	if len(result.Writable) > 0 {
+		if opts.EnforceReadOnly {
+			l.Infof("Made %d writable objects read-only", len(result.Writable))
+		} else {
+			l.Warnf("%d objects are writable, use --%s to make them read-only", len(result.Writable), EnforceReadOnlyFlagName)
+		}
+	}
+
+	if len(result.Corrupted) == 0 {
+		return nil
+	}
+
+	if opts.Repair {
+		l.Infof("Removed %d corrupted objects and the %d trees referencing them, they will be fetched again by the next clone", len(result.Corrupted), len(result.Removed)-len(result.Corrupted))
+
+		return nil
+	}
+
+	return errors.Errorf("%d objects of the CAS store are corrupted, use --%s to remove them", len(result.Corrupted), RepairFlagName)
+}
@@ -3,6 +3,7 @@
 package catalog
 
 import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
 	"github.com/gruntwork-io/terragrunt/cli/commands/scaffold"
 	"github.com/gruntwork-io/terragrunt/cli/flags"
 	"github.com/gruntwork-io/terragrunt/internal/cli"
@@ -14,18 +15,20 @@ const (
 	CommandName = "catalog"
 )
 
-func NewFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
-	return scaffold.NewFlags(opts, prefix).Filter(
+func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	flags := scaffold.NewFlags(opts, prefix).Filter(
 		scaffold.RootFileNameFlagName,
 		scaffold.NoIncludeRootFlagName,
 	)
+
+	return append(flags, run.NewFlags(l, opts, nil).Filter(run.CASMaxSizeFlagName, run.CASRemoteFlagName)...)
 }
 
 func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
 	return &cli.Command{
 		Name:  CommandName,
 		Usage: "Launch the user interface for searching and managing your module catalog.",
-		Flags: NewFlags(opts, nil),
+		Flags: NewFlags(l, opts, nil),
 		Action: func(ctx *cli.Context) error {
 			var repoPath string
 
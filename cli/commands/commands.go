@@ -10,7 +10,9 @@ import (
 
 	"github.com/gruntwork-io/go-commons/env"
 	"github.com/gruntwork-io/terragrunt/cli/commands/backend"
+	"github.com/gruntwork-io/terragrunt/cli/commands/cas"
 	"github.com/gruntwork-io/terragrunt/cli/commands/dag"
+	"github.com/gruntwork-io/terragrunt/cli/commands/engine"
 	"github.com/gruntwork-io/terragrunt/cli/commands/find"
 	"github.com/gruntwork-io/terragrunt/cli/commands/hcl"
 	"github.com/gruntwork-io/terragrunt/cli/commands/info"
@@ -35,6 +37,7 @@ import (
 	"github.com/gruntwork-io/terragrunt/cli/commands/catalog"
 	execCmd "github.com/gruntwork-io/terragrunt/cli/commands/exec"
 	outputmodulegroups "github.com/gruntwork-io/terragrunt/cli/commands/output-module-groups"
+	providercacheCmd "github.com/gruntwork-io/terragrunt/cli/commands/provider-cache"
 	runCmd "github.com/gruntwork-io/terragrunt/cli/commands/run"
 	"github.com/gruntwork-io/terragrunt/internal/cli"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
@@ -61,10 +64,13 @@ const (
 // Categories are ordered in increments of 10 for easy insertion of new categories.
 func New(l log.Logger, opts *options.TerragruntOptions) cli.Commands {
 	mainCommands := cli.Commands{
-		runCmd.NewCommand(l, opts),  // run
-		stack.NewCommand(l, opts),   // stack
-		execCmd.NewCommand(l, opts), // exec
-		backend.NewCommand(l, opts), // backend
+		runCmd.NewCommand(l, opts),           // run
+		stack.NewCommand(l, opts),            // stack
+		execCmd.NewCommand(l, opts),          // exec
+		backend.NewCommand(l, opts),          // backend
+		engine.NewCommand(l, opts),           // engine
+		cas.NewCommand(l, opts),              // cas
+		providercacheCmd.NewCommand(l, opts), // provider-cache
 	}.SetCategory(
 		&cli.Category{
 			Name:  MainCommandsCategoryName,
@@ -294,6 +300,12 @@ func initialSetup(cliCtx *cli.Context, l log.Logger, opts *options.TerragruntOpt
 		opts.ExcludeByDefault = true
 	}
 
+	if !opts.ExcludeByDefault && opts.ChangedSince != "" {
+		l.Debugf("Changed since set. Excluding by default.")
+
+		opts.ExcludeByDefault = true
+	}
+
 	if !opts.ExcludeByDefault && opts.StrictInclude {
 		l.Debugf("Strict include set. Excluding by default.")
 
@@ -7,8 +7,6 @@ import (
 	"github.com/gruntwork-io/terragrunt/cli/commands/common/runall"
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/experiment"
-	"github.com/gruntwork-io/terragrunt/internal/os/stdout"
-	"github.com/gruntwork-io/terragrunt/internal/report"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/util"
 
@@ -50,10 +48,9 @@ func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) err
 	stackOpts := []configstack.Option{}
 
 	if opts.Experiments.Evaluate(experiment.Report) {
-		r := report.NewReport()
-
-		if l.Formatter().DisabledColors() || stdout.IsRedirected() {
-			r.WithDisableColor()
+		r, err := runall.NewReport(l, opts)
+		if err != nil {
+			return err
 		}
 
 		stackOpts = append(stackOpts, configstack.WithReport(r))
@@ -61,6 +58,12 @@ func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) err
 		if !opts.SummaryDisable {
 			defer r.WriteSummary(opts.Writer) //nolint:errcheck
 		}
+
+		defer runall.WriteReportFile(l, opts, r)
+
+		if opts.ReportHistory {
+			defer runall.AppendReportHistory(l, opts, r)
+		}
 	}
 
 	stack, err := configstack.FindStackInSubfolders(ctx, l, rootOptions, stackOpts...)
@@ -3,6 +3,7 @@ package runall
 import (
 	"context"
 	"os"
+	"path/filepath"
 
 	"github.com/gruntwork-io/terragrunt/configstack"
 	"github.com/gruntwork-io/terragrunt/internal/cli"
@@ -51,10 +52,9 @@ func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) err
 	stackOpts := []configstack.Option{}
 
 	if opts.Experiments.Evaluate(experiment.Report) {
-		r := report.NewReport()
-
-		if l.Formatter().DisabledColors() || stdout.IsRedirected() {
-			r.WithDisableColor()
+		r, err := NewReport(l, opts)
+		if err != nil {
+			return err
 		}
 
 		stackOpts = append(stackOpts, configstack.WithReport(r))
@@ -62,6 +62,12 @@ func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) err
 		if !opts.SummaryDisable {
 			defer r.WriteSummary(opts.Writer) //nolint:errcheck
 		}
+
+		defer WriteReportFile(l, opts, r)
+
+		if opts.ReportHistory {
+			defer AppendReportHistory(l, opts, r)
+		}
 	}
 
 	stack, err := configstack.FindStackInSubfolders(ctx, l, opts, stackOpts...)
@@ -72,6 +78,62 @@ func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) err
 	return RunAllOnStack(ctx, l, opts, stack)
 }
 
+// NewReport creates the run report for the given options.
+func NewReport(l log.Logger, opts *options.TerragruntOptions) (*report.Report, error) {
+	r := report.NewReport()
+
+	if l.Formatter().DisabledColors() || stdout.IsRedirected() {
+		r.WithDisableColor()
+	}
+
+	if opts.ReportFormat != "" {
+		format, err := report.ParseFormat(opts.ReportFormat)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		r.WithFormat(format)
+	}
+
+	return r, nil
+}
+
+// WriteReportFile writes the run report to the report file, if one is set in the given options.
+func WriteReportFile(l log.Logger, opts *options.TerragruntOptions, r *report.Report) {
+	if opts.ReportFile == "" {
+		return
+	}
+
+	path := opts.ReportFile
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(opts.WorkingDir, path)
+	}
+
+	if err := r.WriteToFile(path); err != nil {
+		l.Errorf("Error writing run report to %s: %v", path, err)
+		return
+	}
+
+	l.Debugf("Run report written to %s", path)
+}
+
+// ReportHistoryPath returns the path of the run history for the given options.
+func ReportHistoryPath(opts *options.TerragruntOptions) string {
+	return filepath.Join(opts.DownloadDir, report.HistoryFileName)
+}
+
+// AppendReportHistory appends the run report to the run history stored in the download dir.
+func AppendReportHistory(l log.Logger, opts *options.TerragruntOptions, r *report.Report) {
+	history := report.NewHistory(ReportHistoryPath(opts))
+
+	if err := history.Append(r); err != nil {
+		l.Errorf("Error appending run report to run history %s: %v", history.Path(), err)
+		return
+	}
+
+	l.Debugf("Run report appended to run history %s", history.Path())
+}
+
 func RunAllOnStack(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, stack configstack.Stack) error {
 	l.Debugf("%s", stack.String())
 
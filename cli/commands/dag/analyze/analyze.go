@@ -0,0 +1,208 @@
+// Package analyze implements the terragrunt dag analyze command, which combines the Directed Acyclic Graph (DAG)
+// with the durations of past runs to find the critical path, the slack of every unit, and the minimum wall time
+// of a run at a given parallelism.
+package analyze
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/common/runall"
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// historyLimit is the number of past runs in the run history the average durations are computed from.
+const historyLimit = 10
+
+func Run(ctx context.Context, l log.Logger, opts *Options) error {
+	durations, err := unitDurations(l, opts)
+	if err != nil {
+		return err
+	}
+
+	stack, err := configstack.FindStackInSubfolders(ctx, l, opts.TerragruntOptions)
+	if err != nil {
+		return err
+	}
+
+	analysis, err := stack.Modules().Analyze(durations, opts.Parallelism)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format == FormatJSON {
+		return writeJSON(opts.Writer, opts.WorkingDir, analysis)
+	}
+
+	return writeText(opts.Writer, opts.WorkingDir, analysis)
+}
+
+// unitDurations returns the durations of the units, either from the run report passed in with --from-report,
+// or averaged over the run history.
+func unitDurations(l log.Logger, opts *Options) (map[string]time.Duration, error) {
+	if opts.FromReport != "" {
+		path := opts.FromReport
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(opts.WorkingDir, path)
+		}
+
+		r, err := report.ReadFromFile(path)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		return r.Durations(), nil
+	}
+
+	history := report.NewHistory(runall.ReportHistoryPath(opts.TerragruntOptions))
+
+	entries, err := history.Read(historyLimit)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.Errorf("no unit durations found: run history %s is empty. Record runs with --report-history, or pass a run report with --%s", history.Path(), FromReportFlagName)
+	}
+
+	l.Debugf("Averaging unit durations over %d runs in run history %s", len(entries), history.Path())
+
+	return report.AverageDurations(entries), nil
+}
+
+func writeText(w io.Writer, workingDir string, analysis *configstack.DAGAnalysis) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Critical path (%s):\n", formatDuration(analysis.CriticalPathDuration))
+
+	for _, unit := range analysis.CriticalPath {
+		fmt.Fprintf(&b, "  %s (%s)\n", unitName(workingDir, unit.Path), formatUnitDuration(unit))
+	}
+
+	b.WriteString("\n")
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0) //nolint:mnd
+	fmt.Fprintln(tw, "Unit\tDuration\tEarliest Start\tSlack\t")
+
+	for _, unit := range analysis.Units {
+		critical := ""
+		if unit.Critical {
+			critical = "critical"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", unitName(workingDir, unit.Path), formatUnitDuration(unit), formatDuration(unit.EarliestStart), formatDuration(unit.Slack), critical)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return errors.New(err)
+	}
+
+	fmt.Fprintf(&b, "\nParallelism:         %s\n", formatParallelism(analysis.Parallelism))
+	fmt.Fprintf(&b, "Total work:          %s\n", formatDuration(analysis.TotalDuration))
+	fmt.Fprintf(&b, "Minimum wall time:   %s\n", formatDuration(analysis.MinWallTime))
+	fmt.Fprintf(&b, "Estimated wall time: %s\n", formatDuration(analysis.EstimatedWallTime))
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// jsonUnit is the JSON representation of the timing of a unit.
+type jsonUnit struct {
+	Path          string  `json:"path"`
+	Duration      float64 `json:"duration"`
+	EarliestStart float64 `json:"earliest_start"`
+	LatestStart   float64 `json:"latest_start"`
+	Slack         float64 `json:"slack"`
+	HasDuration   bool    `json:"has_duration"`
+	Critical      bool    `json:"critical"`
+}
+
+// jsonAnalysis is the JSON representation of the analysis. Durations are in seconds.
+type jsonAnalysis struct {
+	CriticalPath         []string   `json:"critical_path"`
+	Units                []jsonUnit `json:"units"`
+	CriticalPathDuration float64    `json:"critical_path_duration"`
+	TotalDuration        float64    `json:"total_duration"`
+	MinWallTime          float64    `json:"min_wall_time"`
+	EstimatedWallTime    float64    `json:"estimated_wall_time"`
+	Parallelism          int        `json:"parallelism"`
+}
+
+func writeJSON(w io.Writer, workingDir string, analysis *configstack.DAGAnalysis) error {
+	out := jsonAnalysis{
+		CriticalPath:         make([]string, 0, len(analysis.CriticalPath)),
+		Units:                make([]jsonUnit, 0, len(analysis.Units)),
+		CriticalPathDuration: analysis.CriticalPathDuration.Seconds(),
+		TotalDuration:        analysis.TotalDuration.Seconds(),
+		MinWallTime:          analysis.MinWallTime.Seconds(),
+		EstimatedWallTime:    analysis.EstimatedWallTime.Seconds(),
+		Parallelism:          analysis.Parallelism,
+	}
+
+	for _, unit := range analysis.CriticalPath {
+		out.CriticalPath = append(out.CriticalPath, unitName(workingDir, unit.Path))
+	}
+
+	for _, unit := range analysis.Units {
+		out.Units = append(out.Units, jsonUnit{
+			Path:          unitName(workingDir, unit.Path),
+			Duration:      unit.Duration.Seconds(),
+			EarliestStart: unit.EarliestStart.Seconds(),
+			LatestStart:   unit.LatestStart.Seconds(),
+			Slack:         unit.Slack.Seconds(),
+			HasDuration:   unit.HasDuration,
+			Critical:      unit.Critical,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(out); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// unitName returns the path of the unit relative to the working directory, if possible.
+func unitName(workingDir, path string) string {
+	if rel, err := filepath.Rel(workingDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+
+	return path
+}
+
+func formatDuration(duration time.Duration) string {
+	return duration.Round(time.Second).String()
+}
+
+func formatUnitDuration(unit *configstack.UnitTiming) string {
+	if !unit.HasDuration {
+		return "unknown"
+	}
+
+	return formatDuration(unit.Duration)
+}
+
+func formatParallelism(parallelism int) string {
+	if parallelism >= math.MaxInt32 {
+		return "unlimited"
+	}
+
+	return fmt.Sprintf("%d", parallelism)
+}
@@ -0,0 +1,56 @@
+package analyze
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "analyze"
+
+	FormatFlagName     = "format"
+	FromReportFlagName = "from-report"
+)
+
+func NewFlags(l log.Logger, opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	flags := cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FormatFlagName,
+			EnvVars:     tgPrefix.EnvVars(FormatFlagName),
+			Destination: &opts.Format,
+			Usage:       "Output format for the analysis. Valid values: text, json.",
+			DefaultText: FormatText,
+		}),
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FromReportFlagName,
+			EnvVars:     tgPrefix.EnvVars(FromReportFlagName),
+			Destination: &opts.FromReport,
+			Usage:       "Path to a run report to take the durations of the units from. Default is the average durations in the run history.",
+		}),
+	}
+
+	return append(flags, run.NewFlags(l, opts.TerragruntOptions, nil).Filter(run.ParallelismFlagName, run.DownloadDirFlagName)...)
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) *cli.Command {
+	cmdOpts := NewOptions(opts)
+	prefix = prefix.Append(CommandName)
+
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "Analyze the critical path, slack and minimum wall time of the Directed Acyclic Graph (DAG).",
+		UsageText: "terragrunt dag analyze",
+		Flags:     NewFlags(l, cmdOpts, prefix),
+		Before: func(_ *cli.Context) error {
+			return cmdOpts.Validate()
+		},
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, cmdOpts)
+		},
+	}
+}
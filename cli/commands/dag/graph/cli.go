@@ -1,5 +1,5 @@
 // Package graph implements the terragrunt dag graph command which generates a visual
-// representation of the Terragrunt dependency graph in DOT language format.
+// representation of the Terragrunt dependency graph in DOT language, Mermaid, JSON or HTML format.
 package graph
 
 import (
@@ -15,15 +15,38 @@ import (
 
 const (
 	CommandName = "graph"
+
+	FormatFlagName = "format"
 )
 
-func NewCommand(l log.Logger, opts *options.TerragruntOptions, _ flags.Prefix) *cli.Command {
+func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FormatFlagName,
+			EnvVars:     tgPrefix.EnvVars(FormatFlagName),
+			Destination: &opts.Format,
+			Usage:       "Output format for the graph. Valid values: dot, mermaid, json, html.",
+			DefaultText: configstack.GraphFormatDOT,
+		}),
+	}
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) *cli.Command {
+	cmdOpts := NewOptions(opts)
+	prefix = prefix.Append(CommandName)
+
 	cmd := &cli.Command{
 		Name:      CommandName,
-		Usage:     "Graph the Directed Acyclic Graph (DAG) in DOT language.",
+		Usage:     "Graph the Directed Acyclic Graph (DAG) in DOT language, Mermaid, JSON or HTML.",
 		UsageText: "terragrunt dag graph",
+		Flags:     NewFlags(cmdOpts, prefix),
+		Before: func(_ *cli.Context) error {
+			return cmdOpts.Validate()
+		},
 		Action: func(ctx *cli.Context) error {
-			return Run(ctx, l, opts)
+			return Run(ctx, l, cmdOpts)
 		},
 	}
 
@@ -33,13 +56,17 @@ func NewCommand(l log.Logger, opts *options.TerragruntOptions, _ flags.Prefix) *
 	return cmd
 }
 
-func Run(ctx *cli.Context, l log.Logger, opts *options.TerragruntOptions) error {
-	stack, err := configstack.FindStackInSubfolders(ctx, l, opts)
+func Run(ctx *cli.Context, l log.Logger, opts *Options) error {
+	stack, err := configstack.FindStackInSubfolders(ctx, l, opts.TerragruntOptions)
 	if err != nil {
 		return err
 	}
 
-	stack.Graph(l, opts)
+	if opts.Format == configstack.GraphFormatDOT {
+		stack.Graph(l, opts.TerragruntOptions)
+
+		return nil
+	}
 
-	return nil
+	return stack.Modules().WriteGraph(l, opts.Writer, opts.TerragruntOptions, opts.Format)
 }
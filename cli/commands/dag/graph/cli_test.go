@@ -48,7 +48,7 @@ func BenchmarkRunGraphDependencies(b *testing.B) {
 			b.ResetTimer()
 			b.StartTimer()
 			ctx := cli.NewAppContext(b.Context(), cli.NewApp(), nil)
-			err = graph.Run(ctx, logger.CreateLogger(), terragruntOptions)
+			err = graph.Run(ctx, logger.CreateLogger(), graph.NewOptions(terragruntOptions))
 			b.StopTimer()
 			require.NoError(b, err)
 		})
@@ -0,0 +1,32 @@
+package graph
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+type Options struct {
+	*options.TerragruntOptions
+
+	// Format determines the format of the graph.
+	Format string
+}
+
+func NewOptions(opts *options.TerragruntOptions) *Options {
+	return &Options{
+		TerragruntOptions: opts,
+		Format:            configstack.GraphFormatDOT,
+	}
+}
+
+func (o *Options) Validate() error {
+	if !slices.Contains(configstack.GraphFormats(), o.Format) {
+		return errors.Errorf("invalid format: %s, valid formats: %s", o.Format, strings.Join(configstack.GraphFormats(), ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+// Package engine provides commands for managing Terragrunt IaC engines.
+package engine
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/engine/install"
+	"github.com/gruntwork-io/terragrunt/cli/commands/engine/list"
+	"github.com/gruntwork-io/terragrunt/cli/commands/engine/lock"
+	"github.com/gruntwork-io/terragrunt/cli/commands/engine/prune"
+	"github.com/gruntwork-io/terragrunt/cli/commands/engine/verify"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "engine"
+)
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	prefix := flags.Prefix{CommandName}
+
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "Manage the IaC engines used by units.",
+		Subcommands: cli.Commands{
+			lock.NewCommand(l, opts, prefix),
+			list.NewCommand(l, opts, prefix),
+			install.NewCommand(l, opts),
+			prune.NewCommand(l, opts, prefix),
+			verify.NewCommand(l, opts),
+		},
+		Action: cli.ShowCommandHelp,
+	}
+}
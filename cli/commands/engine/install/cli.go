@@ -0,0 +1,28 @@
+package install
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "install"
+)
+
+func NewFlags(l log.Logger, opts *options.TerragruntOptions) cli.Flags {
+	return run.NewFlags(l, opts, nil).Filter(run.EngineCachePathFlagName, run.EngineSkipCheckFlagName)
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "Download the engines used by the units into the engine cache.",
+		UsageText: "terragrunt engine install [options]",
+		Flags:     NewFlags(l, opts),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, opts)
+		},
+	}
+}
@@ -0,0 +1,47 @@
+// Package install implements the terragrunt engine install command, which downloads the engines used by the units
+// into the engine cache ahead of a run, e.g. to bake them into the image of an air-gapped CI runner.
+package install
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/engine/lock"
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	engines, err := lock.DiscoverEngines(ctx, l, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(engines) == 0 {
+		l.Infof("No engines found in %s, nothing to install", opts.WorkingDir)
+
+		return nil
+	}
+
+	for _, e := range engines {
+		if util.FileExists(e.Source) {
+			l.Debugf("Skipping engine %s, local engines are not installed", e.Source)
+
+			continue
+		}
+
+		installOpts := opts.Clone()
+		installOpts.Engine = e
+		installOpts.EngineEnabled = true
+
+		if err := engine.DownloadEngine(ctx, l, installOpts); err != nil {
+			return err
+		}
+
+		l.Infof("Installed engine %s", strings.TrimSpace(e.Source+" "+e.Version))
+	}
+
+	return nil
+}
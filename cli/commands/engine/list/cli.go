@@ -0,0 +1,50 @@
+package list
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "list"
+
+	FormatFlagName = "format"
+)
+
+func NewFlags(l log.Logger, opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	flags := cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FormatFlagName,
+			EnvVars:     tgPrefix.EnvVars(FormatFlagName),
+			Destination: &opts.Format,
+			Usage:       "Output format for the list of engines. Valid values: text, json.",
+			DefaultText: FormatText,
+		}),
+	}
+
+	return append(flags, run.NewFlags(l, opts.TerragruntOptions, nil).Filter(run.EngineCachePathFlagName)...)
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) *cli.Command {
+	cmdOpts := NewOptions(opts)
+	prefix = prefix.Append(CommandName)
+
+	return &cli.Command{
+		Name:      CommandName,
+		Aliases:   []string{"ls"},
+		Usage:     "List the engines in the engine cache.",
+		UsageText: "terragrunt engine list [options]",
+		Flags:     NewFlags(l, cmdOpts, prefix),
+		Before: func(_ *cli.Context) error {
+			return cmdOpts.Validate()
+		},
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, cmdOpts)
+		},
+	}
+}
@@ -0,0 +1,87 @@
+// Package list implements the terragrunt engine list command, which lists the engines in the engine cache by
+// source, version and platform.
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+func Run(_ context.Context, _ log.Logger, opts *Options) error {
+	engines, err := engine.CachedEngines(opts.TerragruntOptions)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format == FormatJSON {
+		return writeJSON(opts.Writer, engines)
+	}
+
+	return writeText(opts.Writer, engines)
+}
+
+func writeText(w io.Writer, engines []*engine.CachedEngine) error {
+	var b strings.Builder
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0) //nolint:mnd
+	fmt.Fprintln(tw, "Name\tType\tVersion\tPlatform\tSize\tPath")
+
+	for _, cached := range engines {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", cached.Name, cached.Type, cached.Version, cached.Platform, util.FormatByteSize(cached.Size), cached.Path)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return errors.New(err)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// jsonEngine is the JSON representation of a cached engine.
+type jsonEngine struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Version      string `json:"version"`
+	Platform     string `json:"platform"`
+	Path         string `json:"path"`
+	Size         int64  `json:"size"`
+	HasChecksums bool   `json:"has_checksums"`
+}
+
+func writeJSON(w io.Writer, engines []*engine.CachedEngine) error {
+	out := make([]jsonEngine, 0, len(engines))
+
+	for _, cached := range engines {
+		out = append(out, jsonEngine{
+			Name:         cached.Name,
+			Type:         cached.Type,
+			Version:      cached.Version,
+			Platform:     cached.Platform,
+			Path:         cached.Path,
+			Size:         cached.Size,
+			HasChecksums: cached.HasChecksums(),
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(out); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
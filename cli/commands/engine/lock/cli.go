@@ -0,0 +1,50 @@
+package lock
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "lock"
+
+	PlatformFlagName = "platform"
+	UpgradeFlagName  = "upgrade"
+)
+
+func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.SliceFlag[string]{
+			Name:        PlatformFlagName,
+			EnvVars:     tgPrefix.EnvVars(PlatformFlagName),
+			Destination: &opts.Platforms,
+			Usage:       "Platform to lock the checksums of the engines for, e.g. linux_amd64. Default is all the platforms an engine is released for.",
+		}),
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        UpgradeFlagName,
+			EnvVars:     tgPrefix.EnvVars(UpgradeFlagName),
+			Destination: &opts.Upgrade,
+			Usage:       "Resolve the latest release of engines without a version, instead of keeping the locked version.",
+		}),
+	}
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) *cli.Command {
+	cmdOpts := NewOptions(opts)
+	prefix = prefix.Append(CommandName)
+
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "Lock the versions and checksums of the engines used by the units in " + engine.LockFileName + ".",
+		UsageText: "terragrunt engine lock [options]",
+		Flags:     NewFlags(cmdOpts, prefix),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, cmdOpts)
+		},
+	}
+}
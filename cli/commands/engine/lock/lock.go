@@ -0,0 +1,131 @@
+// Package lock implements the terragrunt engine lock command, which pins the versions and the checksums of the
+// engines used by the units in a lock file, so that the same engine binaries are used on every run.
+package lock
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/internal/discovery"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+func Run(ctx context.Context, l log.Logger, opts *Options) error {
+	engines, err := DiscoverEngines(ctx, l, opts.TerragruntOptions)
+	if err != nil {
+		return err
+	}
+
+	path := engine.FindLockFile(opts.WorkingDir)
+
+	lockFile := engine.NewLockFile(filepath.Join(opts.WorkingDir, engine.LockFileName))
+	if path != "" {
+		if lockFile, err = engine.ReadLockFile(path); err != nil {
+			return err
+		}
+	}
+
+	if len(engines) == 0 {
+		l.Infof("No engines found in %s, nothing to lock", opts.WorkingDir)
+
+		return nil
+	}
+
+	for _, e := range engines {
+		if util.FileExists(e.Source) {
+			l.Debugf("Skipping engine %s, local engines are not locked", e.Source)
+
+			continue
+		}
+
+		// Keep the locked version of engines without a version, unless upgrading.
+		if existing := lockFile.Engine(e.Source); existing != nil && len(e.Version) == 0 && !opts.Upgrade {
+			e.Version = existing.Version
+		}
+
+		locked, err := engine.LockEngine(ctx, l, e, opts.Platforms)
+		if err != nil {
+			return err
+		}
+
+		l.Infof("Locked engine %s for %d platforms", strings.TrimSpace(locked.Source+" "+locked.Version), len(locked.Hashes))
+
+		lockFile.SetEngine(locked)
+	}
+
+	if err := lockFile.Write(); err != nil {
+		return err
+	}
+
+	l.Infof("Engine lock file written to %s", lockFile.Path())
+
+	return nil
+}
+
+// DiscoverEngines returns the engines used by the units in the working directory, one per engine source.
+// It's an error for units to use different versions or types of the same engine source.
+func DiscoverEngines(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) ([]*options.EngineOptions, error) {
+	cfgs, err := discovery.NewDiscovery(opts.WorkingDir).Discover(ctx, l, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var engines []*options.EngineOptions
+
+	for _, cfg := range cfgs.Filter(discovery.ConfigTypeUnit) {
+		e, err := parseEngine(ctx, l, opts, cfg.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		if e == nil {
+			continue
+		}
+
+		if engine.IsBuiltin(e) {
+			l.Debugf("Skipping builtin engine of unit %s", cfg.Path)
+
+			continue
+		}
+
+		idx := slices.IndexFunc(engines, func(other *options.EngineOptions) bool {
+			return other.Source == e.Source
+		})
+
+		if idx == -1 {
+			engines = append(engines, e)
+
+			continue
+		}
+
+		if other := engines[idx]; other.Version != e.Version || other.Type != e.Type {
+			return nil, errors.Errorf("engine %s is used with different versions or types: %s %s and %s %s", e.Source, other.Type, other.Version, e.Type, e.Version)
+		}
+	}
+
+	return engines, nil
+}
+
+// parseEngine parses the engine block of the unit in the given directory, returning nil if it has none.
+func parseEngine(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, dir string) (*options.EngineOptions, error) {
+	parseOpts := opts.Clone()
+	parseOpts.WorkingDir = dir
+	parseOpts.TerragruntConfigPath = filepath.Join(dir, config.DefaultTerragruntConfigPath)
+
+	parsingCtx := config.NewParsingContext(ctx, l, parseOpts).WithDecodeList(config.EngineBlock)
+
+	//nolint: contextcheck
+	cfg, err := config.PartialParseConfigFile(parsingCtx, l, parseOpts.TerragruntConfigPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.EngineOptions()
+}
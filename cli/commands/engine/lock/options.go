@@ -0,0 +1,21 @@
+package lock
+
+import (
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+type Options struct {
+	*options.TerragruntOptions
+
+	// Platforms are the platforms to lock the checksums of the engines for.
+	Platforms []string
+
+	// Upgrade resolves the latest release of engines without a version, instead of keeping the locked version.
+	Upgrade bool
+}
+
+func NewOptions(opts *options.TerragruntOptions) *Options {
+	return &Options{
+		TerragruntOptions: opts,
+	}
+}
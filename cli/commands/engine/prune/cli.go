@@ -0,0 +1,45 @@
+package prune
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "prune"
+
+	DryRunFlagName = "dry-run"
+)
+
+func NewFlags(l log.Logger, opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	flags := cli.Flags{
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        DryRunFlagName,
+			EnvVars:     tgPrefix.EnvVars(DryRunFlagName),
+			Destination: &opts.DryRun,
+			Usage:       "List the engines that would be removed, without removing them.",
+		}),
+	}
+
+	return append(flags, run.NewFlags(l, opts.TerragruntOptions, nil).Filter(run.EngineCachePathFlagName)...)
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) *cli.Command {
+	cmdOpts := NewOptions(opts)
+	prefix = prefix.Append(CommandName)
+
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "Remove the engines not used by any unit from the engine cache.",
+		UsageText: "terragrunt engine prune [options]",
+		Flags:     NewFlags(l, cmdOpts, prefix),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, cmdOpts)
+		},
+	}
+}
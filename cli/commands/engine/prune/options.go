@@ -0,0 +1,18 @@
+package prune
+
+import (
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+type Options struct {
+	*options.TerragruntOptions
+
+	// DryRun lists the engines that would be removed, without removing them.
+	DryRun bool
+}
+
+func NewOptions(opts *options.TerragruntOptions) *Options {
+	return &Options{
+		TerragruntOptions: opts,
+	}
+}
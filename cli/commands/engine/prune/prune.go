@@ -0,0 +1,85 @@
+// Package prune implements the terragrunt engine prune command, which removes the engines that are not used by any
+// unit from the engine cache.
+package prune
+
+import (
+	"context"
+	"slices"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/engine/lock"
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+func Run(ctx context.Context, l log.Logger, opts *Options) error {
+	used, err := usedEngines(ctx, l, opts.TerragruntOptions)
+	if err != nil {
+		return err
+	}
+
+	cached, err := engine.CachedEngines(opts.TerragruntOptions)
+	if err != nil {
+		return err
+	}
+
+	unused := 0
+
+	for _, cachedEngine := range cached {
+		if slices.ContainsFunc(used, cachedEngine.Matches) {
+			continue
+		}
+
+		unused++
+
+		if opts.DryRun {
+			l.Infof("Would remove engine %s", cachedEngine.Path)
+
+			continue
+		}
+
+		if err := cachedEngine.Remove(opts.TerragruntOptions); err != nil {
+			return err
+		}
+
+		l.Infof("Removed engine %s", cachedEngine.Path)
+	}
+
+	if opts.DryRun {
+		l.Infof("%d of %d cached engines would be removed", unused, len(cached))
+
+		return nil
+	}
+
+	l.Infof("Removed %d of %d cached engines", unused, len(cached))
+
+	return nil
+}
+
+// usedEngines returns the engines used by the units, with their versions resolved the same way as on a run.
+func usedEngines(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) ([]*options.EngineOptions, error) {
+	engines, err := lock.DiscoverEngines(ctx, l, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make([]*options.EngineOptions, 0, len(engines))
+
+	for _, e := range engines {
+		if util.FileExists(e.Source) {
+			continue
+		}
+
+		resolveOpts := opts.Clone()
+		resolveOpts.Engine = e
+
+		if err := engine.ResolveVersion(ctx, resolveOpts); err != nil {
+			return nil, err
+		}
+
+		used = append(used, e)
+	}
+
+	return used, nil
+}
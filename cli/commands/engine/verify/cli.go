@@ -0,0 +1,28 @@
+package verify
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "verify"
+)
+
+func NewFlags(l log.Logger, opts *options.TerragruntOptions) cli.Flags {
+	return run.NewFlags(l, opts, nil).Filter(run.EngineCachePathFlagName)
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "Verify the engines in the engine cache against their checksums.",
+		UsageText: "terragrunt engine verify [options]",
+		Flags:     NewFlags(l, opts),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, opts)
+		},
+	}
+}
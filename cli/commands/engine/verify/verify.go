@@ -0,0 +1,91 @@
+// Package verify implements the terragrunt engine verify command, which verifies the engines in the engine cache
+// against the signed checksums they were released with, and against the checksums locked in the closest lock file.
+package verify
+
+import (
+	"context"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+func Run(_ context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	cached, err := engine.CachedEngines(opts)
+	if err != nil {
+		return err
+	}
+
+	var lockFile *engine.LockFile
+
+	if path := engine.FindLockFile(opts.WorkingDir); path != "" {
+		if lockFile, err = engine.ReadLockFile(path); err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+
+	for _, cachedEngine := range cached {
+		if err := verifyEngine(l, cachedEngine, lockFile); err != nil {
+			l.Errorf("Engine %s failed verification: %v", cachedEngine.Path, err)
+
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return errors.Errorf("%d of %d cached engines failed verification", failed, len(cached))
+	}
+
+	l.Infof("Verified %d cached engines", len(cached))
+
+	return nil
+}
+
+// verifyEngine verifies the cached engine against its signed checksums and the checksum locked for it, if any.
+func verifyEngine(l log.Logger, cachedEngine *engine.CachedEngine, lockFile *engine.LockFile) error {
+	verified := false
+
+	if cachedEngine.HasChecksums() {
+		if err := cachedEngine.Verify(); err != nil {
+			return err
+		}
+
+		verified = true
+	}
+
+	if locked := lockedEngine(cachedEngine, lockFile); locked != nil {
+		if err := cachedEngine.VerifyLocked(locked); err != nil {
+			return err
+		}
+
+		verified = true
+	}
+
+	if !verified {
+		l.Warnf("Engine %s has no checksums to verify against", cachedEngine.Path)
+
+		return nil
+	}
+
+	l.Infof("Engine %s verified", cachedEngine.Path)
+
+	return nil
+}
+
+// lockedEngine returns the engine of the lock file the cached engine was downloaded for, or nil if there is none.
+func lockedEngine(cachedEngine *engine.CachedEngine, lockFile *engine.LockFile) *engine.LockedEngine {
+	if lockFile == nil {
+		return nil
+	}
+
+	for _, locked := range lockFile.Engines {
+		if cachedEngine.Matches(&options.EngineOptions{Source: locked.Source, Type: locked.Type, Version: locked.Version}) {
+			return locked
+		}
+	}
+
+	return nil
+}
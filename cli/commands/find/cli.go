@@ -3,6 +3,8 @@
 package find
 
 import (
+	"strings"
+
 	"github.com/gruntwork-io/terragrunt/cli/flags"
 	"github.com/gruntwork-io/terragrunt/internal/cli"
 	"github.com/gruntwork-io/terragrunt/options"
@@ -28,6 +30,9 @@ const (
 
 	QueueConstructAsFlagName  = "queue-construct-as"
 	QueueConstructAsFlagAlias = "as"
+
+	FilterFlagName = "filter"
+	FieldsFlagName = "fields"
 )
 
 func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
@@ -91,6 +96,18 @@ func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
 			Usage:       "Construct the queue as if a specific command was run.",
 			Aliases:     []string{QueueConstructAsFlagAlias},
 		}),
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FilterFlagName,
+			EnvVars:     tgPrefix.EnvVars(FilterFlagName),
+			Destination: &opts.Filter,
+			Usage:       `Only include configurations matching the given expression, e.g. 'type == "unit" && source =~ "vpc"'.`,
+		}),
+		flags.NewFlag(&cli.SliceFlag[string]{
+			Name:        FieldsFlagName,
+			EnvVars:     tgPrefix.EnvVars(FieldsFlagName),
+			Destination: &opts.Fields,
+			Usage:       "Additional fields to include in the results, separated by commas (only when using --format=json). Valid values: " + strings.Join(Fields, ", ") + ".",
+		}),
 	}
 }
 
@@ -111,6 +128,18 @@ func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
 				cmdOpts.Mode = ModeDAG
 			}
 
+			// Fields can be passed as a comma separated list, as well as by repeating the flag.
+			fields := make([]string, 0, len(cmdOpts.Fields))
+			for _, field := range cmdOpts.Fields {
+				for _, name := range strings.Split(field, ",") {
+					if name = strings.TrimSpace(name); name != "" {
+						fields = append(fields, name)
+					}
+				}
+			}
+
+			cmdOpts.Fields = fields
+
 			// Requesting a specific command to be used for queue construction
 			// implies DAG mode.
 			if cmdOpts.QueueConstructAs != "" {
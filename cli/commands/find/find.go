@@ -3,7 +3,9 @@ package find
 import (
 	"context"
 	"encoding/json"
+	"maps"
 	"path/filepath"
+	"slices"
 
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/telemetry"
@@ -12,13 +14,27 @@ import (
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/discovery"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/filter"
 	"github.com/gruntwork-io/terragrunt/internal/os/stdout"
 	"github.com/gruntwork-io/terragrunt/internal/queue"
 	"github.com/mgutz/ansi"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 )
 
 // Run runs the find command.
 func Run(ctx context.Context, l log.Logger, opts *Options) error {
+	var filterExpr *filter.Expression
+
+	if opts.Filter != "" {
+		expr, err := filter.Parse(opts.Filter)
+		if err != nil {
+			return err
+		}
+
+		filterExpr = expr
+	}
+
 	d := discovery.
 		NewDiscovery(opts.WorkingDir).
 		WithSuppressParseErrors()
@@ -31,6 +47,14 @@ func Run(ctx context.Context, l log.Logger, opts *Options) error {
 		d = d.WithDiscoverDependencies()
 	}
 
+	if (filterExpr != nil && filterExpr.RequiresParse()) || len(opts.Fields) > 0 {
+		d = d.WithParse()
+	}
+
+	if filterExpr != nil && filterExpr.RequiresDependencies() {
+		d = d.WithDiscoverDependencies()
+	}
+
 	if opts.External {
 		d = d.WithDiscoverExternalDependencies()
 	}
@@ -96,6 +120,12 @@ func Run(ctx context.Context, l log.Logger, opts *Options) error {
 		return errors.New("invalid mode: " + opts.Mode)
 	}
 
+	if filterExpr != nil {
+		if cfgs, err = filterExpr.Filter(opts.WorkingDir, cfgs); err != nil {
+			return err
+		}
+	}
+
 	var foundCfgs FoundConfigs
 
 	err = telemetry.TelemeterFromContext(ctx).Collect(ctx, "find_discovered_to_found", map[string]any{
@@ -132,9 +162,37 @@ type FoundConfig struct {
 	Exclude *config.ExcludeConfig `json:"exclude,omitempty"`
 	Include map[string]string     `json:"include,omitempty"`
 
+	Source       *string                    `json:"source,omitempty"`
+	RemoteState  *FoundRemoteState          `json:"remote_state,omitempty"`
+	Inputs       []string                   `json:"inputs,omitempty"`
+	FeatureFlags map[string]json.RawMessage `json:"feature_flags,omitempty"`
+	Engine       *FoundEngine               `json:"engine,omitempty"`
+	Includes     []string                   `json:"includes,omitempty"`
+
 	Dependencies []string `json:"dependencies,omitempty"`
 }
 
+// FoundRemoteState is the location of the state of a found configuration.
+type FoundRemoteState struct {
+	Backend string `json:"backend"`
+	Key     string `json:"key,omitempty"`
+}
+
+// FoundEngine is the engine configuration of a found configuration.
+type FoundEngine struct {
+	Source  string          `json:"source"`
+	Version string          `json:"version,omitempty"`
+	Type    string          `json:"type,omitempty"`
+	Meta    json.RawMessage `json:"meta,omitempty"`
+}
+
+// remoteStateKeyAttrs are the backend config attributes holding the location of the state, for the backends where
+// it isn't `key`.
+var remoteStateKeyAttrs = map[string]string{
+	"gcs":   "prefix",
+	"local": "path",
+}
+
 func discoveredToFound(configs discovery.DiscoveredConfigs, opts *Options) (FoundConfigs, error) {
 	foundCfgs := make(FoundConfigs, 0, len(configs))
 	errs := []error{}
@@ -178,6 +236,12 @@ func discoveredToFound(configs discovery.DiscoveredConfigs, opts *Options) (Foun
 			}
 		}
 
+		if config.Parsed != nil {
+			if err := addFields(foundCfg, config.Parsed, opts); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
 		if !opts.Dependencies || len(config.Dependencies) == 0 {
 			foundCfgs = append(foundCfgs, foundCfg)
 
@@ -203,6 +267,99 @@ func discoveredToFound(configs discovery.DiscoveredConfigs, opts *Options) (Foun
 	return foundCfgs, errors.Join(errs...)
 }
 
+// addFields adds the fields requested with `--fields` to the found configuration.
+func addFields(foundCfg *FoundConfig, cfg *config.TerragruntConfig, opts *Options) error {
+	if opts.HasField(FieldSource) && cfg.Terraform != nil && cfg.Terraform.Source != nil {
+		foundCfg.Source = cfg.Terraform.Source
+	}
+
+	if opts.HasField(FieldRemoteState) && cfg.RemoteState != nil && cfg.RemoteState.Config != nil {
+		keyAttr, ok := remoteStateKeyAttrs[cfg.RemoteState.BackendName]
+		if !ok {
+			keyAttr = "key"
+		}
+
+		key, _ := cfg.RemoteState.BackendConfig[keyAttr].(string)
+
+		foundCfg.RemoteState = &FoundRemoteState{
+			Backend: cfg.RemoteState.BackendName,
+			Key:     key,
+		}
+	}
+
+	if opts.HasField(FieldInputs) && len(cfg.Inputs) > 0 {
+		foundCfg.Inputs = slices.Sorted(maps.Keys(cfg.Inputs))
+	}
+
+	if opts.HasField(FieldFeatureFlags) && len(cfg.FeatureFlags) > 0 {
+		foundCfg.FeatureFlags = make(map[string]json.RawMessage, len(cfg.FeatureFlags))
+
+		for _, flag := range cfg.FeatureFlags {
+			value, err := ctyValueToJSON(flag.Default)
+			if err != nil {
+				return err
+			}
+
+			foundCfg.FeatureFlags[flag.Name] = value
+		}
+	}
+
+	if opts.HasField(FieldEngine) && cfg.Engine != nil {
+		meta, err := ctyValueToJSON(cfg.Engine.Meta)
+		if err != nil {
+			return err
+		}
+
+		if string(meta) == "null" {
+			meta = nil
+		}
+
+		foundCfg.Engine = &FoundEngine{
+			Source: cfg.Engine.Source,
+			Meta:   meta,
+		}
+
+		if cfg.Engine.Version != nil {
+			foundCfg.Engine.Version = *cfg.Engine.Version
+		}
+
+		if cfg.Engine.Type != nil {
+			foundCfg.Engine.Type = *cfg.Engine.Type
+		}
+	}
+
+	if opts.HasField(FieldIncludes) && len(cfg.ProcessedIncludes) > 0 {
+		foundCfg.Includes = make([]string, 0, len(cfg.ProcessedIncludes))
+
+		for _, include := range cfg.ProcessedIncludes {
+			relPath, err := filepath.Rel(opts.WorkingDir, include.Path)
+			if err != nil {
+				return errors.New(err)
+			}
+
+			foundCfg.Includes = append(foundCfg.Includes, relPath)
+		}
+
+		slices.Sort(foundCfg.Includes)
+	}
+
+	return nil
+}
+
+// ctyValueToJSON converts the given value to JSON, or to `null` if there is none.
+func ctyValueToJSON(value *cty.Value) (json.RawMessage, error) {
+	if value == nil || value.IsNull() {
+		return json.RawMessage("null"), nil
+	}
+
+	data, err := ctyjson.Marshal(*value, value.Type())
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return data, nil
+}
+
 // outputJSON outputs the discovered configurations in JSON format.
 func outputJSON(opts *Options, configs FoundConfigs) error {
 	jsonBytes, err := json.MarshalIndent(configs, "", "  ")
@@ -25,7 +25,9 @@ func TestRun(t *testing.T) {
 		name          string
 		format        string
 		mode          string
+		filter        string
 		expectedPaths []string
+		fields        []string
 		hidden        bool
 		dependencies  bool
 		external      bool
@@ -380,6 +382,153 @@ dependency "B" {
 				assert.Equal(t, []string{"B"}, configs[2].Dependencies, "C should depend on B")
 			},
 		},
+		{
+			name: "filter expression",
+			setup: func(t *testing.T) string {
+				t.Helper()
+
+				tmpDir := t.TempDir()
+
+				testDirs := []string{
+					"network",
+					"vpc",
+					"app",
+					"stack1",
+				}
+
+				for _, dir := range testDirs {
+					err := os.MkdirAll(filepath.Join(tmpDir, dir), 0755)
+					require.NoError(t, err)
+				}
+
+				testFiles := map[string]string{
+					"network/terragrunt.hcl": `terraform {
+  source = "git::https://github.com/acme/modules.git//network"
+}`,
+					"vpc/terragrunt.hcl": `terraform {
+  source = "git::https://github.com/acme/modules.git//vpc"
+}
+
+dependency "network" {
+  config_path = "../network"
+  skip_outputs = true
+}`,
+					"app/terragrunt.hcl": `terraform {
+  source = "git::https://github.com/acme/modules.git//app"
+}
+
+dependency "network" {
+  config_path = "../network"
+  skip_outputs = true
+}`,
+					"stack1/terragrunt.stack.hcl": "",
+				}
+
+				for path, content := range testFiles {
+					err := os.WriteFile(filepath.Join(tmpDir, path), []byte(content), 0644)
+					require.NoError(t, err)
+				}
+
+				return tmpDir
+			},
+			expectedPaths: []string{"vpc"},
+			format:        "text",
+			mode:          "normal",
+			filter:        `type == "unit" && source =~ "vpc" && has_dependency("../network")`,
+			validate: func(t *testing.T, output string, expectedPaths []string) {
+				t.Helper()
+
+				lines := strings.Split(strings.TrimSpace(output), "\n")
+				assert.Equal(t, expectedPaths, lines)
+			},
+		},
+		{
+			name: "json output with fields",
+			setup: func(t *testing.T) string {
+				t.Helper()
+
+				tmpDir := t.TempDir()
+
+				testDirs := []string{
+					"vpc",
+					"app",
+				}
+
+				for _, dir := range testDirs {
+					err := os.MkdirAll(filepath.Join(tmpDir, dir), 0755)
+					require.NoError(t, err)
+				}
+
+				testFiles := map[string]string{
+					"root.hcl": `remote_state {
+  backend = "s3"
+  config = {
+    bucket = "state"
+    key    = "${path_relative_to_include()}/terraform.tfstate"
+    region = "us-east-1"
+  }
+}`,
+					"vpc/terragrunt.hcl": `include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+terraform {
+  source = "git::https://github.com/acme/modules.git//vpc"
+}
+
+feature "flow_logs" {
+  default = true
+}
+
+engine {
+  source  = "github.com/gruntwork-io/terragrunt-engine-opentofu"
+  version = "v0.0.15"
+}
+
+inputs = {
+  name       = "vpc"
+  cidr_block = "10.0.0.0/16"
+}`,
+					"app/terragrunt.hcl": "",
+				}
+
+				for path, content := range testFiles {
+					err := os.WriteFile(filepath.Join(tmpDir, path), []byte(content), 0644)
+					require.NoError(t, err)
+				}
+
+				return tmpDir
+			},
+			expectedPaths: []string{"app", "vpc"},
+			format:        "json",
+			mode:          "normal",
+			fields:        []string{find.FieldSource, find.FieldRemoteState, find.FieldInputs, find.FieldFeatureFlags, find.FieldEngine, find.FieldIncludes},
+			validate: func(t *testing.T, output string, expectedPaths []string) {
+				t.Helper()
+
+				var configs find.FoundConfigs
+				err := json.Unmarshal([]byte(output), &configs)
+				require.NoError(t, err)
+				require.Len(t, configs, 2)
+
+				app, vpc := configs[0], configs[1]
+				assert.Equal(t, "app", app.Path)
+				assert.Nil(t, app.Source)
+				assert.Nil(t, app.RemoteState)
+				assert.Empty(t, app.Inputs)
+
+				assert.Equal(t, "vpc", vpc.Path)
+				require.NotNil(t, vpc.Source)
+				assert.Equal(t, "git::https://github.com/acme/modules.git//vpc", *vpc.Source)
+				assert.Equal(t, &find.FoundRemoteState{Backend: "s3", Key: "vpc/terraform.tfstate"}, vpc.RemoteState)
+				assert.Equal(t, []string{"cidr_block", "name"}, vpc.Inputs)
+				assert.JSONEq(t, "true", string(vpc.FeatureFlags["flow_logs"]))
+				require.NotNil(t, vpc.Engine)
+				assert.Equal(t, "github.com/gruntwork-io/terragrunt-engine-opentofu", vpc.Engine.Source)
+				assert.Equal(t, "v0.0.15", vpc.Engine.Version)
+				assert.Equal(t, []string{"root.hcl"}, vpc.Includes)
+			},
+		},
 		{
 			name: "invalid format",
 			setup: func(t *testing.T) string {
@@ -428,6 +577,8 @@ dependency "B" {
 			opts.Mode = tt.mode
 			opts.Dependencies = tt.dependencies
 			opts.External = tt.external
+			opts.Filter = tt.filter
+			opts.Fields = tt.fields
 
 			// Create a pipe to capture output
 			r, w, err := os.Pipe()
@@ -1,7 +1,11 @@
 package find
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/filter"
 	"github.com/gruntwork-io/terragrunt/options"
 )
 
@@ -23,8 +27,36 @@ const (
 
 	// ModeDAG is the mode for the find command that sorts and groups output in DAG order.
 	ModeDAG = "dag"
+
+	// FieldSource adds the source of the terraform block to the JSON output.
+	FieldSource = "source"
+
+	// FieldRemoteState adds the backend and the key of the remote state to the JSON output.
+	FieldRemoteState = "remote_state"
+
+	// FieldInputs adds the names of the inputs to the JSON output.
+	FieldInputs = "inputs"
+
+	// FieldFeatureFlags adds the default values of the feature flags to the JSON output.
+	FieldFeatureFlags = "feature_flags"
+
+	// FieldEngine adds the engine configuration to the JSON output.
+	FieldEngine = "engine"
+
+	// FieldIncludes adds the list of included files to the JSON output.
+	FieldIncludes = "includes"
 )
 
+// Fields are the fields that can be added to the JSON output with `--fields`.
+var Fields = []string{
+	FieldSource,
+	FieldRemoteState,
+	FieldInputs,
+	FieldFeatureFlags,
+	FieldEngine,
+	FieldIncludes,
+}
+
 type Options struct {
 	*options.TerragruntOptions
 
@@ -37,6 +69,12 @@ type Options struct {
 	// QueueConstructAs constructs the queue as if a particular command was run.
 	QueueConstructAs string
 
+	// Filter is an expression selecting the configurations to output.
+	Filter string
+
+	// Fields are the additional fields of the configurations to include in the JSON output.
+	Fields []string
+
 	// JSON determines if the output should be in JSON format.
 	// Alias for --format=json.
 	JSON bool
@@ -80,6 +118,14 @@ func (o *Options) Validate() error {
 		errs = append(errs, err)
 	}
 
+	if err := o.validateFilter(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := o.validateFields(); err != nil {
+		errs = append(errs, err)
+	}
+
 	if len(errs) > 0 {
 		return errors.New(errors.Join(errs...))
 	}
@@ -108,3 +154,28 @@ func (o *Options) validateMode() error {
 		return errors.New("invalid mode: " + o.Mode)
 	}
 }
+
+func (o *Options) validateFilter() error {
+	if o.Filter == "" {
+		return nil
+	}
+
+	_, err := filter.Parse(o.Filter)
+
+	return err
+}
+
+func (o *Options) validateFields() error {
+	for _, field := range o.Fields {
+		if !slices.Contains(Fields, field) {
+			return errors.New("invalid field: " + field + ", valid fields are: " + strings.Join(Fields, ", "))
+		}
+	}
+
+	return nil
+}
+
+// HasField returns true if the given field should be included in the JSON output.
+func (o *Options) HasField(field string) bool {
+	return slices.Contains(o.Fields, field)
+}
@@ -15,6 +15,7 @@ const (
 
 	StrictFlagName         = "strict"
 	InputsFlagName         = "inputs"
+	MockOutputsFlagName    = "mock-outputs"
 	ShowConfigPathFlagName = "show-config-path"
 	JSONFlagName           = "json"
 )
@@ -45,6 +46,12 @@ func NewFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
 			Destination: &opts.HCLValidateInputs,
 			Usage:       "Checks if the Terragrunt configured inputs align with OpenTofu/Terraform defined variables.",
 		}),
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        MockOutputsFlagName,
+			EnvVars:     tgPrefix.EnvVars(MockOutputsFlagName),
+			Destination: &opts.HCLValidateMockOutputs,
+			Usage:       "Checks if the mock outputs of dependencies align with the outputs defined in their OpenTofu/Terraform source.",
+		}),
 		flags.NewFlag(&cli.BoolFlag{
 			Name:        ShowConfigPathFlagName,
 			EnvVars:     tgPrefix.EnvVars(ShowConfigPathFlagName),
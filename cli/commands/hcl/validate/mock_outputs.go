@@ -0,0 +1,191 @@
+package validate
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/experiment"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// RunValidateMockOutputs checks that the mock outputs of every dependency block of the unit are outputs defined in the
+// OpenTofu/Terraform source of the dependency, with a compatible type where it can be inferred.
+func RunValidateMockOutputs(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	parsingCtx := config.NewParsingContext(ctx, l, opts).WithDecodeList(config.DependencyBlock)
+
+	cfg, err := config.PartialParseConfigFile(parsingCtx, l, opts.TerragruntConfigPath, nil)
+	if err != nil {
+		return err
+	}
+
+	var misaligned []string
+
+	for _, dep := range cfg.TerragruntDependencies {
+		if dep.MockOutputs == nil || (dep.Enabled != nil && !*dep.Enabled) {
+			continue
+		}
+
+		depConfigPath := getDependencyConfigPath(opts, dep.ConfigPath.AsString())
+
+		moduleDir, err := getDependencyModuleDir(ctx, l, opts, depConfigPath)
+		if err != nil {
+			return err
+		}
+
+		if moduleDir == "" {
+			l.Warnf("Skipping mock outputs of dependency %s, the source of %s is not downloaded yet", dep.Name, depConfigPath)
+
+			continue
+		}
+
+		outputs, err := config.ParseOutputs(l, opts, moduleDir)
+		if err != nil {
+			return err
+		}
+
+		misaligned = append(misaligned, getMisalignedMockOutputs(dep, outputs)...)
+	}
+
+	if len(misaligned) > 0 {
+		l.Error("The following mock outputs are not aligned with the outputs of the dependencies:\n")
+
+		for _, msg := range misaligned {
+			l.Errorf("\t- %s", msg)
+		}
+
+		l.Error("")
+
+		return errors.New("terragrunt configuration has mock outputs that are not defined by the OpenTofu/Terraform source of dependencies")
+	}
+
+	l.Info("All mock outputs are defined by the dependencies.")
+
+	return nil
+}
+
+// getMisalignedMockOutputs returns a description of every mock output of the given dependency that isn't one of the
+// given outputs, or whose value cannot be converted to the type of the output.
+func getMisalignedMockOutputs(dep config.Dependency, outputs []*config.ParsedOutput) []string {
+	mockOutputs := *dep.MockOutputs
+	if !mockOutputs.IsKnown() || mockOutputs.IsNull() || !mockOutputs.CanIterateElements() {
+		return nil
+	}
+
+	outputTypes := make(map[string]cty.Type, len(outputs))
+	for _, output := range outputs {
+		outputTypes[output.Name] = output.Type
+	}
+
+	// mock_outputs can be an object or a map, e.g. built with `tomap`, so values are looked up in the value map
+	// rather than as attributes
+	mockValues := mockOutputs.AsValueMap()
+
+	names := make([]string, 0, len(mockValues))
+	for name := range mockValues {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var misaligned []string
+
+	for _, name := range names {
+		outputType, ok := outputTypes[name]
+		if !ok {
+			misaligned = append(misaligned, "dependency."+dep.Name+".mock_outputs."+name+" is not an output of the dependency")
+
+			continue
+		}
+
+		if _, err := convert.Convert(mockValues[name], outputType); err != nil {
+			misaligned = append(misaligned, "dependency."+dep.Name+".mock_outputs."+name+" is not a valid "+outputType.FriendlyName()+": "+err.Error())
+		}
+	}
+
+	return misaligned
+}
+
+// getDependencyConfigPath returns the path of the config of the dependency with the given `config_path`.
+func getDependencyConfigPath(opts *options.TerragruntOptions, configPath string) string {
+	if !filepath.IsAbs(configPath) {
+		configPath = filepath.Join(filepath.Dir(opts.TerragruntConfigPath), configPath)
+	}
+
+	if util.IsDir(configPath) {
+		configPath = config.GetDefaultConfigPath(configPath)
+	}
+
+	return util.CleanPath(configPath)
+}
+
+// getDependencyModuleDir returns the directory of the OpenTofu/Terraform source of the dependency with the given
+// config path: the directory of the config if it has no source, the directory of a local source, or the download
+// directory of a remote source. Returns an empty string if a remote source is not downloaded yet.
+func getDependencyModuleDir(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, depConfigPath string) (string, error) {
+	l, depOpts, err := opts.CloneWithConfigPath(l, depConfigPath)
+	if err != nil {
+		return "", err
+	}
+
+	depDir := filepath.Dir(depConfigPath)
+
+	// The source of the dependency is downloaded to its own download dir, unless a download dir is set explicitly
+	if opts.DownloadDir == filepath.Join(filepath.Dir(opts.TerragruntConfigPath), util.TerragruntCacheDir) {
+		depOpts.DownloadDir = filepath.Join(depDir, util.TerragruntCacheDir)
+	}
+
+	parsingCtx := config.NewParsingContext(ctx, l, depOpts).WithDecodeList(config.TerraformSource)
+
+	depCfg, err := config.PartialParseConfigFile(parsingCtx, l, depConfigPath, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// `--source` only applies to the unit being validated
+	depOpts.Source = ""
+
+	source, err := config.GetTerraformSourceURL(depOpts, depCfg)
+	if err != nil {
+		return "", err
+	}
+
+	if source == "" || source == "." {
+		return depDir, nil
+	}
+
+	sourceURL, err := tf.ToSourceURL(source, depDir)
+	if err != nil {
+		return "", err
+	}
+
+	rootSourceURL, modulePath, err := tf.SplitSourceURL(l, sourceURL)
+	if err != nil {
+		return "", err
+	}
+
+	if tf.IsLocalSource(rootSourceURL) {
+		return filepath.Join(rootSourceURL.Path, modulePath), nil
+	}
+
+	walkWithSymlinks := depOpts.Experiments.Evaluate(experiment.Symlinks)
+
+	terraformSource, err := tf.NewSource(l, source, depOpts.DownloadDir, depDir, walkWithSymlinks)
+	if err != nil {
+		return "", err
+	}
+
+	if !util.IsDir(terraformSource.WorkingDir) {
+		return "", nil
+	}
+
+	return terraformSource.WorkingDir, nil
+}
@@ -43,9 +43,25 @@ func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) err
 			return errors.Errorf("specifying both -%s and -%s is invalid", JSONFlagName, InputsFlagName)
 		}
 
+		if opts.HCLValidateMockOutputs {
+			return errors.Errorf("specifying both -%s and -%s is invalid", MockOutputsFlagName, InputsFlagName)
+		}
+
 		return RunValidateInputs(ctx, l, opts)
 	}
 
+	if opts.HCLValidateMockOutputs {
+		if opts.HCLValidateShowConfigPath {
+			return errors.Errorf("specifying both -%s and -%s is invalid", ShowConfigPathFlagName, MockOutputsFlagName)
+		}
+
+		if opts.HCLValidateJSONOutput {
+			return errors.Errorf("specifying both -%s and -%s is invalid", JSONFlagName, MockOutputsFlagName)
+		}
+
+		return RunValidateMockOutputs(ctx, l, opts)
+	}
+
 	if opts.HCLValidateStrict {
 		return errors.Errorf("specifying -%s without -%s is invalid", StrictFlagName, InputsFlagName)
 	}
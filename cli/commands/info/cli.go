@@ -2,6 +2,7 @@
 package info
 
 import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/info/history"
 	"github.com/gruntwork-io/terragrunt/cli/commands/info/print"
 	"github.com/gruntwork-io/terragrunt/cli/commands/info/strict"
 	"github.com/gruntwork-io/terragrunt/internal/cli"
@@ -20,6 +21,7 @@ func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
 		Subcommands: cli.Commands{
 			strict.NewCommand(l, opts),
 			print.NewCommand(l, opts),
+			history.NewCommand(l, opts),
 		},
 		Action: cli.ShowCommandHelp,
 	}
@@ -0,0 +1,45 @@
+package history
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "history"
+
+	LimitFlagName = "limit"
+
+	// DefaultLimit is the number of past runs compared by default.
+	DefaultLimit = 10
+)
+
+func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	flags := cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[int]{
+			Name:        LimitFlagName,
+			EnvVars:     tgPrefix.EnvVars("history-" + LimitFlagName),
+			Usage:       "The number of past runs to compare. Default is 10.",
+			Destination: &opts.ReportHistoryLimit,
+		}),
+	}
+
+	return append(flags, run.NewFlags(l, opts, nil).Filter(run.DownloadDirFlagName)...)
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "Show units that regressed, got slower or are flaky across the run history.",
+		UsageText: "terragrunt info history",
+		Flags:     NewFlags(l, opts, nil),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, opts)
+		},
+	}
+}
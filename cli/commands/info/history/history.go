@@ -0,0 +1,87 @@
+// Package history implements the 'terragrunt info history' command that compares the latest run
+// stored in the run history against the runs before it, showing units that regressed, got slower,
+// or are flaky.
+package history
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/common/runall"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+func Run(_ context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	limit := opts.ReportHistoryLimit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	history := report.NewHistory(runall.ReportHistoryPath(opts))
+
+	entries, err := history.Read(limit)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	l.Debugf("Read %d runs from run history %s", len(entries), history.Path())
+
+	if len(entries) < 2 { //nolint:mnd
+		l.Infof("Not enough runs in the run history %s to compare. Enable the run history with --report-history.", history.Path())
+
+		return nil
+	}
+
+	return writeTrends(opts.Writer, opts.WorkingDir, report.CompareHistory(entries))
+}
+
+func writeTrends(w io.Writer, workingDir string, trends *report.Trends) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Compared the latest run to the %d runs before it.\n", trends.Entries-1)
+
+	fmt.Fprintf(&b, "\nRegressions: %d\n", len(trends.Regressions))
+
+	for _, regression := range trends.Regressions {
+		fmt.Fprintf(&b, "  %s: %s -> %s\n", unitName(workingDir, regression.Name), regression.Previous, regression.Latest)
+	}
+
+	fmt.Fprintf(&b, "\nSlower: %d\n", len(trends.Slowdowns))
+
+	for _, slowdown := range trends.Slowdowns {
+		fmt.Fprintf(&b, "  %s: %s (average %s)\n", unitName(workingDir, slowdown.Name), slowdown.Latest.Round(time.Millisecond), slowdown.Average.Round(time.Millisecond))
+	}
+
+	fmt.Fprintf(&b, "\nFlaky: %d\n", len(trends.Flaky))
+
+	for _, flaky := range trends.Flaky {
+		results := make([]string, len(flaky.Results))
+		for i, result := range flaky.Results {
+			results[i] = string(result)
+		}
+
+		fmt.Fprintf(&b, "  %s: %s\n", unitName(workingDir, flaky.Name), strings.Join(results, ", "))
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// unitName returns the path of the unit relative to the working directory, if possible.
+func unitName(workingDir, path string) string {
+	if rel, err := filepath.Rel(workingDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+
+	return path
+}
@@ -29,6 +29,8 @@ const (
 
 	QueueConstructAsFlagName  = "queue-construct-as"
 	QueueConstructAsFlagAlias = "as"
+
+	FilterFlagName = "filter"
 )
 
 func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
@@ -87,6 +89,12 @@ func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
 			Usage:       "Construct the queue as if a specific command was run.",
 			Aliases:     []string{QueueConstructAsFlagAlias},
 		}),
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FilterFlagName,
+			EnvVars:     tgPrefix.EnvVars(FilterFlagName),
+			Destination: &opts.Filter,
+			Usage:       `Only include configurations matching the given expression, e.g. 'type == "unit" && source =~ "vpc"'.`,
+		}),
 	}
 }
 
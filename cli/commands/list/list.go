@@ -14,6 +14,7 @@ import (
 	"github.com/charmbracelet/x/term"
 	"github.com/gruntwork-io/terragrunt/internal/discovery"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/filter"
 	"github.com/gruntwork-io/terragrunt/internal/os/stdout"
 	"github.com/gruntwork-io/terragrunt/internal/queue"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
@@ -22,6 +23,17 @@ import (
 
 // Run runs the list command.
 func Run(ctx context.Context, l log.Logger, opts *Options) error {
+	var filterExpr *filter.Expression
+
+	if opts.Filter != "" {
+		expr, err := filter.Parse(opts.Filter)
+		if err != nil {
+			return err
+		}
+
+		filterExpr = expr
+	}
+
 	d := discovery.
 		NewDiscovery(opts.WorkingDir).
 		WithSuppressParseErrors()
@@ -34,6 +46,14 @@ func Run(ctx context.Context, l log.Logger, opts *Options) error {
 		d = d.WithDiscoverDependencies()
 	}
 
+	if filterExpr != nil && filterExpr.RequiresParse() {
+		d = d.WithParse()
+	}
+
+	if filterExpr != nil && filterExpr.RequiresDependencies() {
+		d = d.WithDiscoverDependencies()
+	}
+
 	if opts.External {
 		d = d.WithDiscoverExternalDependencies()
 	}
@@ -92,6 +112,12 @@ func Run(ctx context.Context, l log.Logger, opts *Options) error {
 		return errors.New("invalid mode: " + opts.Mode)
 	}
 
+	if filterExpr != nil {
+		if cfgs, err = filterExpr.Filter(opts.WorkingDir, cfgs); err != nil {
+			return err
+		}
+	}
+
 	var listedCfgs ListedConfigs
 
 	err = telemetry.TelemeterFromContext(ctx).Collect(ctx, "list_discovered_to_listed", map[string]any{
@@ -181,6 +181,90 @@ func TestHiddenDiscovery(t *testing.T) {
 	assert.ElementsMatch(t, expectedPaths, fields)
 }
 
+func TestFilterDiscovery(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	// Create test directory structure
+	testDirs := []string{
+		"prod/vpc",
+		"prod/app",
+		"stage/vpc",
+		"stack1",
+	}
+
+	for _, dir := range testDirs {
+		err := os.MkdirAll(filepath.Join(tmpDir, dir), 0755)
+		require.NoError(t, err)
+	}
+
+	// Create test files
+	remoteState := func(bucket string) string {
+		return `remote_state {
+  backend = "s3"
+  config = {
+    bucket = "` + bucket + `"
+    key    = "terraform.tfstate"
+    region = "us-east-1"
+  }
+}`
+	}
+
+	testFiles := map[string]string{
+		"prod/vpc/terragrunt.hcl":     remoteState("prod-state"),
+		"prod/app/terragrunt.hcl":     remoteState("prod-state"),
+		"stage/vpc/terragrunt.hcl":    remoteState("stage-state"),
+		"stack1/terragrunt.stack.hcl": "",
+	}
+
+	for path, content := range testFiles {
+		err := os.WriteFile(filepath.Join(tmpDir, path), []byte(content), 0644)
+		require.NoError(t, err)
+	}
+
+	expectedPaths := []string{"prod/vpc", "stage/vpc"}
+
+	tgOpts := options.NewTerragruntOptions()
+	tgOpts.WorkingDir = tmpDir
+
+	l := logger.CreateLogger()
+	l.Formatter().SetDisabledColors(true)
+
+	// Create options
+	opts := list.NewOptions(tgOpts)
+	opts.Format = "text"
+	opts.Filter = `remote_state.bucket =~ "-state$" && path =~ "/vpc$"`
+
+	// Create a pipe to capture output
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	// Set the writer in options
+	opts.Writer = w
+
+	err = list.Run(t.Context(), l, opts)
+	require.NoError(t, err)
+
+	// Close the write end of the pipe
+	w.Close()
+
+	// Read all output
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	// Split output into fields and trim whitespace
+	fields := strings.Fields(string(output))
+
+	// Normalize path separators in the output fields
+	for i, field := range fields {
+		fields[i] = filepath.ToSlash(field)
+	}
+
+	// Verify all expected paths are present
+	assert.ElementsMatch(t, expectedPaths, fields)
+}
+
 func TestDAGSortingSimpleDependencies(t *testing.T) {
 	t.Parallel()
 
@@ -2,6 +2,7 @@ package list
 
 import (
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/filter"
 	"github.com/gruntwork-io/terragrunt/options"
 )
 
@@ -40,6 +41,9 @@ type Options struct {
 	// QueueConstructAs constructs the queue as if a particular command was run.
 	QueueConstructAs string
 
+	// Filter is an expression selecting the configurations to output.
+	Filter string
+
 	// Hidden determines whether to detect hidden directories.
 	Hidden bool
 
@@ -79,6 +83,10 @@ func (o *Options) Validate() error {
 		errs = append(errs, err)
 	}
 
+	if err := o.validateFilter(); err != nil {
+		errs = append(errs, err)
+	}
+
 	if len(errs) > 0 {
 		return errors.New(errors.Join(errs...))
 	}
@@ -109,3 +117,13 @@ func (o *Options) validateMode() error {
 		return errors.New("invalid mode: " + o.Mode)
 	}
 }
+
+func (o *Options) validateFilter() error {
+	if o.Filter == "" {
+		return nil
+	}
+
+	_, err := filter.Parse(o.Filter)
+
+	return err
+}
@@ -0,0 +1,29 @@
+// Package providercache provides commands for managing the provider cache directory of the Terragrunt Provider Cache.
+package providercache
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/provider-cache/prune"
+	"github.com/gruntwork-io/terragrunt/cli/commands/provider-cache/stats"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "provider-cache"
+)
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	prefix := flags.Prefix{CommandName}
+
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "Manage the providers cached by the Terragrunt Provider Cache.",
+		Subcommands: cli.Commands{
+			stats.NewCommand(l, opts, prefix),
+			prune.NewCommand(l, opts, prefix),
+		},
+		Action: cli.ShowCommandHelp,
+	}
+}
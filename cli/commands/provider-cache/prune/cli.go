@@ -0,0 +1,74 @@
+package prune
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	CommandName = "prune"
+
+	KeepLatestFlagName = "keep-latest"
+	UnusedForFlagName  = "unused-for"
+	DryRunFlagName     = "dry-run"
+)
+
+func NewFlags(l log.Logger, opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	flags := cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[int]{
+			Name:        KeepLatestFlagName,
+			EnvVars:     tgPrefix.EnvVars(KeepLatestFlagName),
+			Destination: &opts.KeepLatest,
+			Usage:       "Keep the given number of latest versions of every provider.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:    UnusedForFlagName,
+			EnvVars: tgPrefix.EnvVars(UnusedForFlagName),
+			Usage:   "Only remove the providers unused for longer than the given duration, e.g. '30d'.",
+			Action: func(_ *cli.Context, val string) error {
+				duration, err := util.ParseDuration(val)
+				if err != nil {
+					return err
+				}
+
+				opts.UnusedFor = duration
+
+				return nil
+			},
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        DryRunFlagName,
+			EnvVars:     tgPrefix.EnvVars(DryRunFlagName),
+			Destination: &opts.DryRun,
+			Usage:       "List the providers that would be removed, without removing them. Implied unless --keep-latest or --unused-for is set.",
+		}),
+	}
+
+	return append(flags, run.NewFlags(l, opts.TerragruntOptions, nil).Filter(run.ProviderCacheDirFlagName)...)
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) *cli.Command {
+	cmdOpts := NewOptions(opts)
+	prefix = prefix.Append(CommandName)
+
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "Remove the cached providers not referenced by the lock file of any unit.",
+		UsageText: "terragrunt provider-cache prune [options]",
+		Flags:     NewFlags(l, cmdOpts, prefix),
+		Before: func(_ *cli.Context) error {
+			return cmdOpts.Validate()
+		},
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, cmdOpts)
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package prune
+
+import (
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+type Options struct {
+	*options.TerragruntOptions
+
+	// KeepLatest is the number of latest versions of every provider kept, whether they are referenced or not.
+	KeepLatest int
+
+	// UnusedFor keeps the providers used more recently than the given duration.
+	UnusedFor time.Duration
+
+	// DryRun lists the providers that would be removed, without removing them.
+	DryRun bool
+}
+
+// IsLimited returns true if the providers removed are limited by `--keep-latest` or `--unused-for`.
+func (o *Options) IsLimited() bool {
+	return o.KeepLatest > 0 || o.UnusedFor > 0
+}
+
+func NewOptions(opts *options.TerragruntOptions) *Options {
+	return &Options{
+		TerragruntOptions: opts,
+	}
+}
+
+func (o *Options) Validate() error {
+	if o.KeepLatest < 0 {
+		return errors.Errorf("invalid --%s value %d, expected a positive number", KeepLatestFlagName, o.KeepLatest)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// Package prune implements the terragrunt provider-cache prune command, which removes the cached providers that are
+// not referenced by the lock file of any unit.
+package prune
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/provider-cache/stats"
+	"github.com/gruntwork-io/terragrunt/internal/providercache"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf/cache/services"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+func Run(ctx context.Context, l log.Logger, opts *Options) error {
+	cacheDir, err := providercache.CacheDir(opts.TerragruntOptions)
+	if err != nil {
+		return err
+	}
+
+	cached, err := services.CachedProviders(cacheDir)
+	if err != nil {
+		return err
+	}
+
+	refs, err := stats.DiscoverReferences(ctx, l, opts.TerragruntOptions)
+	if err != nil {
+		return err
+	}
+
+	var (
+		removed     int
+		removedSize int64
+		dryRun      = opts.DryRun
+	)
+
+	// The provider cache is shared by all units of the user, while only the lock files of the units in the working
+	// directory are taken into account, so without a limit the providers are only listed.
+	if !dryRun && !opts.IsLimited() {
+		l.Warnf("Neither --%s nor --%s is set, listing the providers that would be removed without removing them", KeepLatestFlagName, UnusedForFlagName)
+
+		dryRun = true
+	}
+
+	for _, provider := range prunable(cached, refs, opts) {
+		removed++
+		removedSize += provider.Size
+
+		if dryRun {
+			l.Infof("Would remove provider %s %s (%s)", provider.Address, provider.Version, provider.Platform)
+
+			continue
+		}
+
+		if err := provider.Remove(cacheDir); err != nil {
+			return err
+		}
+
+		l.Infof("Removed provider %s %s (%s)", provider.Address, provider.Version, provider.Platform)
+	}
+
+	if dryRun {
+		l.Infof("%d of %d cached providers (%s) would be removed", removed, len(cached), util.FormatByteSize(removedSize))
+
+		return nil
+	}
+
+	l.Infof("Removed %d of %d cached providers (%s)", removed, len(cached), util.FormatByteSize(removedSize))
+
+	return nil
+}
+
+// prunable returns the cached providers to remove: the ones not referenced by any unit, not among the latest
+// versions kept, and unused for longer than the given duration. The cached providers are sorted with the latest
+// versions first.
+func prunable(cached []*services.CachedProvider, refs stats.References, opts *Options) []*services.CachedProvider {
+	var (
+		providers []*services.CachedProvider
+		// versions are the versions of every provider, latest first
+		versions = make(map[string][]string)
+	)
+
+	for _, provider := range cached {
+		if n := len(versions[provider.Address]); n == 0 || versions[provider.Address][n-1] != provider.Version {
+			versions[provider.Address] = append(versions[provider.Address], provider.Version)
+		}
+
+		if len(refs.Units(provider.Address, provider.Version)) > 0 {
+			continue
+		}
+
+		if len(versions[provider.Address]) <= opts.KeepLatest {
+			continue
+		}
+
+		if opts.UnusedFor > 0 && time.Since(provider.LastUsed) < opts.UnusedFor {
+			continue
+		}
+
+		providers = append(providers, provider)
+	}
+
+	return providers
+}
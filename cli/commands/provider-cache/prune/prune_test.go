@@ -0,0 +1,135 @@
+package prune
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/provider-cache/stats"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/gruntwork-io/terragrunt/tf/cache/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrunable(t *testing.T) {
+	t.Parallel()
+
+	const aws = "registry.terraform.io/hashicorp/aws"
+
+	now := time.Now()
+
+	// sorted the same way as services.CachedProviders, with the latest versions first
+	cached := []*services.CachedProvider{
+		{Address: aws, Version: "5.3.0", Platform: "darwin_arm64", LastUsed: now},
+		{Address: aws, Version: "5.3.0", Platform: "linux_amd64", LastUsed: now},
+		{Address: aws, Version: "5.2.0", Platform: "linux_amd64", LastUsed: now.Add(-60 * 24 * time.Hour)},
+		{Address: aws, Version: "5.1.0", Platform: "linux_amd64", LastUsed: now.Add(-10 * 24 * time.Hour)},
+		{Address: aws, Version: "5.0.0", Platform: "linux_amd64", LastUsed: now.Add(-60 * 24 * time.Hour)},
+	}
+
+	refs := stats.References{aws: {"5.0.0": {"unit"}}}
+
+	testCases := []struct {
+		name     string
+		opts     *Options
+		expected []string
+	}{
+		{
+			name:     "unreferenced",
+			opts:     &Options{},
+			expected: []string{"5.3.0", "5.3.0", "5.2.0", "5.1.0"},
+		},
+		{
+			name:     "keep latest",
+			opts:     &Options{KeepLatest: 2},
+			expected: []string{"5.1.0"},
+		},
+		{
+			name:     "unused for",
+			opts:     &Options{UnusedFor: 30 * 24 * time.Hour},
+			expected: []string{"5.2.0"},
+		},
+		{
+			name:     "keep latest and unused for",
+			opts:     &Options{KeepLatest: 3, UnusedFor: 7 * 24 * time.Hour},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var versions []string
+			for _, provider := range prunable(cached, refs, tc.opts) {
+				versions = append(versions, provider.Version)
+			}
+
+			assert.Equal(t, tc.expected, versions)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		opts     Options
+		expected []string
+	}{
+		{
+			name:     "no limit",
+			opts:     Options{},
+			expected: []string{"5.1.0", "5.0.0"},
+		},
+		{
+			name:     "dry run",
+			opts:     Options{KeepLatest: 1, DryRun: true},
+			expected: []string{"5.1.0", "5.0.0"},
+		},
+		{
+			name:     "keep latest",
+			opts:     Options{KeepLatest: 1},
+			expected: []string{"5.1.0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cacheDir := t.TempDir()
+
+			for _, version := range []string{"5.1.0", "5.0.0"} {
+				dir := filepath.Join(cacheDir, "registry.terraform.io", "hashicorp", "aws", version, "linux_amd64")
+				require.NoError(t, os.MkdirAll(dir, 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "terraform-provider"), []byte("provider binary"), 0555))
+			}
+
+			terragruntOptions, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+			require.NoError(t, err)
+
+			terragruntOptions.WorkingDir = filepath.Dir(terragruntOptions.TerragruntConfigPath)
+			terragruntOptions.ProviderCacheDir = cacheDir
+
+			opts := tc.opts
+			opts.TerragruntOptions = terragruntOptions
+
+			require.NoError(t, Run(t.Context(), logger.CreateLogger(), &opts))
+
+			cached, err := services.CachedProviders(cacheDir)
+			require.NoError(t, err)
+
+			var versions []string
+			for _, provider := range cached {
+				versions = append(versions, provider.Version)
+			}
+
+			assert.Equal(t, tc.expected, versions)
+		})
+	}
+}
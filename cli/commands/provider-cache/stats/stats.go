@@ -0,0 +1,173 @@
+// Package stats implements the terragrunt provider-cache stats command, which reports the disk usage of the cached
+// providers by provider, version and platform, and the units referencing them in their lock files.
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/discovery"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/providercache"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf/cache/services"
+	"github.com/gruntwork-io/terragrunt/tf/getproviders"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// lastUsedFormat is the format of the last use of the cached providers in text output.
+const lastUsedFormat = "2006-01-02 15:04"
+
+// References are the units referencing every provider version in their lock file, by provider address and version.
+type References map[string]map[string][]string
+
+// Units returns the units referencing the given provider version.
+func (refs References) Units(address, version string) []string {
+	return refs[address][version]
+}
+
+// DiscoverReferences discovers the units of the working directory and reads the provider versions locked in their
+// lock files. Units are referenced by their path relative to the working directory.
+func DiscoverReferences(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) (References, error) {
+	cfgs, err := discovery.NewDiscovery(opts.WorkingDir).Discover(ctx, l, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make(References)
+
+	for _, cfg := range cfgs.Filter(discovery.ConfigTypeUnit).Sort() {
+		locked, err := getproviders.LockedProviders(cfg.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		unit := cfg.Path
+		if rel, err := filepath.Rel(opts.WorkingDir, cfg.Path); err == nil {
+			unit = rel
+		}
+
+		for address, version := range locked {
+			if refs[address] == nil {
+				refs[address] = make(map[string][]string)
+			}
+
+			refs[address][version] = append(refs[address][version], unit)
+		}
+	}
+
+	return refs, nil
+}
+
+func Run(ctx context.Context, l log.Logger, opts *Options) error {
+	cacheDir, err := providercache.CacheDir(opts.TerragruntOptions)
+	if err != nil {
+		return err
+	}
+
+	cached, err := services.CachedProviders(cacheDir)
+	if err != nil {
+		return err
+	}
+
+	refs, err := DiscoverReferences(ctx, l, opts.TerragruntOptions)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format == FormatJSON {
+		return writeJSON(opts.Writer, cached, refs)
+	}
+
+	return writeText(opts.Writer, cached, refs)
+}
+
+func writeText(w io.Writer, cached []*services.CachedProvider, refs References) error {
+	var (
+		b         strings.Builder
+		totalSize int64
+		providers = make(map[string]struct{})
+	)
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0) //nolint:mnd
+	fmt.Fprintln(tw, "Provider\tVersion\tPlatform\tSize\tLast Used\tUnits")
+
+	for _, provider := range cached {
+		size := util.FormatByteSize(provider.Size)
+		if provider.Linked {
+			size = "linked"
+		}
+
+		units := "-"
+		if refUnits := refs.Units(provider.Address, provider.Version); len(refUnits) > 0 {
+			units = strings.Join(refUnits, ", ")
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", provider.Address, provider.Version, provider.Platform, size, provider.LastUsed.Format(lastUsedFormat), units)
+
+		totalSize += provider.Size
+		providers[provider.Address] = struct{}{}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return errors.New(err)
+	}
+
+	fmt.Fprintf(&b, "\n%d packages of %d providers, %s in total\n", len(cached), len(providers), util.FormatByteSize(totalSize))
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// jsonProvider is the JSON representation of a cached provider.
+type jsonProvider struct {
+	LastUsed time.Time `json:"last_used"`
+	Address  string    `json:"address"`
+	Version  string    `json:"version"`
+	Platform string    `json:"platform"`
+	Path     string    `json:"path"`
+	Units    []string  `json:"units"`
+	Size     int64     `json:"size"`
+	Linked   bool      `json:"linked"`
+}
+
+func writeJSON(w io.Writer, cached []*services.CachedProvider, refs References) error {
+	out := make([]jsonProvider, 0, len(cached))
+
+	for _, provider := range cached {
+		units := refs.Units(provider.Address, provider.Version)
+		if units == nil {
+			units = []string{}
+		}
+
+		out = append(out, jsonProvider{
+			LastUsed: provider.LastUsed,
+			Address:  provider.Address,
+			Version:  provider.Version,
+			Platform: provider.Platform,
+			Path:     provider.Path,
+			Units:    units,
+			Size:     provider.Size,
+			Linked:   provider.Linked,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(out); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
@@ -3,13 +3,16 @@ package run
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/go-getter"
 
 	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/cas"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/experiment"
 	"github.com/gruntwork-io/terragrunt/options"
@@ -28,6 +31,9 @@ const tfLintConfig = ".tflint.hcl"
 
 const fileURIScheme = "file://"
 
+// casGetters are the getters of the sources ingested into the CAS when the cas experiment is enabled.
+var casGetters = []string{"tfr", "http", "https", "s3", "gcs"} //nolint:gochecknoglobals
+
 // 1. Download the given source URL, which should use Terraform's module source syntax, into a temporary folder
 // 2. Check if module directory exists in temporary folder
 // 3. Copy the contents of terragruntOptions.WorkingDir into the temporary folder.
@@ -275,10 +281,56 @@ func downloadSource(ctx context.Context, l log.Logger, src *tf.Source, opts *opt
 		src.DownloadDir)
 
 	return opts.RunWithErrorHandling(ctx, l, func() error {
+		if opts.Experiments.Evaluate(experiment.CAS) && isCASSource(src.CanonicalSourceURL) {
+			return downloadSourceWithCAS(ctx, l, src, opts, cfg)
+		}
+
 		return getter.GetAny(src.DownloadDir, src.CanonicalSourceURL.String(), UpdateGetters(opts, cfg))
 	})
 }
 
+// isCASSource returns true if the given source URL is downloaded with one of the casGetters.
+func isCASSource(sourceURL *url.URL) bool {
+	getterName, _, _ := strings.Cut(sourceURL.Scheme, "::")
+
+	return slices.Contains(casGetters, getterName)
+}
+
+// downloadSourceWithCAS downloads the source into a temporary directory, ingests it into the CAS and links it into
+// the download dir, so that a module version used by many units is stored once.
+func downloadSourceWithCAS(ctx context.Context, l log.Logger, src *tf.Source, opts *options.TerragruntOptions, cfg *config.TerragruntConfig) error {
+	c, err := cas.New(cas.Options{MaxSize: opts.CASMaxSize, Remote: opts.CASRemote})
+	if err != nil {
+		return err
+	}
+
+	tempDir, err := os.MkdirTemp("", "terragrunt-cas-download-")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			l.Warnf("Failed to remove temp dir %s: %v", tempDir, err)
+		}
+	}()
+
+	sourceDir := filepath.Join(tempDir, "source")
+
+	if err := getter.GetAny(sourceDir, src.CanonicalSourceURL.String(), UpdateGetters(opts, cfg)); err != nil {
+		return err
+	}
+
+	hash, err := c.Ingest(ctx, &l, sourceDir)
+	if err != nil {
+		return err
+	}
+
+	l.Debugf("Linking CAS tree %s into %s", hash, src.DownloadDir)
+
+	return c.Link(ctx, &l, hash, src.DownloadDir)
+}
+
 // ValidateWorkingDir checks if working terraformSource.WorkingDir exists and is directory
 func ValidateWorkingDir(terraformSource *tf.Source) error {
 	workingLocalDir := strings.ReplaceAll(terraformSource.WorkingDir, terraformSource.DownloadDir+filepath.FromSlash("/"), "")
@@ -1,9 +1,14 @@
 package run_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path"
@@ -22,6 +27,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/experiment"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/hashicorp/go-getter"
@@ -532,3 +538,53 @@ func TestUpdateGettersExcludeFromCopy(t *testing.T) {
 		})
 	}
 }
+
+func TestDownloadTerraformSourceIfNecessaryArchiveWithCAS(t *testing.T) {
+	// The CAS store is in the home directory.
+	t.Setenv("HOME", t.TempDir())
+
+	var archive bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	contents := []byte("# Hello, World")
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "main.tf", Mode: 0644, Size: int64(len(contents))}))
+	_, err := tarWriter.Write(contents)
+	require.NoError(t, err)
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(archive.Bytes()) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	canonicalURL := server.URL + "/module.tar.gz"
+
+	var downloadDirs []string
+
+	for range 2 {
+		downloadDir := tmpDir(t)
+
+		terraformSource, terragruntOptions, terragruntConfig, err := createConfig(t, canonicalURL, downloadDir, false)
+		require.NoError(t, err)
+		require.NoError(t, terragruntOptions.Experiments.EnableExperiment(experiment.CAS))
+
+		err = run.DownloadTerraformSourceIfNecessary(t.Context(), logger.CreateLogger(), terraformSource, terragruntOptions, terragruntConfig)
+		require.NoError(t, err)
+
+		assert.Equal(t, string(contents), readFile(t, filepath.Join(downloadDir, "main.tf")))
+
+		downloadDirs = append(downloadDirs, downloadDir)
+	}
+
+	// both units link the same file from the CAS
+	first, err := os.Stat(filepath.Join(downloadDirs[0], "main.tf"))
+	require.NoError(t, err)
+
+	second, err := os.Stat(filepath.Join(downloadDirs[1], "main.tf"))
+	require.NoError(t, err)
+
+	assert.True(t, os.SameFile(first, second))
+}
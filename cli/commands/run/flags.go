@@ -25,6 +25,9 @@ const (
 	InputsDebugFlagName                    = "inputs-debug"
 	UnitsThatIncludeFlagName               = "units-that-include"
 	DependencyFetchOutputFromStateFlagName = "dependency-fetch-output-from-state"
+	DependencyOutputCacheFlagName          = "dependency-output-cache"
+	DependencyOutputCacheTTLFlagName       = "dependency-output-cache-ttl"
+	DependencyOutputCacheDirFlagName       = "dependency-output-cache-dir"
 	UsePartialParseConfigCacheFlagName     = "use-partial-parse-config-cache"
 
 	BackendBootstrapFlagName        = "backend-bootstrap"
@@ -59,6 +62,7 @@ const (
 	QueueIncludeExternalFlagName     = "queue-include-external"
 	QueueStrictIncludeFlagName       = "queue-strict-include"
 	QueueIncludeUnitsReadingFlagName = "queue-include-units-reading"
+	QueueIncludeChangedSinceFlagName = "queue-include-changed-since"
 
 	// Terragrunt Provider Cache related flags.
 
@@ -76,9 +80,18 @@ const (
 	EngineSkipCheckFlagName = "engine-skip-check"
 	EngineLogLevelFlagName  = "engine-log-level"
 
+	// CAS related flags.
+
+	CASMaxSizeFlagName = "cas-max-size"
+	CASRemoteFlagName  = "cas-remote"
+
 	// Report related flags.
 
 	SummaryDisableFlagName = "summary-disable"
+	ReportFileFlagName     = "report-file"
+	ReportFormatFlagName   = "report-format"
+	ReportHistoryFlagName  = "report-history"
+	ResumeFromFlagName     = "resume-from"
 
 	// `--all` related flags.
 
@@ -343,6 +356,36 @@ func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames("fetch-dependency-output-from-state"), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        DependencyOutputCacheFlagName,
+			EnvVars:     tgPrefix.EnvVars(DependencyOutputCacheFlagName),
+			Destination: &opts.DependencyOutputCache,
+			Usage:       "Cache dependency outputs on disk across runs, until the state of the dependency changes.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:    DependencyOutputCacheTTLFlagName,
+			EnvVars: tgPrefix.EnvVars(DependencyOutputCacheTTLFlagName),
+			Usage:   "How long cached dependency outputs are valid for, such as 30m or 1d. Required to cache the outputs of dependencies whose backend cannot tell whether the state changed.",
+			Action: func(_ *cli.Context, val string) error {
+				ttl, err := util.ParseDuration(val)
+				if err != nil {
+					return err
+				}
+
+				opts.DependencyOutputCacheTTL = ttl
+
+				return nil
+			},
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        DependencyOutputCacheDirFlagName,
+			EnvVars:     tgPrefix.EnvVars(DependencyOutputCacheDirFlagName),
+			Destination: &opts.DependencyOutputCacheDir,
+			Usage:       "The directory dependency outputs are cached in. By default, 'terragrunt/dependency-outputs' folder in the user cache directory.",
+		}),
+
 		flags.NewFlag(&cli.BoolFlag{
 			Name:        TFForwardStdoutFlagName,
 			EnvVars:     tgPrefix.EnvVars(TFForwardStdoutFlagName),
@@ -385,6 +428,13 @@ func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames("queue-include-units-reading"), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        QueueIncludeChangedSinceFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueIncludeChangedSinceFlagName),
+			Destination: &opts.ChangedSince,
+			Usage:       "If flag is set, 'run --all' will only run the command against Terragrunt units affected by the changes since the specified git ref, and the units that depend on them.",
+		}),
+
 		flags.NewFlag(&cli.BoolFlag{
 			Name:        BackendBootstrapFlagName,
 			EnvVars:     tgPrefix.EnvVars(BackendBootstrapFlagName),
@@ -535,12 +585,63 @@ func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames("engine-log-level"), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:    CASMaxSizeFlagName,
+			EnvVars: tgPrefix.EnvVars(CASMaxSizeFlagName),
+			Usage:   "Maximum size of the CAS store, e.g. '10GB'. When exceeded, the least recently used content is evicted.",
+			Action: func(_ *cli.Context, val string) error {
+				size, err := util.ParseByteSize(val)
+				if err != nil {
+					return err
+				}
+
+				opts.CASMaxSize = size
+
+				return nil
+			},
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        CASRemoteFlagName,
+			EnvVars:     tgPrefix.EnvVars(CASRemoteFlagName),
+			Destination: &opts.CASRemote,
+			Usage:       "Shared tier of the CAS store, either a directory or an s3://bucket/prefix URL, read from and written to when storing content in the CAS.",
+		}),
+
 		flags.NewFlag(&cli.BoolFlag{
 			Name:        SummaryDisableFlagName,
 			EnvVars:     tgPrefix.EnvVars(SummaryDisableFlagName),
 			Destination: &opts.SummaryDisable,
 			Usage:       `Disable the summary output at the end of a run.`,
 		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        ReportFileFlagName,
+			EnvVars:     tgPrefix.EnvVars(ReportFileFlagName),
+			Destination: &opts.ReportFile,
+			Usage:       "Path to the file the run report is written to.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        ReportFormatFlagName,
+			EnvVars:     tgPrefix.EnvVars(ReportFormatFlagName),
+			Destination: &opts.ReportFormat,
+			Usage:       "Format of the run report. Valid values: csv, json, junit. Determined by the extension of the report file by default.",
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        ReportHistoryFlagName,
+			EnvVars:     tgPrefix.EnvVars(ReportHistoryFlagName),
+			Destination: &opts.ReportHistory,
+			Usage:       "Append the run report to the run history stored in the download dir.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        ResumeFromFlagName,
+			EnvVars:     tgPrefix.EnvVars(ResumeFromFlagName),
+			Destination: &opts.ResumeFrom,
+			Usage:       "Path to the report of a prior run. Units that succeeded in that run are skipped, unless a unit they depend on is run again.",
+		}),
 	}
 
 	return flags.Sort()
@@ -160,6 +160,10 @@ func WriteToFile(l log.Logger, opts *options.TerragruntOptions, basePath string,
 		contentsToWrite = hclwrite.Format(contentsToWrite)
 	}
 
+	if err := util.RemoveHardLink(targetPath); err != nil {
+		return err
+	}
+
 	const ownerWriteGlobalReadPerms = 0644
 	if err := os.WriteFile(targetPath, contentsToWrite, ownerWriteGlobalReadPerms); err != nil {
 		return errors.New(err)
@@ -187,7 +187,20 @@ func (cfg *TerragruntConfig) GetRemoteState(l log.Logger, opts *options.Terragru
 	if sourceURL != "" {
 		walkWithSymlinks := opts.Experiments.Evaluate(experiment.Symlinks)
 
-		tfSource, err := tf.NewSource(l, sourceURL, opts.DownloadDir, opts.WorkingDir, walkWithSymlinks)
+		// The state of units with a source is located relative to the working dir OpenTofu/Terraform runs in, which
+		// is in the download dir set in the config, unless it was set on the command line, the same way `run` does.
+		downloadDir := opts.DownloadDir
+
+		_, defaultDownloadDir, err := options.DefaultWorkingAndDownloadDirs(opts.TerragruntConfigPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if downloadDir == defaultDownloadDir && cfg.DownloadDir != "" {
+			downloadDir = cfg.DownloadDir
+		}
+
+		tfSource, err := tf.NewSource(l, sourceURL, downloadDir, opts.WorkingDir, walkWithSymlinks)
 		if err != nil {
 			return nil, err
 		}
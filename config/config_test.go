@@ -865,6 +865,81 @@ terraform {
 	assert.Equal(t, "foo", *terragruntConfig.Terraform.Source)
 }
 
+func TestGetRemoteStateWorkingDir(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		cfg         string
+		downloadDir string
+	}{
+		{
+			name: "no source",
+			cfg: `
+remote_state {
+	backend = "local"
+	config  = {}
+}
+`,
+		},
+		{
+			name: "source",
+			cfg: `
+terraform {
+	source = "../module"
+}
+
+remote_state {
+	backend = "local"
+	config  = {}
+}
+`,
+			downloadDir: util.TerragruntCacheDir,
+		},
+		{
+			name: "source with download dir",
+			cfg: `
+terraform {
+	source = "../module"
+}
+
+download_dir = "${get_terragrunt_dir()}/cache"
+
+remote_state {
+	backend = "local"
+	config  = {}
+}
+`,
+			downloadDir: "cache",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			unitDir := filepath.Join(t.TempDir(), "unit")
+			require.NoError(t, os.MkdirAll(unitDir, os.ModePerm))
+
+			configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+			require.NoError(t, os.WriteFile(configPath, []byte(tc.cfg), 0644))
+
+			opts := mockOptionsForTestWithConfigPath(t, configPath)
+
+			remoteState, err := config.ParseRemoteState(t.Context(), createLogger(), opts)
+			require.NoError(t, err)
+			require.NotNil(t, remoteState)
+
+			// the state is located relative to the working dir OpenTofu/Terraform runs in
+			if tc.downloadDir == "" {
+				assert.Equal(t, filepath.ToSlash(unitDir), filepath.ToSlash(opts.WorkingDir))
+			} else {
+				assert.True(t, strings.HasPrefix(opts.WorkingDir, filepath.ToSlash(filepath.Join(unitDir, tc.downloadDir))+"/"), opts.WorkingDir)
+			}
+		})
+	}
+}
+
 func TestParseTerragruntConfigTerraformWithExtraArguments(t *testing.T) {
 	t.Parallel()
 
@@ -4,25 +4,22 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 
-	"github.com/gruntwork-io/terragrunt/awshelper"
 	"github.com/gruntwork-io/terragrunt/internal/cache"
 	"github.com/gruntwork-io/terragrunt/internal/experiment"
+	"github.com/gruntwork-io/terragrunt/internal/outputcache"
 	"github.com/gruntwork-io/terragrunt/internal/remotestate"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 
-	s3backend "github.com/gruntwork-io/terragrunt/internal/remotestate/backend/s3"
-
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/go-getter"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/gocty"
@@ -810,17 +807,86 @@ func getTerragruntOutputJSON(ctx *ParsingContext, l log.Logger, targetConfig str
 
 	ctx.TerragruntOptions.Engine = engineOpts
 
-	if isInit {
-		return getTerragruntOutputJSONFromInitFolder(ctx, l, workingDir, remoteStateTGConfig.GetIAMRoleOptions())
+	fetchOutputJSON := func() ([]byte, error) {
+		if isInit {
+			return getTerragruntOutputJSONFromInitFolder(ctx, l, workingDir, remoteStateTGConfig.GetIAMRoleOptions())
+		}
+
+		return getTerragruntOutputJSONFromRemoteState(
+			ctx,
+			l,
+			targetConfig,
+			remoteStateTGConfig.RemoteState,
+			remoteStateTGConfig.GetIAMRoleOptions(),
+		)
 	}
 
-	return getTerragruntOutputJSONFromRemoteState(
-		ctx,
-		l,
-		targetConfig,
-		remoteStateTGConfig.RemoteState,
-		remoteStateTGConfig.GetIAMRoleOptions(),
+	if ctx.TerragruntOptions.DependencyOutputCache {
+		return getOutputJSONWithDiskCache(ctx, l, targetConfig, remoteStateTGConfig, fetchOutputJSON)
+	}
+
+	return fetchOutputJSON()
+}
+
+// getOutputJSONWithDiskCache returns the outputs of the target config cached on disk for the location of its state, as long
+// as the state hasn't changed since. If the backend cannot tell whether the state changed, the cached outputs are used
+// while they are younger than the TTL instead. Otherwise, it fetches the outputs and caches them.
+func getOutputJSONWithDiskCache(ctx *ParsingContext, l log.Logger, targetConfig string, remoteStateTGConfig *TerragruntConfig, fetchOutputJSON func() ([]byte, error)) ([]byte, error) {
+	var (
+		remoteState = remoteStateTGConfig.RemoteState
+		ttl         = ctx.TerragruntOptions.DependencyOutputCacheTTL
 	)
+
+	store, err := outputcache.New(ctx.TerragruntOptions.DependencyOutputCacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := outputcache.Key(targetConfig, remoteState.BackendName, remoteState.BackendConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	targetTGOptions, err := setupTerragruntOptionsForBareTerraform(ctx, l, filepath.Dir(targetConfig), targetConfig, remoteStateTGConfig.GetIAMRoleOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	// The entity tag is read before the outputs, so that the outputs are never older than the tag they are cached with
+	etag, err := remoteState.StateETag(ctx, l, targetTGOptions)
+
+	switch {
+	case err == nil:
+		// The backend tells whether the state changed, so the TTL doesn't apply
+		ttl = 0
+	case !errors.As(err, new(backend.StateAccessNotImplementedError)):
+		l.Warnf("Failed to read the state entity tag of %s, not caching its outputs: %v", targetConfig, err)
+
+		return fetchOutputJSON()
+	}
+
+	if entry := store.Get(key); entry != nil && entry.IsValid(etag, ttl) {
+		l.Debugf("Using outputs of %s cached at %s", targetConfig, entry.CachedAt.Format(time.RFC3339))
+
+		return entry.Outputs, nil
+	}
+
+	jsonBytes, err := fetchOutputJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	if etag == "" && ttl == 0 {
+		l.Debugf("Not caching outputs of %s, the %s backend cannot tell whether the state changed and no TTL is set", targetConfig, remoteState.BackendName)
+
+		return jsonBytes, nil
+	}
+
+	if err := store.Put(key, &outputcache.Entry{CachedAt: time.Now(), ETag: etag, Outputs: jsonBytes}); err != nil {
+		l.Warnf("Failed to cache outputs of %s: %v", targetConfig, err)
+	}
+
+	return jsonBytes, nil
 }
 
 // canGetRemoteState returns true if the remote state block is not nil and dependency optimization is not disabled
@@ -944,23 +1010,18 @@ func getTerragruntOutputJSONFromRemoteState(
 
 	// To speed up dependencies processing it is possible to retrieve its output directly from the backend without init dependencies
 	if ctx.TerragruntOptions.FetchDependencyOutputFromState {
-		switch backend := remoteState.BackendName; backend {
-		case s3backend.BackendName:
-			jsonBytes, err := getTerragruntOutputJSONFromRemoteStateS3(
-				l,
-				targetTGOptions,
-				remoteState,
-			)
-			if err != nil {
-				return nil, err
-			}
-
-			l.Debugf("Retrieved output from %s as json: %s using s3 bucket", targetTGOptions.TerragruntConfigPath, jsonBytes)
+		jsonBytes, err := remoteState.ReadOutputs(ctx, l, targetTGOptions)
+		if err == nil {
+			l.Debugf("Retrieved output from %s as json: %s using %s backend", targetTGOptions.TerragruntConfigPath, jsonBytes, remoteState.BackendName)
 
 			return jsonBytes, nil
-		default:
-			l.Errorf("FetchDependencyOutputFromState is not supported for backend %s, falling back to normal method", backend)
 		}
+
+		if !errors.As(err, new(backend.StateAccessNotImplementedError)) {
+			return nil, err
+		}
+
+		l.Errorf("FetchDependencyOutputFromState is not supported for backend %s, falling back to normal method", remoteState.BackendName)
 	}
 
 	// Generate the backend configuration in the working dir. If no generate config is set on the remote state block,
@@ -1004,59 +1065,6 @@ func getTerragruntOutputJSONFromRemoteState(
 	return jsonBytes, nil
 }
 
-// getTerragruntOutputJSONFromRemoteStateS3 pulls the output directly from an S3 bucket without calling Terraform
-func getTerragruntOutputJSONFromRemoteStateS3(l log.Logger, opts *options.TerragruntOptions, remoteState *remotestate.RemoteState) ([]byte, error) {
-	l.Debugf("Fetching outputs directly from s3://%s/%s", remoteState.BackendConfig["bucket"], remoteState.BackendConfig["key"])
-
-	s3ConfigExtended, err := s3backend.Config(remoteState.BackendConfig).ParseExtendedS3Config()
-	if err != nil {
-		return nil, err
-	}
-
-	sessionConfig := s3ConfigExtended.GetAwsSessionConfig()
-
-	s3Client, err := awshelper.CreateS3Client(l, sessionConfig, opts)
-	if err != nil {
-		return nil, err
-	}
-
-	result, err := s3Client.GetObject(&s3.GetObjectInput{
-		Bucket: aws.String(fmt.Sprintf("%s", remoteState.BackendConfig["bucket"])),
-		Key:    aws.String(fmt.Sprintf("%s", remoteState.BackendConfig["key"])),
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			l.Warnf("Failed to close remote state response %v", err)
-		}
-	}(result.Body)
-
-	steateBody, err := io.ReadAll(result.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	jsonState := string(steateBody)
-	jsonMap := make(map[string]any)
-
-	err = json.Unmarshal([]byte(jsonState), &jsonMap)
-	if err != nil {
-		return nil, err
-	}
-
-	jsonOutputs, err := json.Marshal(jsonMap["outputs"])
-	if err != nil {
-		return nil, err
-	}
-
-	return jsonOutputs, nil
-}
-
 // setupTerragruntOptionsForBareTerraform sets up a new TerragruntOptions struct that can be used to run terraform
 // without going through the full RunTerragrunt operation.
 func setupTerragruntOptionsForBareTerraform(ctx *ParsingContext, l log.Logger, workingDir string, configPath string, iamRoleOpts options.IAMRoleOptions) (*options.TerragruntOptions, error) {
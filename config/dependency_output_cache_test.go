@@ -0,0 +1,91 @@
+package config
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/remotestate"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// etagBackend is a backend which returns the given entity tag of the state.
+type etagBackend struct {
+	*backend.CommonBackend
+	etag string
+}
+
+func (backend *etagBackend) StateETag(ctx context.Context, l log.Logger, config backend.Config, opts *options.TerragruntOptions) (string, error) {
+	return backend.etag, nil
+}
+
+func TestGetOutputJSONWithDiskCache(t *testing.T) {
+	etagRemote := &etagBackend{CommonBackend: backend.NewCommonBackend("etag"), etag: `"v1"`}
+	remotestate.RegisterBackend(etagRemote)
+
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		backendName string
+		// expected are the outputs returned by each of the three calls, between which the state is written.
+		expected []string
+	}{
+		{
+			name:        "etag",
+			backendName: "etag",
+			expected:    []string{"1", "1", "2"},
+		},
+		{
+			name:        "no etag",
+			backendName: "unknown",
+			expected:    []string{"1", "1", "1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			targetConfig := filepath.Join(t.TempDir(), "dep", "terragrunt.hcl")
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+			require.NoError(t, err)
+
+			opts.DependencyOutputCacheDir = t.TempDir()
+			opts.DependencyOutputCacheTTL = time.Hour
+
+			var (
+				l       = logger.CreateLogger()
+				ctx     = NewParsingContext(t.Context(), l, opts)
+				outputs = "1"
+				cfg     = &TerragruntConfig{RemoteState: remotestate.New(&remotestate.Config{BackendName: tc.backendName, BackendConfig: map[string]any{}})}
+			)
+
+			fetchOutputJSON := func() ([]byte, error) {
+				return []byte(outputs), nil
+			}
+
+			for i, expected := range tc.expected {
+				if i == 2 {
+					// the state is written within the TTL
+					outputs = "2"
+
+					if tc.backendName == "etag" {
+						etagRemote.etag = `"v2"`
+					}
+				}
+
+				actual, err := getOutputJSONWithDiskCache(ctx, l, targetConfig, cfg, fetchOutputJSON)
+				require.NoError(t, err)
+				assert.Equal(t, expected, string(actual))
+			}
+		})
+	}
+}
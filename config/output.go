@@ -0,0 +1,115 @@
+package config
+
+import (
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/experiment"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ParsedOutput structure with output name and the type of its value.
+type ParsedOutput struct {
+	Name string
+	// Type is the type of the output value, or `cty.DynamicPseudoType` if it can't be inferred without evaluating
+	// the module.
+	Type cty.Type
+}
+
+// ParseOutputs - parse outputs from tf files.
+//
+// The type of an output is only inferred if its value is a primitive literal, or a reference to a variable with a
+// type constraint.
+func ParseOutputs(l log.Logger, opts *options.TerragruntOptions, directoryPath string) ([]*ParsedOutput, error) {
+	walkWithSymlinks := opts.Experiments.Evaluate(experiment.Symlinks)
+
+	tfFiles, err := util.ListTfFiles(directoryPath, walkWithSymlinks)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	parser := hclparse.NewParser(DefaultParserOptions(l, opts)...)
+
+	for _, tfFile := range tfFiles {
+		if _, err := parser.ParseFromFile(tfFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		outputBlocks []*hclsyntax.Block
+		varTypes     = make(map[string]cty.Type)
+	)
+
+	for _, file := range parser.Files() {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if len(block.Labels) == 0 {
+				continue
+			}
+
+			switch block.Type {
+			case "output":
+				outputBlocks = append(outputBlocks, block)
+			case "variable":
+				if attr, ok := block.Body.Attributes["type"]; ok {
+					if varType, diags := typeexpr.TypeConstraint(attr.Expr); !diags.HasErrors() {
+						varTypes[block.Labels[0]] = varType
+					}
+				}
+			}
+		}
+	}
+
+	outputs := make([]*ParsedOutput, 0, len(outputBlocks))
+
+	for _, block := range outputBlocks {
+		output := &ParsedOutput{
+			Name: block.Labels[0],
+			Type: cty.DynamicPseudoType,
+		}
+
+		if attr, ok := block.Body.Attributes["value"]; ok {
+			output.Type = inferOutputType(attr.Expr, varTypes)
+		}
+
+		outputs = append(outputs, output)
+	}
+
+	return outputs, nil
+}
+
+// inferOutputType returns the type of the given output value expression, if it is a primitive literal or a reference
+// to a variable with a type constraint, and `cty.DynamicPseudoType` otherwise.
+func inferOutputType(expr hcl.Expression, varTypes map[string]cty.Type) cty.Type {
+	if len(expr.Variables()) == 0 {
+		value, diags := expr.Value(nil)
+		if !diags.HasErrors() && value.Type().IsPrimitiveType() {
+			return value.Type()
+		}
+
+		return cty.DynamicPseudoType
+	}
+
+	traversal, diags := hcl.AbsTraversalForExpr(expr)
+	if diags.HasErrors() || len(traversal) != 2 || traversal.RootName() != "var" { //nolint:mnd
+		return cty.DynamicPseudoType
+	}
+
+	if attr, ok := traversal[1].(hcl.TraverseAttr); ok {
+		if varType, ok := varTypes[attr.Name]; ok {
+			return varType
+		}
+	}
+
+	return cty.DynamicPseudoType
+}
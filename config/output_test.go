@@ -0,0 +1,31 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestParseOutputs(t *testing.T) {
+	t.Parallel()
+
+	opts := terragruntOptionsForTest(t, "")
+
+	outputs, err := config.ParseOutputs(logger.CreateLogger(), opts, "../test/fixtures/validate-mock-outputs/modules/vpc")
+	require.NoError(t, err)
+	assert.Len(t, outputs, 4)
+
+	outputByName := map[string]*config.ParsedOutput{}
+	for _, output := range outputs {
+		outputByName[output.Name] = output
+	}
+
+	assert.Equal(t, cty.String, outputByName["vpc_id"].Type)
+	assert.Equal(t, cty.String, outputByName["cidr_block"].Type)
+	assert.Equal(t, cty.List(cty.String), outputByName["subnet_ids"].Type)
+	assert.Equal(t, cty.DynamicPseudoType, outputByName["tags"].Type)
+}
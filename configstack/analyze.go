@@ -0,0 +1,252 @@
+package configstack
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// UnitTiming is the timing of a single unit in a DAG analysis.
+type UnitTiming struct {
+	Path string
+	// Duration is how long the unit takes to run.
+	Duration time.Duration
+	// EarliestStart is the earliest the unit can start, given unlimited parallelism.
+	EarliestStart time.Duration
+	// LatestStart is the latest the unit can start without delaying the whole run.
+	LatestStart time.Duration
+	// Slack is how much the unit can be delayed without delaying the whole run.
+	Slack time.Duration
+	// HasDuration is false when no duration is known for the unit, in which case it's assumed to take no time.
+	HasDuration bool
+	// Critical is true when the unit has no slack, so that delaying it delays the whole run.
+	Critical bool
+}
+
+// DAGAnalysis is the critical path and timing analysis of the dependency DAG.
+type DAGAnalysis struct {
+	// Units are the timings of all the units, in the order they can start.
+	Units []*UnitTiming
+	// CriticalPath is the longest chain of dependent units, which determines the shortest possible run.
+	CriticalPath []*UnitTiming
+	// CriticalPathDuration is the time the run takes with unlimited parallelism.
+	CriticalPathDuration time.Duration
+	// TotalDuration is the time all the units take when run one after another.
+	TotalDuration time.Duration
+	// Parallelism is the number of units that are allowed to run at the same time.
+	Parallelism int
+	// MinWallTime is the lower bound of the time the run takes at the given parallelism.
+	MinWallTime time.Duration
+	// EstimatedWallTime is the time the run takes at the given parallelism,
+	// when the units with the longest path ahead of them are started first.
+	EstimatedWallTime time.Duration
+}
+
+// Analyze computes the critical path, the slack of every unit and the wall time of running the modules at the given
+// parallelism, using the given durations of the units by path. Excluded modules are left out of the analysis.
+func (modules TerraformModules) Analyze(durations map[string]time.Duration, parallelism int) (*DAGAnalysis, error) {
+	if err := modules.CheckForCycles(); err != nil {
+		return nil, err
+	}
+
+	if parallelism < 1 {
+		return nil, errors.Errorf("parallelism must be at least 1, got %d", parallelism)
+	}
+
+	var (
+		analysis = &DAGAnalysis{Parallelism: parallelism}
+		timings  = make(map[string]*UnitTiming)
+		order    TerraformModules
+		visited  = make(map[string]bool)
+	)
+
+	// Order the modules so that every module comes after its dependencies.
+	var visit func(module *TerraformModule)
+
+	visit = func(module *TerraformModule) {
+		if visited[module.Path] {
+			return
+		}
+
+		visited[module.Path] = true
+
+		for _, dependency := range module.Dependencies {
+			visit(dependency)
+		}
+
+		if !module.FlagExcluded {
+			order = append(order, module)
+		}
+	}
+
+	for _, module := range modules {
+		visit(module)
+	}
+
+	dependents := make(map[string][]*UnitTiming)
+
+	for _, module := range order {
+		duration, ok := durations[module.Path]
+
+		timing := &UnitTiming{
+			Path:        module.Path,
+			Duration:    duration,
+			HasDuration: ok,
+		}
+
+		for _, dependency := range module.Dependencies {
+			if depTiming, ok := timings[dependency.Path]; ok {
+				timing.EarliestStart = max(timing.EarliestStart, depTiming.EarliestStart+depTiming.Duration)
+				dependents[dependency.Path] = append(dependents[dependency.Path], timing)
+			}
+		}
+
+		timings[module.Path] = timing
+		analysis.Units = append(analysis.Units, timing)
+		analysis.TotalDuration += duration
+		analysis.CriticalPathDuration = max(analysis.CriticalPathDuration, timing.EarliestStart+duration)
+	}
+
+	for i := len(analysis.Units) - 1; i >= 0; i-- {
+		timing := analysis.Units[i]
+		latestFinish := analysis.CriticalPathDuration
+
+		for _, dependent := range dependents[timing.Path] {
+			latestFinish = min(latestFinish, dependent.LatestStart)
+		}
+
+		timing.LatestStart = latestFinish - timing.Duration
+		timing.Slack = timing.LatestStart - timing.EarliestStart
+		timing.Critical = timing.Slack == 0
+	}
+
+	analysis.CriticalPath = criticalPath(order, timings, analysis.CriticalPathDuration)
+
+	slices.SortStableFunc(analysis.Units, func(a, b *UnitTiming) int {
+		return cmp.Compare(a.EarliestStart, b.EarliestStart)
+	})
+
+	analysis.MinWallTime = max(analysis.CriticalPathDuration, analysis.TotalDuration/time.Duration(parallelism))
+	analysis.EstimatedWallTime = simulateRun(order, timings, parallelism)
+
+	return analysis, nil
+}
+
+// criticalPath walks back from the unit that finishes last, following the dependency that finishes last.
+func criticalPath(order TerraformModules, timings map[string]*UnitTiming, total time.Duration) []*UnitTiming {
+	var last *TerraformModule
+
+	for _, module := range order {
+		timing := timings[module.Path]
+		if timing.EarliestStart+timing.Duration == total {
+			last = module
+		}
+	}
+
+	var path []*UnitTiming
+
+	for module := last; module != nil; {
+		timing := timings[module.Path]
+		path = append(path, timing)
+
+		var next *TerraformModule
+
+		for _, dependency := range module.Dependencies {
+			if depTiming, ok := timings[dependency.Path]; ok && depTiming.EarliestStart+depTiming.Duration == timing.EarliestStart {
+				next = dependency
+				break
+			}
+		}
+
+		module = next
+	}
+
+	slices.Reverse(path)
+
+	return path
+}
+
+// simulateRun returns the wall time of running the units at the given parallelism. Whenever a slot is free,
+// the ready unit with the longest path ahead of it is started, breaking ties by path.
+func simulateRun(order TerraformModules, timings map[string]*UnitTiming, parallelism int) time.Duration {
+	var (
+		// remaining is the number of unfinished dependencies of every unit.
+		remaining = make(map[string]int)
+		// tail is the longest path from the start of a unit to the end of the run.
+		tail       = make(map[string]time.Duration)
+		dependents = make(map[string]TerraformModules)
+		ready      TerraformModules
+	)
+
+	for _, module := range order {
+		for _, dependency := range module.Dependencies {
+			if _, ok := timings[dependency.Path]; ok {
+				remaining[module.Path]++
+				dependents[dependency.Path] = append(dependents[dependency.Path], module)
+			}
+		}
+	}
+
+	for i := len(order) - 1; i >= 0; i-- {
+		module := order[i]
+
+		var longest time.Duration
+		for _, dependent := range dependents[module.Path] {
+			longest = max(longest, tail[dependent.Path])
+		}
+
+		tail[module.Path] = timings[module.Path].Duration + longest
+	}
+
+	for _, module := range order {
+		if remaining[module.Path] == 0 {
+			ready = append(ready, module)
+		}
+	}
+
+	type runningUnit struct {
+		module *TerraformModule
+		end    time.Duration
+	}
+
+	var (
+		now     time.Duration
+		running []runningUnit
+	)
+
+	for len(ready) > 0 || len(running) > 0 {
+		slices.SortFunc(ready, func(a, b *TerraformModule) int {
+			if c := cmp.Compare(tail[b.Path], tail[a.Path]); c != 0 {
+				return c
+			}
+
+			return cmp.Compare(a.Path, b.Path)
+		})
+
+		for len(ready) > 0 && len(running) < parallelism {
+			running = append(running, runningUnit{module: ready[0], end: now + timings[ready[0].Path].Duration})
+			ready = ready[1:]
+		}
+
+		// Advance to the next unit that finishes.
+		slices.SortFunc(running, func(a, b runningUnit) int {
+			return cmp.Compare(a.end, b.end)
+		})
+
+		finished := running[0]
+		running = running[1:]
+		now = finished.end
+
+		for _, dependent := range dependents[finished.module.Path] {
+			remaining[dependent.Path]--
+
+			if remaining[dependent.Path] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	return now
+}
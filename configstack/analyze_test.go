@@ -0,0 +1,103 @@
+package configstack_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze(t *testing.T) {
+	t.Parallel()
+
+	// a -> b -> d
+	// c ------> d
+	moduleA := &configstack.TerraformModule{Path: "a"}
+	moduleB := &configstack.TerraformModule{Path: "b", Dependencies: configstack.TerraformModules{moduleA}}
+	moduleC := &configstack.TerraformModule{Path: "c"}
+	moduleD := &configstack.TerraformModule{Path: "d", Dependencies: configstack.TerraformModules{moduleB, moduleC}}
+	moduleE := &configstack.TerraformModule{Path: "e", FlagExcluded: true}
+
+	modules := configstack.TerraformModules{moduleD, moduleC, moduleB, moduleA, moduleE}
+
+	durations := map[string]time.Duration{
+		"a": time.Minute,
+		"b": 3 * time.Minute,
+		"c": 2 * time.Minute,
+		"d": time.Minute,
+		"e": time.Hour,
+	}
+
+	tc := []struct {
+		name              string
+		parallelism       int
+		expectedMin       time.Duration
+		expectedEstimated time.Duration
+	}{
+		{
+			name:              "unlimited parallelism",
+			parallelism:       10,
+			expectedMin:       5 * time.Minute,
+			expectedEstimated: 5 * time.Minute,
+		},
+		{
+			name:              "no parallelism",
+			parallelism:       1,
+			expectedMin:       7 * time.Minute,
+			expectedEstimated: 7 * time.Minute,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			analysis, err := modules.Analyze(durations, tt.parallelism)
+			require.NoError(t, err)
+
+			assert.Equal(t, 5*time.Minute, analysis.CriticalPathDuration)
+			assert.Equal(t, 7*time.Minute, analysis.TotalDuration)
+			assert.Equal(t, tt.expectedMin, analysis.MinWallTime)
+			assert.Equal(t, tt.expectedEstimated, analysis.EstimatedWallTime)
+
+			var criticalPath []string
+			for _, unit := range analysis.CriticalPath {
+				criticalPath = append(criticalPath, unit.Path)
+			}
+
+			assert.Equal(t, []string{"a", "b", "d"}, criticalPath)
+
+			slack := make(map[string]time.Duration)
+			for _, unit := range analysis.Units {
+				slack[unit.Path] = unit.Slack
+			}
+
+			assert.Equal(t, map[string]time.Duration{
+				"a": 0,
+				"b": 0,
+				"c": 2 * time.Minute,
+				"d": 0,
+			}, slack)
+		})
+	}
+}
+
+func TestAnalyzeUnknownDuration(t *testing.T) {
+	t.Parallel()
+
+	moduleA := &configstack.TerraformModule{Path: "a"}
+	moduleB := &configstack.TerraformModule{Path: "b", Dependencies: configstack.TerraformModules{moduleA}}
+
+	analysis, err := configstack.TerraformModules{moduleA, moduleB}.Analyze(map[string]time.Duration{"a": time.Minute}, 1)
+	require.NoError(t, err)
+
+	require.Len(t, analysis.Units, 2)
+	assert.True(t, analysis.Units[0].HasDuration)
+	assert.False(t, analysis.Units[1].HasDuration)
+	assert.Equal(t, time.Minute, analysis.CriticalPathDuration)
+
+	_, err = configstack.TerraformModules{moduleA}.Analyze(nil, 0)
+	require.Error(t, err)
+}
@@ -442,12 +442,50 @@ func (stack *DefaultStack) ResolveTerraformModules(ctx context.Context, l log.Lo
 		return nil, err
 	}
 
+	var withUnitsChanged TerraformModules
+
+	err = telemetry.TelemeterFromContext(ctx).Collect(ctx, "flag_units_changed_since", map[string]any{
+		"working_dir": stack.terragruntOptions.WorkingDir,
+	}, func(ctx context.Context) error {
+		result, err := withUnitsRead.flagUnitsChangedSince(ctx, l, stack.terragruntOptions)
+		if err != nil {
+			return err
+		}
+
+		withUnitsChanged = result
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
 	var withModulesExcluded TerraformModules
 
 	err = telemetry.TelemeterFromContext(ctx).Collect(ctx, "flag_excluded_dirs", map[string]any{
 		"working_dir": stack.terragruntOptions.WorkingDir,
 	}, func(_ context.Context) error {
-		withModulesExcluded = withUnitsRead.flagExcludedDirs(stack.terragruntOptions)
+		withModulesExcluded = withUnitsChanged.flagExcludedDirs(stack.terragruntOptions)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var withUnitsResumed TerraformModules
+
+	err = telemetry.TelemeterFromContext(ctx).Collect(ctx, "flag_resumed_units", map[string]any{
+		"working_dir": stack.terragruntOptions.WorkingDir,
+	}, func(_ context.Context) error {
+		result, err := withModulesExcluded.flagResumedUnits(l, stack.terragruntOptions)
+		if err != nil {
+			return err
+		}
+
+		withUnitsResumed = result
+
 		return nil
 	})
 
@@ -455,7 +493,7 @@ func (stack *DefaultStack) ResolveTerraformModules(ctx context.Context, l log.Lo
 		return nil, err
 	}
 
-	return withModulesExcluded, nil
+	return withUnitsResumed, nil
 }
 
 // Go through each of the given Terragrunt configuration files and resolve the module that configuration file represents
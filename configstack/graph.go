@@ -0,0 +1,342 @@
+package configstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	// GraphFormatDOT outputs the graph in Graphviz DOT language.
+	GraphFormatDOT = "dot"
+	// GraphFormatMermaid outputs the graph as a Mermaid flowchart.
+	GraphFormatMermaid = "mermaid"
+	// GraphFormatJSON outputs the graph as JSON nodes and edges.
+	GraphFormatJSON = "json"
+	// GraphFormatHTML outputs the graph as a self-contained interactive HTML page.
+	GraphFormatHTML = "html"
+)
+
+// GraphFormats returns all the supported graph formats.
+func GraphFormats() []string {
+	return []string{GraphFormatDOT, GraphFormatMermaid, GraphFormatJSON, GraphFormatHTML}
+}
+
+// nodeTypeUnit is the type of the graph nodes that are units.
+const nodeTypeUnit = "unit"
+
+// GraphNode is a unit in the exported graph.
+type GraphNode struct {
+	// ID is the path of the unit relative to the directory of the Terragrunt configuration.
+	ID string `json:"id"`
+	// Path is the absolute path of the unit.
+	Path string `json:"path"`
+	// Type is the type of the node. Currently always "unit".
+	Type string `json:"type"`
+	// External is true when the unit is outside of the working directory.
+	External bool `json:"external"`
+	// Excluded is true when the unit is excluded from the run.
+	Excluded bool `json:"excluded"`
+	// AssumeAlreadyApplied is true when the unit is an external dependency that is assumed to be applied already.
+	AssumeAlreadyApplied bool `json:"assume_already_applied"`
+}
+
+// GraphEdge is a dependency between two units in the exported graph, pointing from the dependent unit to its dependency.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Graph is the exported representation of the dependency graph of the modules.
+type Graph struct {
+	Nodes []*GraphNode `json:"nodes"`
+	Edges []*GraphEdge `json:"edges"`
+}
+
+// WriteGraph writes the graph of the modules to the given writer in the given format.
+func (modules TerraformModules) WriteGraph(l log.Logger, w io.Writer, opts *options.TerragruntOptions, format string) error {
+	switch format {
+	case GraphFormatDOT, "":
+		return modules.WriteDot(l, w, opts)
+	case GraphFormatMermaid:
+		return modules.WriteMermaid(w, opts)
+	case GraphFormatJSON:
+		return modules.WriteGraphJSON(w, opts)
+	case GraphFormatHTML:
+		return modules.WriteHTML(w, opts)
+	default:
+		return errors.Errorf("unsupported graph format %q, supported formats: %s", format, strings.Join(GraphFormats(), ", "))
+	}
+}
+
+// Graph returns the nodes and edges of the dependency graph of the modules. Dependencies that are not part of the
+// modules themselves are added as nodes as well.
+func (modules TerraformModules) Graph(opts *options.TerragruntOptions) *Graph {
+	var (
+		graph = &Graph{Nodes: []*GraphNode{}, Edges: []*GraphEdge{}}
+		nodes = make(map[string]*GraphNode)
+		// all paths are relative to the TerragruntConfigPath, the same as in the DOT output
+		prefix = filepath.Dir(opts.TerragruntConfigPath) + "/"
+	)
+
+	addNode := func(module *TerraformModule) *GraphNode {
+		if node, ok := nodes[module.Path]; ok {
+			return node
+		}
+
+		node := &GraphNode{
+			ID:                   strings.TrimPrefix(module.Path, prefix),
+			Path:                 module.Path,
+			Type:                 nodeTypeUnit,
+			External:             opts.WorkingDir != "" && !util.HasPathPrefix(module.Path, opts.WorkingDir),
+			Excluded:             module.FlagExcluded,
+			AssumeAlreadyApplied: module.AssumeAlreadyApplied,
+		}
+
+		nodes[module.Path] = node
+		graph.Nodes = append(graph.Nodes, node)
+
+		return node
+	}
+
+	for _, module := range modules {
+		addNode(module)
+	}
+
+	for _, module := range modules {
+		source := nodes[module.Path]
+
+		for _, dependency := range module.Dependencies {
+			target := addNode(dependency)
+			graph.Edges = append(graph.Edges, &GraphEdge{From: source.ID, To: target.ID})
+		}
+	}
+
+	return graph
+}
+
+// WriteGraphJSON writes the nodes and edges of the dependency graph of the modules as JSON.
+func (modules TerraformModules) WriteGraphJSON(w io.Writer, opts *options.TerragruntOptions) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(modules.Graph(opts)); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// WriteMermaid writes the dependency graph of the modules as a Mermaid flowchart, which GitHub, GitLab and most
+// documentation tools render natively. Excluded units are outlined in red, external units with a dashed line.
+func (modules TerraformModules) WriteMermaid(w io.Writer, opts *options.TerragruntOptions) error {
+	var (
+		graph = modules.Graph(opts)
+		ids   = make(map[string]string, len(graph.Nodes))
+		b     strings.Builder
+
+		excluded, external []string
+	)
+
+	b.WriteString("flowchart LR\n")
+
+	for i, node := range graph.Nodes {
+		// Unit paths contain characters that aren't valid in Mermaid node IDs, so the paths are only used as labels.
+		id := fmt.Sprintf("n%d", i)
+		ids[node.ID] = id
+
+		fmt.Fprintf(&b, "\t%s[\"%s\"]\n", id, strings.ReplaceAll(node.ID, `"`, "#quot;"))
+
+		if node.Excluded {
+			excluded = append(excluded, id)
+		}
+
+		if node.External {
+			external = append(external, id)
+		}
+	}
+
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&b, "\t%s --> %s\n", ids[edge.From], ids[edge.To])
+	}
+
+	if len(excluded) > 0 {
+		b.WriteString("\tclassDef excluded stroke:#f00,color:#f00\n")
+		fmt.Fprintf(&b, "\tclass %s excluded\n", strings.Join(excluded, ","))
+	}
+
+	if len(external) > 0 {
+		b.WriteString("\tclassDef external stroke-dasharray:5 5\n")
+		fmt.Fprintf(&b, "\tclass %s external\n", strings.Join(external, ","))
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// WriteHTML writes the dependency graph of the modules as a self-contained interactive HTML page, which doesn't need
+// Graphviz or any network access to be viewed. Clicking a unit highlights everything it depends on and everything
+// that depends on it.
+func (modules TerraformModules) WriteHTML(w io.Writer, opts *options.TerragruntOptions) error {
+	if err := graphHTMLTemplate.Execute(w, modules.Graph(opts)); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+var graphHTMLTemplate = template.Must(template.New("graph").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Terragrunt DAG</title>
+<style>
+  body { margin: 0; font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; font-size: 13px; color: #1f2328; }
+  header { display: flex; gap: 16px; align-items: center; padding: 8px 16px; border-bottom: 1px solid #d0d7de; }
+  header input { padding: 4px 8px; width: 240px; }
+  .legend span { margin-right: 12px; }
+  #graph { overflow: auto; }
+  .node rect { fill: #fff; stroke: #57606a; stroke-width: 1.5; rx: 4; }
+  .node text { pointer-events: none; }
+  .node { cursor: pointer; }
+  .node.excluded rect { stroke: #cf222e; }
+  .node.excluded text { fill: #cf222e; }
+  .node.external rect { stroke-dasharray: 5 3; }
+  .edge { fill: none; stroke: #8c959f; stroke-width: 1.2; }
+  .dimmed { opacity: 0.15; }
+  .node.selected rect { fill: #ddf4ff; stroke: #0969da; stroke-width: 2.5; }
+  .node.match rect { fill: #fff8c5; }
+</style>
+</head>
+<body>
+<header>
+  <strong>Terragrunt DAG</strong>
+  <input id="search" type="search" placeholder="Filter units">
+  <div class="legend">
+    <span style="color:#cf222e">&#9632; excluded</span>
+    <span>&#9633; dashed: external</span>
+    <span>Click a unit to highlight its dependencies and dependents.</span>
+  </div>
+</header>
+<div id="graph"></div>
+<script>
+(function () {
+  var graph = {{.}};
+  var byId = {}, deps = {}, dependents = {}, level = {};
+
+  graph.nodes.forEach(function (n) { byId[n.id] = n; deps[n.id] = []; dependents[n.id] = []; });
+  graph.edges.forEach(function (e) { deps[e.from].push(e.to); dependents[e.to].push(e.from); });
+
+  function depth(id, seen) {
+    if (level[id] !== undefined) { return level[id]; }
+    if (seen[id]) { return 0; }
+    seen[id] = true;
+    var d = 0;
+    deps[id].forEach(function (dep) { d = Math.max(d, depth(dep, seen) + 1); });
+    level[id] = d;
+    return d;
+  }
+
+  var columns = [];
+  graph.nodes.forEach(function (n) {
+    var d = depth(n.id, {});
+    (columns[d] = columns[d] || []).push(n);
+  });
+
+  var colWidth = 260, rowHeight = 44, boxWidth = 220, boxHeight = 28, pad = 20, pos = {}, rows = 0;
+  columns.forEach(function (col, c) {
+    col.sort(function (a, b) { return a.id < b.id ? -1 : 1; });
+    col.forEach(function (n, r) { pos[n.id] = { x: pad + c * colWidth, y: pad + r * rowHeight }; });
+    rows = Math.max(rows, col.length);
+  });
+
+  var ns = "http://www.w3.org/2000/svg";
+  var svg = document.createElementNS(ns, "svg");
+  svg.setAttribute("width", pad * 2 + Math.max(columns.length, 1) * colWidth);
+  svg.setAttribute("height", pad * 2 + Math.max(rows, 1) * rowHeight);
+  svg.innerHTML = '<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto-start-reverse"><path d="M 0 0 L 10 5 L 0 10 z" fill="#8c959f"/></marker></defs>';
+
+  var edgeEls = [], nodeEls = {};
+  graph.edges.forEach(function (e) {
+    var from = pos[e.from], to = pos[e.to];
+    var x1 = from.x, y1 = from.y + boxHeight / 2, x2 = to.x + boxWidth, y2 = to.y + boxHeight / 2, mid = (x1 + x2) / 2;
+    var path = document.createElementNS(ns, "path");
+    path.setAttribute("class", "edge");
+    path.setAttribute("d", "M" + x1 + "," + y1 + " C" + mid + "," + y1 + " " + mid + "," + y2 + " " + x2 + "," + y2);
+    path.setAttribute("marker-end", "url(#arrow)");
+    svg.appendChild(path);
+    edgeEls.push({ edge: e, el: path });
+  });
+
+  graph.nodes.forEach(function (n) {
+    var g = document.createElementNS(ns, "g");
+    g.setAttribute("class", "node" + (n.excluded ? " excluded" : "") + (n.external ? " external" : ""));
+    g.setAttribute("transform", "translate(" + pos[n.id].x + "," + pos[n.id].y + ")");
+    var title = document.createElementNS(ns, "title");
+    title.textContent = n.path + (n.excluded ? " (excluded)" : "") + (n.external ? " (external)" : "");
+    var rect = document.createElementNS(ns, "rect");
+    rect.setAttribute("width", boxWidth);
+    rect.setAttribute("height", boxHeight);
+    var text = document.createElementNS(ns, "text");
+    text.setAttribute("x", 8);
+    text.setAttribute("y", boxHeight / 2 + 4);
+    text.textContent = n.id.length > 32 ? "…" + n.id.slice(-31) : n.id;
+    g.appendChild(title);
+    g.appendChild(rect);
+    g.appendChild(text);
+    g.addEventListener("click", function (evt) { evt.stopPropagation(); select(n.id); });
+    svg.appendChild(g);
+    nodeEls[n.id] = g;
+  });
+
+  function walk(id, next, seen) {
+    next[id].forEach(function (other) {
+      if (!seen[other]) { seen[other] = true; walk(other, next, seen); }
+    });
+    return seen;
+  }
+
+  var selected = null;
+  function select(id) {
+    selected = selected === id ? null : id;
+    var related = null;
+    if (selected) {
+      related = walk(selected, deps, {});
+      walk(selected, dependents, related);
+      related[selected] = true;
+    }
+    Object.keys(nodeEls).forEach(function (nid) {
+      nodeEls[nid].classList.toggle("dimmed", related !== null && !related[nid]);
+      nodeEls[nid].classList.toggle("selected", nid === selected);
+    });
+    edgeEls.forEach(function (e) {
+      e.el.classList.toggle("dimmed", related !== null && !(related[e.edge.from] && related[e.edge.to]));
+    });
+  }
+
+  svg.addEventListener("click", function () { if (selected) { select(selected); } });
+
+  document.getElementById("search").addEventListener("input", function (evt) {
+    var query = evt.target.value.toLowerCase();
+    Object.keys(nodeEls).forEach(function (nid) {
+      nodeEls[nid].classList.toggle("match", query !== "" && nid.toLowerCase().indexOf(query) !== -1);
+    });
+  });
+
+  document.getElementById("graph").appendChild(svg);
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,104 @@
+package configstack_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGraphTestModules(t *testing.T) (configstack.TerraformModules, *options.TerragruntOptions) {
+	t.Helper()
+
+	a := &configstack.TerraformModule{Path: "/stack/a"}
+	b := &configstack.TerraformModule{Path: "/stack/b", FlagExcluded: true}
+	ext := &configstack.TerraformModule{Path: "/external/c", FlagExcluded: true, AssumeAlreadyApplied: true}
+	d := &configstack.TerraformModule{Path: "/stack/d", Dependencies: configstack.TerraformModules{a, b, ext}}
+
+	opts, err := options.NewTerragruntOptionsForTest("/stack/terragrunt.hcl")
+	require.NoError(t, err)
+
+	opts.WorkingDir = "/stack"
+
+	return configstack.TerraformModules{a, b, d}, opts
+}
+
+func TestWriteGraphJSON(t *testing.T) {
+	t.Parallel()
+
+	modules, opts := newGraphTestModules(t)
+
+	var stdout bytes.Buffer
+	require.NoError(t, modules.WriteGraph(logger.CreateLogger(), &stdout, opts, configstack.GraphFormatJSON))
+
+	var graph configstack.Graph
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &graph))
+
+	assert.Equal(t, []*configstack.GraphNode{
+		{ID: "a", Path: "/stack/a", Type: "unit"},
+		{ID: "b", Path: "/stack/b", Type: "unit", Excluded: true},
+		{ID: "d", Path: "/stack/d", Type: "unit"},
+		{ID: "/external/c", Path: "/external/c", Type: "unit", External: true, Excluded: true, AssumeAlreadyApplied: true},
+	}, graph.Nodes)
+
+	assert.Equal(t, []*configstack.GraphEdge{
+		{From: "d", To: "a"},
+		{From: "d", To: "b"},
+		{From: "d", To: "/external/c"},
+	}, graph.Edges)
+}
+
+func TestWriteGraphMermaid(t *testing.T) {
+	t.Parallel()
+
+	modules, opts := newGraphTestModules(t)
+
+	var stdout bytes.Buffer
+	require.NoError(t, modules.WriteGraph(logger.CreateLogger(), &stdout, opts, configstack.GraphFormatMermaid))
+
+	expected := strings.TrimSpace(`
+flowchart LR
+	n0["a"]
+	n1["b"]
+	n2["d"]
+	n3["/external/c"]
+	n2 --> n0
+	n2 --> n1
+	n2 --> n3
+	classDef excluded stroke:#f00,color:#f00
+	class n1,n3 excluded
+	classDef external stroke-dasharray:5 5
+	class n3 external
+`)
+
+	assert.Equal(t, expected, strings.TrimSpace(stdout.String()))
+}
+
+func TestWriteGraphHTML(t *testing.T) {
+	t.Parallel()
+
+	modules, opts := newGraphTestModules(t)
+
+	var stdout bytes.Buffer
+	require.NoError(t, modules.WriteGraph(logger.CreateLogger(), &stdout, opts, configstack.GraphFormatHTML))
+
+	html := stdout.String()
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>"))
+	assert.Contains(t, html, `"id":"/external/c"`)
+	assert.NotContains(t, html, "<script src=")
+}
+
+func TestWriteGraphUnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	modules, opts := newGraphTestModules(t)
+
+	var stdout bytes.Buffer
+	require.Error(t, modules.WriteGraph(logger.CreateLogger(), &stdout, opts, "svg"))
+}
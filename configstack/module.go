@@ -41,6 +41,8 @@ type TerraformModule struct {
 	Config               config.TerragruntConfig
 	AssumeAlreadyApplied bool
 	FlagExcluded         bool
+	// FlagResumed is set when the unit is excluded because it already succeeded in the report passed to --resume-from.
+	FlagResumed bool
 }
 
 // String renders this module as a human-readable string
@@ -558,6 +560,138 @@ func (modules TerraformModules) flagUnitsThatRead(opts *options.TerragruntOption
 	return modules
 }
 
+// flagUnitsChangedSince iterates over a module slice and flags all modules as included that changed since the git ref
+// passed in with the queue-include-changed-since CLI flag, along with all the modules that depend on them.
+func (modules TerraformModules) flagUnitsChangedSince(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) (TerraformModules, error) {
+	// If no ChangedSince is specified return the modules list instantly
+	if opts.ChangedSince == "" {
+		return modules, nil
+	}
+
+	changedFiles, err := shell.GitChangedFiles(ctx, l, opts, opts.WorkingDir, opts.ChangedSince)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string]bool)
+
+	for _, file := range changedFiles {
+		// A changed file in a directory belongs to the innermost module, not to the modules it is nested in.
+		var owner *TerraformModule
+
+		for _, module := range modules {
+			if util.HasPathPrefix(file, resolvePath(module.Path)) && (owner == nil || len(module.Path) > len(owner.Path)) {
+				owner = module
+			}
+		}
+
+		if owner != nil {
+			changed[owner.Path] = true
+		}
+	}
+
+	for _, module := range modules {
+		if changed[module.Path] {
+			continue
+		}
+
+		moduleChanged, err := module.dependsOnChangedFiles(l, opts, changedFiles)
+		if err != nil {
+			return nil, err
+		}
+
+		changed[module.Path] = moduleChanged
+	}
+
+	// Modules that depend on a changed module are affected by the change as well.
+	for found := true; found; {
+		found = false
+
+		for _, module := range modules {
+			if changed[module.Path] {
+				continue
+			}
+
+			for _, dependency := range module.Dependencies {
+				if changed[dependency.Path] {
+					changed[module.Path] = true
+					found = true
+
+					break
+				}
+			}
+		}
+	}
+
+	for _, module := range modules {
+		if changed[module.Path] {
+			l.Debugf("Module %s is affected by the changes since %s", module.Path, opts.ChangedSince)
+
+			module.FlagExcluded = false
+		}
+	}
+
+	return modules, nil
+}
+
+// dependsOnChangedFiles returns true if any of the given changed files is included by the module, was read by the
+// module with read_terragrunt_config or mark_as_read, or is part of the local terraform source of the module.
+func (module *TerraformModule) dependsOnChangedFiles(l log.Logger, opts *options.TerragruntOptions, changedFiles []string) (bool, error) {
+	var sourceDir string
+
+	if module.Config.Terraform != nil && module.Config.Terraform.Source != nil {
+		sourceURL, err := tf.ToSourceURL(*module.Config.Terraform.Source, module.Path)
+		if err != nil {
+			return false, err
+		}
+
+		if tf.IsLocalSource(sourceURL) {
+			// The whole repo root before the double slash is copied along with the module, so all of it counts.
+			sourceDir = resolvePath(strings.SplitN(sourceURL.Path, "//", 2)[0]) //nolint:mnd
+		}
+	}
+
+	var includes []string
+
+	for _, includeConfig := range module.Config.ProcessedIncludes {
+		canonicalPath, err := util.CanonicalPath(includeConfig.Path, module.Path)
+		if err != nil {
+			return false, err
+		}
+
+		includes = append(includes, resolvePath(canonicalPath))
+	}
+
+	for _, file := range changedFiles {
+		if slices.Contains(includes, file) {
+			l.Debugf("Module %s includes changed file %s", module.Path, file)
+			return true, nil
+		}
+
+		if opts.DidReadFile(file, module.Path) {
+			l.Debugf("Module %s read changed file %s", module.Path, file)
+			return true, nil
+		}
+
+		if sourceDir != "" && util.HasPathPrefix(file, sourceDir) {
+			l.Debugf("Module %s has changed file %s in its terraform source", module.Path, file)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// resolvePath resolves the symlinks in the given path, so that it can be compared to the paths output by git,
+// falling back to the path itself if it can't be resolved.
+func resolvePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+
+	return path
+}
+
 // flagExcludedDirs iterates over a module slice and flags all entries as excluded listed in the queue-exclude-dir CLI flag.
 func (modules TerraformModules) flagExcludedDirs(opts *options.TerragruntOptions) TerraformModules {
 	// If we don't have any excludes, we don't need to do anything.
@@ -582,6 +716,92 @@ func (modules TerraformModules) flagExcludedDirs(opts *options.TerragruntOptions
 	return modules
 }
 
+// flagResumedUnits iterates over a module slice and flags all modules as excluded that already succeeded in the
+// report passed in with the resume-from CLI flag. Modules that depend on a module that runs again, are run again too.
+func (modules TerraformModules) flagResumedUnits(l log.Logger, opts *options.TerragruntOptions) (TerraformModules, error) {
+	if opts.ResumeFrom == "" {
+		return modules, nil
+	}
+
+	path := opts.ResumeFrom
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(opts.WorkingDir, path)
+	}
+
+	priorReport, err := report.ReadFromFile(path)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	done := make(map[string]bool, len(priorReport.Runs))
+
+	for _, run := range priorReport.Runs {
+		// Units skipped when resuming a prior run count as done, so that a resumed run can be resumed again.
+		done[run.Name] = run.Result == report.ResultSucceeded ||
+			(run.Result == report.ResultExcluded && run.Reason != nil && *run.Reason == report.ReasonResumeFrom)
+	}
+
+	// When destroying, modules run after the modules that depend on them, so those are the ones that have to
+	// be taken into account.
+	upstream := func(module *TerraformModule) TerraformModules {
+		return module.Dependencies
+	}
+
+	if opts.TerraformCommand == tf.CommandNameDestroy && !opts.IgnoreDependencyOrder {
+		dependents := make(map[string]TerraformModules)
+
+		for _, module := range modules {
+			for _, dependency := range module.Dependencies {
+				dependents[dependency.Path] = append(dependents[dependency.Path], module)
+			}
+		}
+
+		upstream = func(module *TerraformModule) TerraformModules {
+			return dependents[module.Path]
+		}
+	}
+
+	rerun := make(map[string]bool, len(modules))
+
+	var mustRerun func(module *TerraformModule) bool
+
+	mustRerun = func(module *TerraformModule) bool {
+		if result, ok := rerun[module.Path]; ok {
+			return result
+		}
+
+		// Guard against dependency cycles, which are reported elsewhere.
+		rerun[module.Path] = false
+
+		result := !done[module.Path] && !module.FlagExcluded
+
+		if !opts.IgnoreDependencyOrder {
+			for _, dependency := range upstream(module) {
+				if mustRerun(dependency) {
+					result = true
+				}
+			}
+		}
+
+		rerun[module.Path] = result
+
+		return result
+	}
+
+	for _, module := range modules {
+		if module.FlagExcluded || !done[module.Path] || mustRerun(module) {
+			continue
+		}
+
+		l.Debugf("Module %s already succeeded in %s, skipping it", module.Path, opts.ResumeFrom)
+
+		module.FlagExcluded = true
+		module.FlagResumed = true
+	}
+
+	return modules, nil
+}
+
 var existingModules = cache.NewCache[*TerraformModulesMap](existingModulesCacheName)
 
 type TerraformModulesMap map[string]*TerraformModule
@@ -197,10 +197,13 @@ func (module *RunningModule) moduleFinished(moduleErr error, r *report.Report, r
 		module.Logger.Errorf("Module %s has finished with an error", module.Module.Path)
 
 		if reportExperiment {
+			ancestors := dependencyAncestry(moduleErr)
+
 			if err := r.EndRun(
 				module.Module.Path,
 				report.WithResult(report.ResultFailed),
 				report.WithReason(report.ReasonRunError),
+				report.WithError(moduleErr),
 			); err != nil {
 				// If we can't find the run, then it never started,
 				// so we should end it as an early exit.
@@ -216,11 +219,18 @@ func (module *RunningModule) moduleFinished(moduleErr error, r *report.Report, r
 						return
 					}
 
-					if err := r.EndRun(
-						run.Name,
+					endOptions := []report.EndOption{
 						report.WithResult(report.ResultEarlyExit),
 						report.WithReason(report.ReasonRunError),
-					); err != nil {
+						report.WithError(moduleErr),
+						report.WithAncestors(ancestors...),
+					}
+
+					if len(ancestors) > 0 {
+						endOptions = append(endOptions, report.WithCauseAncestorExit(ancestors[len(ancestors)-1]))
+					}
+
+					if err := r.EndRun(run.Name, endOptions...); err != nil {
 						module.Logger.Errorf("Error ending run for unit %s: %v", module.Module.Path, err)
 					}
 				} else {
@@ -331,6 +341,22 @@ func (modules RunningModules) crossLinkDependencies(dependencyOrder DependencyOr
 	return modules, nil
 }
 
+// dependencyAncestry returns the paths of the dependencies that caused the given error, nearest dependency first.
+// The last path is the dependency that actually failed.
+func dependencyAncestry(err error) []string {
+	var ancestors []string
+
+	for {
+		var depErr ProcessingModuleDependencyError
+		if !errors.As(err, &depErr) {
+			return ancestors
+		}
+
+		ancestors = append(ancestors, depErr.Dependency.Path)
+		err = depErr.Err
+	}
+}
+
 // RemoveFlagExcluded returns a cleaned-up map that only contains modules and
 // dependencies that should not be excluded
 func (modules RunningModules) RemoveFlagExcluded(r *report.Report, reportExperiment bool) (RunningModules, error) {
@@ -372,10 +398,15 @@ func (modules RunningModules) RemoveFlagExcluded(r *report.Report, reportExperim
 				}
 			}
 
+			reason := report.ReasonExcludeBlock
+			if module.Module.FlagResumed {
+				reason = report.ReasonResumeFrom
+			}
+
 			if err := r.EndRun(
 				run.Name,
 				report.WithResult(report.ResultExcluded),
-				report.WithReason(report.ReasonExcludeBlock),
+				report.WithReason(reason),
 			); err != nil {
 				errs = append(errs, err)
 			}
@@ -2,6 +2,7 @@ package configstack_test
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -11,10 +12,12 @@ import (
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/configstack"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
 	"github.com/gruntwork-io/terragrunt/tf"
 	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	goerrors "github.com/go-errors/errors"
@@ -769,6 +772,80 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesExcludedDirsWithNoDepe
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
+func TestResolveTerraformModulesTwoModulesWithDependenciesResumeFrom(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pathA       = canonical(t, "../test/fixtures/modules/module-a")
+		pathC       = canonical(t, "../test/fixtures/modules/module-c")
+		configPaths = []string{
+			"../test/fixtures/modules/module-a/" + config.DefaultTerragruntConfigPath,
+			"../test/fixtures/modules/module-c/" + config.DefaultTerragruntConfigPath,
+		}
+	)
+
+	tc := []struct {
+		name            string
+		results         map[string]report.Result
+		expectedResumed []string
+	}{
+		{
+			name:            "dependent failed",
+			results:         map[string]report.Result{pathA: report.ResultSucceeded, pathC: report.ResultFailed},
+			expectedResumed: []string{pathA},
+		},
+		{
+			name:            "dependency failed",
+			results:         map[string]report.Result{pathA: report.ResultFailed, pathC: report.ResultSucceeded},
+			expectedResumed: nil,
+		},
+		{
+			name:            "all succeeded",
+			results:         map[string]report.Result{pathA: report.ResultSucceeded, pathC: report.ResultSucceeded},
+			expectedResumed: []string{pathA, pathC},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			priorReport := report.NewReport()
+
+			for path, result := range tt.results {
+				run, err := report.NewRun(path)
+				require.NoError(t, err)
+				require.NoError(t, priorReport.AddRun(run))
+				require.NoError(t, priorReport.EndRun(path, report.WithResult(result)))
+			}
+
+			reportFile := filepath.Join(t.TempDir(), "report.json")
+			require.NoError(t, priorReport.WriteToFile(reportFile))
+
+			opts, _ := options.NewTerragruntOptionsForTest("running_module_test")
+			opts.ResumeFrom = reportFile
+
+			l := logger.CreateLogger()
+
+			stack := configstack.NewDefaultStack(l, opts)
+			actualModules, err := stack.ResolveTerraformModules(t.Context(), l, configPaths)
+			require.NoError(t, err)
+
+			var resumed []string
+
+			for _, module := range actualModules {
+				assert.Equal(t, module.FlagResumed, module.FlagExcluded, module.Path)
+
+				if module.FlagResumed {
+					resumed = append(resumed, module.Path)
+				}
+			}
+
+			assert.ElementsMatch(t, tt.expectedResumed, resumed)
+		})
+	}
+}
+
 func TestResolveTerraformModulesTwoModulesWithDependenciesIncludedDirsWithDependency(t *testing.T) {
 	t.Parallel()
 
@@ -1355,3 +1432,110 @@ func TestCircularDependencies(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, result)
 	}
 }
+
+func TestResolveTerraformModulesChangedSince(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name          string
+		changedFile   string
+		expectedUnits []string
+	}{
+		{
+			name:          "local terraform source changed",
+			changedFile:   "modules/vpc/main.tf",
+			expectedUnits: []string{"a", "b"},
+		},
+		{
+			name:          "included file changed",
+			changedFile:   "live/root.hcl",
+			expectedUnits: []string{"c"},
+		},
+		{
+			name:          "file in unit directory changed",
+			changedFile:   "live/d/main.tf",
+			expectedUnits: []string{"d"},
+		},
+		{
+			name:          "read file changed",
+			changedFile:   "live/common.hcl",
+			expectedUnits: []string{"e"},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repoDir := t.TempDir()
+
+			files := map[string]string{
+				"modules/vpc/main.tf":      "",
+				"live/root.hcl":            "",
+				"live/a/terragrunt.hcl":    `terraform { source = "../../modules//vpc" }`,
+				"live/b/terragrunt.hcl":    `dependencies { paths = ["../a"] }`,
+				"live/c/terragrunt.hcl":    `include "root" { path = find_in_parent_folders("root.hcl") }`,
+				"live/d/terragrunt.hcl":    "",
+				"live/b/main.tf":           "",
+				"live/c/main.tf":           "",
+				"live/d/main.tf":           "",
+				"live/common.hcl":          "",
+				"live/e/terragrunt.hcl":    `locals { common = read_terragrunt_config("../common.hcl") }`,
+				"live/e/main.tf":           "",
+				"live/unrelated/README.md": "",
+			}
+
+			for path, content := range files {
+				path = filepath.Join(repoDir, path)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+			}
+
+			runGit(t, repoDir, "init")
+			runGit(t, repoDir, "add", "-A")
+			runGit(t, repoDir, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-m", "initial")
+
+			require.NoError(t, os.WriteFile(filepath.Join(repoDir, tt.changedFile), []byte("# changed\n"), 0644))
+
+			liveDir := filepath.Join(repoDir, "live")
+
+			var configPaths []string
+			for _, unit := range []string{"a", "b", "c", "d", "e"} {
+				configPaths = append(configPaths, filepath.Join(liveDir, unit, config.DefaultTerragruntConfigPath))
+			}
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(liveDir, config.DefaultTerragruntConfigPath))
+			require.NoError(t, err)
+
+			opts.WorkingDir = liveDir
+			opts.ChangedSince = "HEAD"
+			opts.ExcludeByDefault = true
+
+			l := logger.CreateLogger()
+
+			stack := configstack.NewDefaultStack(l, opts)
+			modules, err := stack.ResolveTerraformModules(t.Context(), l, configPaths)
+			require.NoError(t, err)
+
+			var included []string
+
+			for _, module := range modules {
+				if !module.FlagExcluded {
+					included = append(included, filepath.Base(module.Path))
+				}
+			}
+
+			assert.ElementsMatch(t, tt.expectedUnits, included)
+		})
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
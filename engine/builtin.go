@@ -0,0 +1,280 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os/exec"
+	"sync"
+
+	"github.com/gruntwork-io/terragrunt-engine-go/proto"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// TypeBuiltin is the type of the engine shipped in the Terragrunt binary, which runs commands locally.
+	TypeBuiltin = "builtin"
+
+	// builtinMetaCommand is the meta key to override the command run by the builtin engine.
+	builtinMetaCommand = "command"
+	// builtinMetaEnv is the meta key of the additional environment variables of the commands run by the builtin engine.
+	builtinMetaEnv = "env"
+
+	builtinStreamBufferSize = 32
+	builtinReadBufferSize   = 4096
+)
+
+// IsBuiltin returns true if the given engine is the builtin engine, which doesn't need to be downloaded.
+func IsBuiltin(e *options.EngineOptions) bool {
+	return e != nil && e.Type == TypeBuiltin
+}
+
+// builtinEngine is an implementation of the engine protocol that runs the commands as local processes, the same
+// way Terragrunt does without an engine. It allows testing the engine code path without an external plugin,
+// and serves as a reference of the protocol for engine authors.
+//
+// The following meta keys are supported:
+//   - `command`: the command to run instead of the one requested, e.g. `tofu`.
+//   - `env`: a map of environment variables to add to the ones of the requests.
+type builtinEngine struct {
+	logger log.Logger
+}
+
+// NewBuiltinEngine returns the builtin engine, which can be used as a reference implementation of the engine protocol.
+func NewBuiltinEngine(l log.Logger) proto.EngineClient {
+	return &builtinEngine{logger: l}
+}
+
+// Init validates the meta of the engine.
+func (builtin *builtinEngine) Init(ctx context.Context, req *proto.InitRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[proto.InitResponse], error) {
+	meta, err := parseBuiltinMeta(req.GetMeta())
+	if err != nil {
+		return nil, err
+	}
+
+	stream := newBuiltinStream[proto.InitResponse](ctx)
+	defer stream.close()
+
+	if meta.command != "" {
+		if _, err := exec.LookPath(meta.command); err != nil {
+			return nil, errors.New(err)
+		}
+	}
+
+	builtin.logger.Debugf("Builtin engine initialized in %s", req.GetWorkingDir())
+
+	return stream, nil
+}
+
+// Run runs the requested command, streaming its output, and sends its exit code in the last response.
+func (builtin *builtinEngine) Run(ctx context.Context, req *proto.RunRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[proto.RunResponse], error) {
+	meta, err := parseBuiltinMeta(req.GetMeta())
+	if err != nil {
+		return nil, err
+	}
+
+	command := req.GetCommand()
+	if meta.command != "" {
+		command = meta.command
+	}
+
+	cmd := exec.CommandContext(ctx, command, req.GetArgs()...)
+	cmd.Dir = req.GetWorkingDir()
+
+	for key, value := range req.GetEnvVars() {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	for key, value := range meta.env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, errors.New(err)
+	}
+
+	builtin.logger.Debugf("Builtin engine running %s %v in %s", command, req.GetArgs(), req.GetWorkingDir())
+
+	stream := newBuiltinStream[proto.RunResponse](ctx)
+
+	go func() {
+		defer stream.close()
+
+		var wg sync.WaitGroup
+
+		wg.Add(2) //nolint:mnd
+
+		go func() {
+			defer wg.Done()
+
+			readBuiltinOutput(stdout, func(data string) {
+				stream.send(&proto.RunResponse{Stdout: data})
+			})
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			readBuiltinOutput(stderr, func(data string) {
+				stream.send(&proto.RunResponse{Stderr: data})
+			})
+		}()
+
+		// all the output must be read before waiting for the command
+		wg.Wait()
+
+		resultCode := 0
+
+		if err := cmd.Wait(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				resultCode = exitErr.ExitCode()
+			} else {
+				resultCode = 1
+
+				stream.send(&proto.RunResponse{Stderr: err.Error() + "\n"})
+			}
+		}
+
+		stream.send(&proto.RunResponse{ResultCode: int32(resultCode)}) //nolint:gosec
+	}()
+
+	return stream, nil
+}
+
+// Shutdown has nothing to clean up, since every command runs in its own process.
+func (builtin *builtinEngine) Shutdown(ctx context.Context, req *proto.ShutdownRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[proto.ShutdownResponse], error) {
+	stream := newBuiltinStream[proto.ShutdownResponse](ctx)
+	defer stream.close()
+
+	builtin.logger.Debugf("Builtin engine shut down in %s", req.GetWorkingDir())
+
+	return stream, nil
+}
+
+// readBuiltinOutput reads the given output of a command until EOF, passing every chunk read to the send function.
+func readBuiltinOutput(output io.Reader, send func(data string)) {
+	buf := make([]byte, builtinReadBufferSize)
+
+	for {
+		n, err := output.Read(buf)
+		if n > 0 {
+			send(string(buf[:n]))
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// builtinMeta is the meta of the builtin engine.
+type builtinMeta struct {
+	env     map[string]string
+	command string
+}
+
+// parseBuiltinMeta parses the meta of the builtin engine, as converted to protobuf by ConvertMetaToProtobuf.
+func parseBuiltinMeta(protoMeta map[string]*anypb.Any) (*builtinMeta, error) {
+	meta := &builtinMeta{}
+
+	for key, protoValue := range protoMeta {
+		var value structpb.Value
+		if err := protoValue.UnmarshalTo(&value); err != nil {
+			return nil, errors.New(err)
+		}
+
+		switch key {
+		case builtinMetaCommand:
+			if err := json.Unmarshal([]byte(value.GetStringValue()), &meta.command); err != nil {
+				return nil, errors.Errorf("builtin engine meta %q must be a string: %w", key, err)
+			}
+		case builtinMetaEnv:
+			if err := json.Unmarshal([]byte(value.GetStringValue()), &meta.env); err != nil {
+				return nil, errors.Errorf("builtin engine meta %q must be a map of strings: %w", key, err)
+			}
+		default:
+			return nil, errors.Errorf("unsupported builtin engine meta %q", key)
+		}
+	}
+
+	return meta, nil
+}
+
+// builtinStream is an in-memory stream of responses of the builtin engine.
+type builtinStream[T any] struct {
+	ctx       context.Context
+	responses chan *T
+}
+
+func newBuiltinStream[T any](ctx context.Context) *builtinStream[T] {
+	return &builtinStream[T]{
+		ctx:       ctx,
+		responses: make(chan *T, builtinStreamBufferSize),
+	}
+}
+
+func (stream *builtinStream[T]) send(response *T) {
+	select {
+	case stream.responses <- response:
+	case <-stream.ctx.Done():
+	}
+}
+
+func (stream *builtinStream[T]) close() {
+	close(stream.responses)
+}
+
+// Recv returns the next response, or io.EOF once all the responses are received.
+func (stream *builtinStream[T]) Recv() (*T, error) {
+	select {
+	case response, ok := <-stream.responses:
+		if !ok {
+			return nil, io.EOF
+		}
+
+		return response, nil
+	case <-stream.ctx.Done():
+		return nil, stream.ctx.Err()
+	}
+}
+
+func (stream *builtinStream[T]) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+func (stream *builtinStream[T]) Trailer() metadata.MD {
+	return metadata.MD{}
+}
+
+func (stream *builtinStream[T]) CloseSend() error {
+	return nil
+}
+
+func (stream *builtinStream[T]) Context() context.Context {
+	return stream.ctx
+}
+
+func (stream *builtinStream[T]) SendMsg(_ any) error {
+	return errors.New("builtin engine streams are receive only")
+}
+
+func (stream *builtinStream[T]) RecvMsg(_ any) error {
+	return errors.New("builtin engine streams only support Recv")
+}
@@ -0,0 +1,98 @@
+package engine_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinEngine(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the builtin engine test relies on sh")
+	}
+
+	tc := []struct {
+		meta           map[string]any
+		name           string
+		script         string
+		expectedStdout string
+		expectedStderr string
+		expectedErr    string
+	}{
+		{
+			name:           "streams stdout and stderr",
+			script:         "echo out; echo err >&2",
+			expectedStdout: "out\n",
+			expectedStderr: "err\n",
+		},
+		{
+			name:           "exit code",
+			script:         "echo failing; exit 3",
+			expectedStdout: "failing\n",
+			expectedErr:    "exit code 3",
+		},
+		{
+			name:           "env meta",
+			meta:           map[string]any{"env": map[string]any{"BUILTIN_ENGINE_TEST": "from-meta"}},
+			script:         "echo $BUILTIN_ENGINE_TEST",
+			expectedStdout: "from-meta\n",
+		},
+		{
+			name:        "unsupported meta",
+			meta:        map[string]any{"unknown": true},
+			script:      "true",
+			expectedErr: `unsupported builtin engine meta "unknown"`,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(dir, "terragrunt.hcl"))
+			require.NoError(t, err)
+
+			opts.WorkingDir = dir
+			opts.EngineEnabled = true
+			opts.Engine = &options.EngineOptions{Source: "builtin", Type: engine.TypeBuiltin, Meta: tt.meta}
+
+			var stdout, stderr bytes.Buffer
+
+			l := logger.CreateLogger()
+			ctx := engine.WithEngineValues(t.Context())
+
+			output, err := engine.Run(ctx, l, &engine.ExecutionOptions{
+				TerragruntOptions: opts,
+				CmdStdout:         &stdout,
+				CmdStderr:         &stderr,
+				WorkingDir:        dir,
+				Command:           "sh",
+				Args:              []string{"-c", tt.script},
+			})
+
+			require.NoError(t, engine.Shutdown(ctx, l, opts))
+
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStdout, output.Stdout.String())
+			}
+
+			assert.Equal(t, tt.expectedStdout, stdout.String())
+			assert.Equal(t, tt.expectedStderr, stderr.String())
+		})
+	}
+}
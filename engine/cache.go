@@ -0,0 +1,220 @@
+package engine
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// enginePrefix is the prefix of the file names of cached engines.
+const enginePrefix = "terragrunt-iac-"
+
+// CachedEngine is an engine binary in the engine cache.
+type CachedEngine struct {
+	// Name is the name of the engine, which is the base name of its source without the `terragrunt-` prefix.
+	Name     string
+	Type     string
+	Version  string
+	Platform string
+	// Path is the path of the engine binary.
+	Path string
+	// PackagePath is the path of the downloaded engine package, if it is kept in the cache.
+	PackagePath string
+	// ChecksumsPath and SignaturePath are the paths of the checksums file and its signature, if the engine is
+	// released with them.
+	ChecksumsPath string
+	SignaturePath string
+	Size          int64
+}
+
+// CachedEngines returns all the engines in the engine cache, sorted by name, type, version and platform.
+func CachedEngines(opts *options.TerragruntOptions) ([]*CachedEngine, error) {
+	cacheDir, err := CacheDir(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var engines []*CachedEngine
+
+	err = filepath.WalkDir(cacheDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == cacheDir {
+				return filepath.SkipDir
+			}
+
+			return err
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		cached := parseCachedEngine(cacheDir, path)
+		if cached == nil {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		cached.Size = info.Size()
+		engines = append(engines, cached)
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	sort.Slice(engines, func(i, j int) bool {
+		a, b := engines[i], engines[j]
+
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+
+		return a.Platform < b.Platform
+	})
+
+	return engines, nil
+}
+
+// parseCachedEngine parses the engine binary at the given path of the cache, laid out as
+// `<type>/<version>/<os>/<arch>/terragrunt-iac-<name>_<type>_<version>_<os>_<arch>`. It returns nil if the path
+// is not an engine binary.
+func parseCachedEngine(cacheDir, path string) *CachedEngine {
+	fileName := filepath.Base(path)
+
+	if !strings.HasPrefix(fileName, enginePrefix) || strings.HasSuffix(fileName, ".zip") ||
+		strings.HasSuffix(fileName, "_SHA256SUMS") || strings.HasSuffix(fileName, ".sig") {
+		return nil
+	}
+
+	rel, err := filepath.Rel(cacheDir, filepath.Dir(path))
+	if err != nil {
+		return nil
+	}
+
+	// The version is empty for engines downloaded from a URL, in which case there is no version directory.
+	dirs := strings.Split(filepath.ToSlash(rel), "/")
+	if len(dirs) < 3 || len(dirs) > 4 { //nolint:mnd
+		return nil
+	}
+
+	cached := &CachedEngine{
+		Type:     dirs[0],
+		Platform: dirs[len(dirs)-2] + "_" + dirs[len(dirs)-1],
+		Path:     path,
+	}
+
+	if len(dirs) == 4 { //nolint:mnd
+		cached.Version = dirs[1]
+	}
+
+	suffix := "_" + cached.Type + "_" + cached.Version + "_" + cached.Platform
+
+	name, ok := strings.CutSuffix(strings.TrimPrefix(fileName, enginePrefix), suffix)
+	if !ok {
+		return nil
+	}
+
+	cached.Name = name
+
+	e := &options.EngineOptions{Source: name, Type: cached.Type, Version: cached.Version}
+	dir := filepath.Dir(path)
+
+	if packagePath := path + ".zip"; util.FileExists(packagePath) {
+		cached.PackagePath = packagePath
+	}
+
+	if checksumsPath := filepath.Join(dir, engineChecksumName(e)); util.FileExists(checksumsPath) {
+		cached.ChecksumsPath = checksumsPath
+	}
+
+	if signaturePath := filepath.Join(dir, engineChecksumSigName(e)); util.FileExists(signaturePath) {
+		cached.SignaturePath = signaturePath
+	}
+
+	return cached
+}
+
+// Matches returns true if the cached engine is the engine of the given options, on any platform.
+func (cached *CachedEngine) Matches(e *options.EngineOptions) bool {
+	return cached.Name == strings.TrimPrefix(filepath.Base(e.Source), prefixTrim) &&
+		cached.Type == e.Type && cached.Version == e.Version
+}
+
+// HasChecksums returns true if the cached engine was released with a signed checksums file.
+func (cached *CachedEngine) HasChecksums() bool {
+	return cached.ChecksumsPath != "" && cached.SignaturePath != ""
+}
+
+// Verify verifies the cached engine package against the signed checksums file it was released with.
+func (cached *CachedEngine) Verify() error {
+	if !cached.HasChecksums() {
+		return errors.Errorf("no checksums found for engine %s", cached.Path)
+	}
+
+	return verifyFile(cached.packagePath(), cached.ChecksumsPath, cached.SignaturePath)
+}
+
+// VerifyLocked verifies the cached engine package against the checksum locked for its platform.
+func (cached *CachedEngine) VerifyLocked(locked *LockedEngine) error {
+	return locked.VerifyPlatform(cached.packagePath(), cached.Platform)
+}
+
+// packagePath returns the path of the engine package, which is the binary itself if it wasn't downloaded as an archive.
+func (cached *CachedEngine) packagePath() string {
+	if cached.PackagePath != "" {
+		return cached.PackagePath
+	}
+
+	return cached.Path
+}
+
+// Remove removes the cached engine with its package and checksums, along with the directories left empty.
+func (cached *CachedEngine) Remove(opts *options.TerragruntOptions) error {
+	for _, path := range []string{cached.Path, cached.PackagePath, cached.ChecksumsPath, cached.SignaturePath} {
+		if path == "" {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.New(err)
+		}
+	}
+
+	cacheDir, err := CacheDir(opts)
+	if err != nil {
+		return err
+	}
+
+	for dir := filepath.Dir(cached.Path); dir != cacheDir && util.HasPathPrefix(dir, cacheDir); dir = filepath.Dir(dir) {
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) > 0 {
+			break
+		}
+
+		if err := os.Remove(dir); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package engine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedEngines(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.EngineCachePath = t.TempDir()
+
+	cacheDir, err := engine.CacheDir(opts)
+	require.NoError(t, err)
+
+	engines, err := engine.CachedEngines(opts)
+	require.NoError(t, err)
+	assert.Empty(t, engines)
+
+	writeFile := func(path string) {
+		path = filepath.Join(cacheDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte("engine"), 0644))
+	}
+
+	writeFile("rpc/v0.1.0/linux/amd64/terragrunt-iac-engine-opentofu_rpc_v0.1.0_linux_amd64")
+	writeFile("rpc/v0.1.0/linux/amd64/terragrunt-iac-engine-opentofu_rpc_v0.1.0_linux_amd64.zip")
+	writeFile("rpc/v0.1.0/linux/amd64/terragrunt-iac-engine-opentofu_rpc_v0.1.0_SHA256SUMS")
+	writeFile("rpc/v0.1.0/linux/amd64/terragrunt-iac-engine-opentofu_rpc_v0.1.0_SHA256SUMS.sig")
+	writeFile("rpc/v0.2.0/darwin/arm64/terragrunt-iac-engine-opentofu_rpc_v0.2.0_darwin_arm64")
+	writeFile("rpc/linux/amd64/terragrunt-iac-engine.zip_rpc__linux_amd64")
+	writeFile("rpc/v0.1.0/linux/amd64/unrelated-file")
+
+	engines, err = engine.CachedEngines(opts)
+	require.NoError(t, err)
+	require.Len(t, engines, 3)
+
+	assert.Equal(t, "engine-opentofu", engines[0].Name)
+	assert.Equal(t, "rpc", engines[0].Type)
+	assert.Equal(t, "v0.1.0", engines[0].Version)
+	assert.Equal(t, "linux_amd64", engines[0].Platform)
+	assert.NotEmpty(t, engines[0].PackagePath)
+	assert.True(t, engines[0].HasChecksums())
+
+	assert.Equal(t, "v0.2.0", engines[1].Version)
+	assert.Equal(t, "darwin_arm64", engines[1].Platform)
+	assert.False(t, engines[1].HasChecksums())
+
+	assert.Equal(t, "engine.zip", engines[2].Name)
+	assert.Empty(t, engines[2].Version)
+
+	assert.True(t, engines[0].Matches(&options.EngineOptions{Source: "github.com/gruntwork-io/terragrunt-engine-opentofu", Type: "rpc", Version: "v0.1.0"}))
+	assert.False(t, engines[0].Matches(&options.EngineOptions{Source: "github.com/gruntwork-io/terragrunt-engine-opentofu", Type: "rpc", Version: "v0.2.0"}))
+	assert.True(t, engines[2].Matches(&options.EngineOptions{Source: "https://example.com/terragrunt-engine.zip", Type: "rpc"}))
+
+	require.NoError(t, engines[0].Remove(opts))
+	assert.NoFileExists(t, filepath.Join(cacheDir, "rpc", "v0.1.0", "linux", "amd64", "terragrunt-iac-engine-opentofu_rpc_v0.1.0_SHA256SUMS"))
+	assert.FileExists(t, filepath.Join(cacheDir, "rpc", "v0.1.0", "linux", "amd64", "unrelated-file"))
+
+	require.NoError(t, engines[1].Remove(opts))
+	assert.NoDirExists(t, filepath.Join(cacheDir, "rpc", "v0.2.0"))
+	assert.DirExists(t, cacheDir)
+
+	engines, err = engine.CachedEngines(opts)
+	require.NoError(t, err)
+	assert.Len(t, engines, 1)
+}
@@ -0,0 +1,255 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt-engine-go/proto"
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const (
+	envCheckName  = "TG_ENGINE_CONFORMANCE"
+	envCheckValue = "conformance value"
+
+	largeOutputLines = 1000
+	exitCode         = 3
+)
+
+// check is a single conformance check, returning the protocol violations it found.
+type check struct {
+	run  func(ctx context.Context, c *checker) []string
+	name string
+}
+
+// checks returns the conformance checks, in the order they are run.
+func checks() []check {
+	var largeOutput strings.Builder
+	for i := range largeOutputLines {
+		fmt.Fprintf(&largeOutput, "line %d\n", i)
+	}
+
+	return []check{
+		{name: "init", run: checkInit},
+		runCheck("run-stdout", "printf 'line 1\\nline 2\\n'", expectedRun{stdout: "line 1\nline 2\n"}),
+		runCheck("run-stderr", "printf 'error\\n' >&2", expectedRun{stderr: "error\n"}),
+		runCheck("run-stdout-and-stderr", "printf 'out\\n'; printf 'err\\n' >&2; printf 'more out\\n'", expectedRun{stdout: "out\nmore out\n", stderr: "err\n"}),
+		runCheck("run-partial-line", "printf 'no trailing newline'", expectedRun{stdout: "no trailing newline"}),
+		runCheck("run-large-output", fmt.Sprintf("i=0; while [ $i -lt %d ]; do echo \"line $i\"; i=$((i+1)); done", largeOutputLines), expectedRun{stdout: largeOutput.String()}),
+		runCheck("run-exit-code", fmt.Sprintf("printf 'failing\\n'; exit %d", exitCode), expectedRun{stdout: "failing\n", resultCode: exitCode}),
+		runCheck("run-args", `printf '%s|' "$0" "$1"`, expectedRun{stdout: "first arg|second arg|"}, "first arg", "second arg"),
+		runCheck("run-env", fmt.Sprintf(`printf '%%s' "$%s"`, envCheckName), expectedRun{stdout: envCheckValue}),
+		{name: "run-working-dir", run: checkWorkingDir},
+		{name: "shutdown", run: checkShutdown},
+	}
+}
+
+// expectedRun is the expected outcome of a run request.
+type expectedRun struct {
+	stdout     string
+	stderr     string
+	resultCode int32
+}
+
+// runResult is the outcome of a run request, as seen by Terragrunt.
+type runResult struct {
+	streamErr   error
+	stdout      string
+	stderr      string
+	resultCodes []int32
+}
+
+// runCheck returns a check running the given shell script with the given arguments, and comparing its outcome
+// with the expected one.
+func runCheck(name, script string, expected expectedRun, args ...string) check {
+	return check{
+		name: name,
+		run: func(ctx context.Context, c *checker) []string {
+			result, err := c.run(ctx, script, args...)
+			if err != nil {
+				return []string{fmt.Sprintf("run request failed: %v", err)}
+			}
+
+			return result.violations(expected)
+		},
+	}
+}
+
+func checkInit(ctx context.Context, c *checker) []string {
+	meta, err := engine.ConvertMetaToProtobuf(c.meta)
+	if err != nil {
+		return []string{fmt.Sprintf("meta can't be converted to protobuf: %v", err)}
+	}
+
+	stream, err := c.client.Init(ctx, &proto.InitRequest{
+		EnvVars:    c.env,
+		WorkingDir: c.workingDir,
+		Meta:       meta,
+	})
+	if err != nil {
+		return []string{fmt.Sprintf("init request failed: %v", err)}
+	}
+
+	return c.readOutput(func() (string, string, error) {
+		response, err := stream.Recv()
+		return response.GetStdout(), response.GetStderr(), err
+	})
+}
+
+func checkShutdown(ctx context.Context, c *checker) []string {
+	meta, err := engine.ConvertMetaToProtobuf(c.meta)
+	if err != nil {
+		return []string{fmt.Sprintf("meta can't be converted to protobuf: %v", err)}
+	}
+
+	stream, err := c.client.Shutdown(ctx, &proto.ShutdownRequest{
+		EnvVars:    c.env,
+		WorkingDir: c.workingDir,
+		Meta:       meta,
+	})
+	if err != nil {
+		return []string{fmt.Sprintf("shutdown request failed: %v", err)}
+	}
+
+	return c.readOutput(func() (string, string, error) {
+		response, err := stream.Recv()
+		return response.GetStdout(), response.GetStderr(), err
+	})
+}
+
+func checkWorkingDir(ctx context.Context, c *checker) []string {
+	result, err := c.run(ctx, "pwd -P")
+	if err != nil {
+		return []string{fmt.Sprintf("run request failed: %v", err)}
+	}
+
+	workingDir, err := filepath.EvalSymlinks(c.workingDir)
+	if err != nil {
+		return []string{fmt.Sprintf("working dir %s can't be resolved: %v", c.workingDir, err)}
+	}
+
+	return result.violations(expectedRun{stdout: workingDir + "\n"})
+}
+
+// readOutput reads the output of an init or shutdown stream the way Terragrunt does, with engine.ReadEngineOutput,
+// reporting stream errors other than the end of the stream.
+func (c *checker) readOutput(recv func() (string, string, error)) []string {
+	var (
+		violations     []string
+		stdout, stderr bytes.Buffer
+	)
+
+	err := engine.ReadEngineOutput(&engine.ExecutionOptions{CmdStdout: &stdout, CmdStderr: &stderr}, true, func() (*engine.OutputLine, error) {
+		responseStdout, responseStderr, err := recv()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				violations = append(violations, fmt.Sprintf("stream ended with an error instead of EOF: %v", err))
+			}
+
+			return nil, err
+		}
+
+		return &engine.OutputLine{Stdout: responseStdout, Stderr: responseStderr}, nil
+	})
+	if err != nil {
+		violations = append(violations, fmt.Sprintf("output can't be read: %v", err))
+	}
+
+	if output := stderr.String(); output != "" {
+		c.logger.Debugf("Engine output: %s", output)
+	}
+
+	return violations
+}
+
+// run runs the given shell script with the given arguments through the engine, reading its output the same way
+// Terragrunt does.
+func (c *checker) run(ctx context.Context, script string, args ...string) (*runResult, error) {
+	meta, err := engine.ConvertMetaToProtobuf(c.meta)
+	if err != nil {
+		return nil, err
+	}
+
+	env := maps.Clone(c.env)
+	env[envCheckName] = envCheckValue
+
+	stream, err := c.client.Run(ctx, &proto.RunRequest{
+		Command:    c.shell,
+		Args:       append([]string{"-c", script}, args...),
+		WorkingDir: c.workingDir,
+		Meta:       meta,
+		EnvVars:    env,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result         = &runResult{}
+		stdout, stderr strings.Builder
+	)
+
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				result.streamErr = err
+			}
+
+			break
+		}
+
+		stdout.WriteString(response.GetStdout())
+		stderr.WriteString(response.GetStderr())
+
+		result.resultCodes = append(result.resultCodes, response.GetResultCode())
+	}
+
+	result.stdout = stdout.String()
+	result.stderr = stderr.String()
+
+	return result, nil
+}
+
+// violations returns the differences between the outcome of the run and the expected one.
+func (result *runResult) violations(expected expectedRun) []string {
+	var violations []string
+
+	if result.streamErr != nil {
+		violations = append(violations, fmt.Sprintf("stream ended with an error instead of EOF: %v", result.streamErr))
+	}
+
+	if result.stdout != expected.stdout {
+		violations = append(violations, fmt.Sprintf("stdout is %s, expected %s", abbreviate(result.stdout), abbreviate(expected.stdout)))
+	}
+
+	if result.stderr != expected.stderr {
+		violations = append(violations, fmt.Sprintf("stderr is %s, expected %s", abbreviate(result.stderr), abbreviate(expected.stderr)))
+	}
+
+	// Terragrunt takes the result code of the last response as the exit code of the command.
+	if len(result.resultCodes) == 0 {
+		violations = append(violations, "no response received, expected at least one with the result code")
+	} else if code := result.resultCodes[len(result.resultCodes)-1]; code != expected.resultCode {
+		violations = append(violations, fmt.Sprintf("result code of the last response is %d, expected %d", code, expected.resultCode))
+	}
+
+	return violations
+}
+
+// abbreviate quotes the given output, shortening it if it's too long to be reported in full.
+func abbreviate(output string) string {
+	const maxLen = 80
+
+	if len(output) > maxLen {
+		return fmt.Sprintf("%q... (%d bytes)", output[:maxLen], len(output))
+	}
+
+	return fmt.Sprintf("%q", output)
+}
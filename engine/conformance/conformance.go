@@ -0,0 +1,215 @@
+// Package conformance provides a test kit for authors of Terragrunt IaC engines. It launches an engine plugin
+// binary, or takes an engine client, and exercises the engine protocol the same way Terragrunt does: Init,
+// Run and Shutdown, the streaming of stdout and stderr, exit codes, environment variables and meta. Every
+// deviation from the behavior Terragrunt relies on is reported as a protocol violation.
+//
+// The commands run by the checks are shell scripts, so the engine is expected to run the command of the
+// requests with their arguments, environment variables and working directory, as the builtin engine does.
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt-engine-go/proto"
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	// DefaultShell is the shell the commands of the checks are run with.
+	DefaultShell = "sh"
+
+	// DefaultTimeout is the time a single check is allowed to take.
+	DefaultTimeout = time.Minute
+
+	defaultLogLevel = "warn"
+)
+
+// Options are the options of the conformance checks.
+type Options struct {
+	// Meta is passed in every request, the same as the `meta` attribute of the `engine` block.
+	Meta map[string]any
+
+	// Env are the environment variables passed in every request, in addition to the environment of the current
+	// process.
+	Env map[string]string
+
+	// Shell is the shell the commands of the checks are run with. Default is DefaultShell.
+	Shell string
+
+	// WorkingDir is the directory the commands of the checks are run in. Default is a temporary directory.
+	WorkingDir string
+
+	// LogLevel is the log level the plugin is launched with by RunPlugin. Default is `warn`.
+	LogLevel string
+
+	// Timeout is the time a single check is allowed to take. Default is DefaultTimeout.
+	Timeout time.Duration
+}
+
+// Result is the result of a single conformance check.
+type Result struct {
+	// Check is the name of the check.
+	Check string
+
+	// Violations are the protocol violations found by the check.
+	Violations []string
+}
+
+// Passed returns true if the check found no protocol violations.
+func (result *Result) Passed() bool {
+	return len(result.Violations) == 0
+}
+
+// Report is the report of the conformance checks of an engine.
+type Report struct {
+	Results []*Result
+}
+
+// Passed returns true if all the checks passed.
+func (report *Report) Passed() bool {
+	for _, result := range report.Results {
+		if !result.Passed() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Write writes the report in a human readable format, one line per check followed by its violations.
+func (report *Report) Write(w io.Writer) error {
+	var b strings.Builder
+
+	for _, result := range report.Results {
+		if result.Passed() {
+			fmt.Fprintf(&b, "PASS %s\n", result.Check)
+
+			continue
+		}
+
+		fmt.Fprintf(&b, "FAIL %s\n", result.Check)
+
+		for _, violation := range result.Violations {
+			fmt.Fprintf(&b, "  - %s\n", violation)
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// RunPlugin launches the engine plugin binary at the given path, runs the conformance checks against it,
+// and kills it once done.
+func RunPlugin(ctx context.Context, l log.Logger, enginePath string, opts *Options) (*Report, error) {
+	logLevel := opts.LogLevel
+	if logLevel == "" {
+		logLevel = defaultLogLevel
+	}
+
+	client, pluginClient, err := engine.StartPlugin(l, enginePath, logLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	defer pluginClient.Kill()
+
+	return Run(ctx, l, client, opts)
+}
+
+// Run runs the conformance checks against the given engine client. Init is checked first and Shutdown last,
+// the same order Terragrunt calls them in.
+func Run(ctx context.Context, l log.Logger, client proto.EngineClient, opts *Options) (*Report, error) {
+	c, cleanup, err := newChecker(l, client, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cleanup()
+
+	report := &Report{}
+
+	for _, check := range checks() {
+		l.Debugf("Running conformance check %s", check.name)
+
+		checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		violations := check.run(checkCtx, c)
+
+		if checkCtx.Err() != nil {
+			violations = append(violations, fmt.Sprintf("check did not complete within %s", c.timeout))
+		}
+
+		cancel()
+
+		report.Results = append(report.Results, &Result{Check: check.name, Violations: violations})
+	}
+
+	return report, nil
+}
+
+// checker runs the requests of the checks against the engine.
+type checker struct {
+	logger     log.Logger
+	client     proto.EngineClient
+	meta       map[string]any
+	env        map[string]string
+	shell      string
+	workingDir string
+	timeout    time.Duration
+}
+
+func newChecker(l log.Logger, client proto.EngineClient, opts *Options) (*checker, func(), error) {
+	c := &checker{
+		logger:     l,
+		client:     client,
+		meta:       opts.Meta,
+		env:        make(map[string]string),
+		shell:      opts.Shell,
+		workingDir: opts.WorkingDir,
+		timeout:    opts.Timeout,
+	}
+
+	if c.shell == "" {
+		c.shell = DefaultShell
+	}
+
+	if c.timeout == 0 {
+		c.timeout = DefaultTimeout
+	}
+
+	for _, env := range os.Environ() {
+		if key, value, ok := strings.Cut(env, "="); ok {
+			c.env[key] = value
+		}
+	}
+
+	maps.Copy(c.env, opts.Env)
+
+	cleanup := func() {}
+
+	if c.workingDir == "" {
+		dir, err := os.MkdirTemp("", "terragrunt-engine-conformance-")
+		if err != nil {
+			return nil, nil, errors.New(err)
+		}
+
+		c.workingDir = dir
+		cleanup = func() {
+			if err := os.RemoveAll(dir); err != nil {
+				l.Warnf("Failed to clean temp dir %s: %v", dir, err)
+			}
+		}
+	}
+
+	return c, cleanup, nil
+}
@@ -0,0 +1,96 @@
+package conformance_test
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt-engine-go/proto"
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/engine/conformance"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestBuiltinEngineConformance(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the conformance checks rely on sh")
+	}
+
+	l := logger.CreateLogger()
+
+	report, err := conformance.Run(t.Context(), l, engine.NewBuiltinEngine(l), &conformance.Options{
+		Meta: map[string]any{"env": map[string]any{"EXTRA": "value"}},
+	})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out))
+
+	assert.True(t, report.Passed(), out.String())
+	assert.Contains(t, out.String(), "PASS run-exit-code")
+}
+
+// exitCodeSwallowingEngine is an engine violating the protocol by always reporting a zero result code.
+type exitCodeSwallowingEngine struct {
+	proto.EngineClient
+}
+
+func (e *exitCodeSwallowingEngine) Run(ctx context.Context, req *proto.RunRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[proto.RunResponse], error) {
+	stream, err := e.EngineClient.Run(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exitCodeSwallowingStream{ServerStreamingClient: stream}, nil
+}
+
+type exitCodeSwallowingStream struct {
+	grpc.ServerStreamingClient[proto.RunResponse]
+}
+
+func (s *exitCodeSwallowingStream) Recv() (*proto.RunResponse, error) {
+	response, err := s.ServerStreamingClient.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.RunResponse{Stdout: response.GetStdout(), Stderr: response.GetStderr()}, nil
+}
+
+func TestConformanceViolations(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the conformance checks rely on sh")
+	}
+
+	l := logger.CreateLogger()
+
+	report, err := conformance.Run(t.Context(), l, &exitCodeSwallowingEngine{EngineClient: engine.NewBuiltinEngine(l)}, &conformance.Options{})
+	require.NoError(t, err)
+	assert.False(t, report.Passed())
+
+	for _, result := range report.Results {
+		if result.Check == "run-exit-code" {
+			assert.Equal(t, []string{"result code of the last response is 0, expected 3"}, result.Violations)
+		} else {
+			assert.True(t, result.Passed(), "%s: %v", result.Check, result.Violations)
+		}
+	}
+
+	// unsupported meta is rejected by every request of the builtin engine
+	report, err = conformance.Run(t.Context(), l, engine.NewBuiltinEngine(l), &conformance.Options{
+		Meta: map[string]any{"unknown": true},
+	})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out))
+	assert.Contains(t, out.String(), "FAIL init\n  - init request failed: unsupported builtin engine meta \"unknown\"")
+}
@@ -15,13 +15,12 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 
 	"github.com/gruntwork-io/terragrunt/internal/cache"
 
-	"github.com/hashicorp/go-getter"
-
 	"github.com/hashicorp/go-hclog"
 
 	"google.golang.org/grpc/credentials/insecure"
@@ -49,6 +48,9 @@ const (
 	checksumFileNameFormat                      = "terragrunt-iac-%s_%s_%s_SHA256SUMS"
 	engineLogLevelEnv                           = "TG_ENGINE_LOG_LEVEL"
 	defaultEngineRepoRoot                       = "github.com/"
+	lockFileExt                                 = ".lock"
+	retryDelayLockFile                          = time.Second
+	maxRetriesLockFile                          = 600
 	terraformCommandContextKey engineClientsKey = iota
 	locksContextKey            engineLocksKey   = iota
 	latestVersionsContextKey   engineLocksKey   = iota
@@ -144,21 +146,14 @@ func DownloadEngine(ctx context.Context, l log.Logger, opts *options.TerragruntO
 
 	e := opts.Engine
 
-	if util.FileExists(e.Source) {
-		// if source is a file, no need to download, exit
+	if util.FileExists(e.Source) || IsBuiltin(e) {
+		// if source is a file, or the engine is builtin, no need to download, exit
 		return nil
 	}
 
-	// identify engine version if not specified
-	if len(e.Version) == 0 {
-		if !strings.Contains(e.Source, "://") {
-			tag, err := lastReleaseVersion(ctx, opts)
-			if err != nil {
-				return errors.New(err)
-			}
-
-			e.Version = tag
-		}
+	locked, lockFile, err := resolveVersion(ctx, opts)
+	if err != nil {
+		return err
 	}
 
 	path, err := engineDir(opts)
@@ -177,71 +172,156 @@ func DownloadEngine(ctx context.Context, l log.Logger, opts *options.TerragruntO
 	if err != nil {
 		return errors.New(err)
 	}
-	// locking by file where engine is downloaded
-	// however, it will not help in case of multiple parallel Terragrunt runs
+
 	locks.Lock(localEngineFile)
 	defer locks.Unlock(localEngineFile)
 
+	// lock by file as well, for parallel Terragrunt runs sharing the engine cache
+	lockfile, err := acquireLockFile(ctx, l, localEngineFile+lockFileExt)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := lockfile.Unlock(); err != nil {
+			l.Warnf("Failed to unlock %s: %v", lockfile.Path(), err)
+		}
+	}()
+
+	downloadFile := filepath.Join(path, enginePackageName(e))
+
 	if util.FileExists(localEngineFile) {
+		if locked != nil && !opts.EngineSkipChecksumCheck {
+			// the package is renamed to the engine file when it is not an archive
+			packageFile := downloadFile
+			if !util.FileExists(packageFile) {
+				packageFile = localEngineFile
+			}
+
+			if err := locked.Verify(packageFile); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}
 
-	downloadFile := filepath.Join(path, enginePackageName(e))
+	// download and extract in a temp dir, so that partial downloads never end up in the cache
+	tempDir, err := os.MkdirTemp(path, "temp-download-")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			l.Warnf("Failed to clean temp dir %s: %v", tempDir, err)
+		}
+	}()
 
 	downloads := make(map[string]string)
+	tempDownloadFile := filepath.Join(tempDir, enginePackageName(e))
 	checksumFile := ""
 	checksumSigFile := ""
 
 	if strings.Contains(e.Source, "://") {
 		// if source starts with absolute path, download as is
-		downloads[e.Source] = downloadFile
+		downloads[e.Source] = tempDownloadFile
 	} else {
 		baseURL := fmt.Sprintf("https://%s/releases/download/%s", e.Source, e.Version)
 
 		// URLs and their corresponding local paths
-		checksumFile = filepath.Join(path, engineChecksumName(e))
-		checksumSigFile = filepath.Join(path, engineChecksumSigName(e))
-		downloads[fmt.Sprintf("%s/%s", baseURL, enginePackageName(e))] = downloadFile
+		checksumFile = filepath.Join(tempDir, engineChecksumName(e))
+		checksumSigFile = filepath.Join(tempDir, engineChecksumSigName(e))
+		downloads[fmt.Sprintf("%s/%s", baseURL, enginePackageName(e))] = tempDownloadFile
 		downloads[fmt.Sprintf("%s/%s", baseURL, engineChecksumName(e))] = checksumFile
 		downloads[fmt.Sprintf("%s/%s.sig", baseURL, engineChecksumName(e))] = checksumSigFile
 	}
 
 	for url, path := range downloads {
-		l.Infof("Downloading %s to %s", url, path)
-		client := &getter.Client{
-			Ctx:           ctx,
-			Src:           url,
-			Dst:           path,
-			Mode:          getter.ClientModeFile,
-			Decompressors: map[string]getter.Decompressor{},
-		}
-
-		if err := client.Get(); err != nil {
-			return errors.New(err)
+		if err := fetchFile(ctx, l, url, path); err != nil {
+			return err
 		}
 	}
 
 	if !opts.EngineSkipChecksumCheck && checksumFile != "" && checksumSigFile != "" {
 		l.Infof("Verifying checksum for %s", downloadFile)
 
-		if err := verifyFile(downloadFile, checksumFile, checksumSigFile); err != nil {
+		if err := verifyFile(tempDownloadFile, checksumFile, checksumSigFile); err != nil {
 			return errors.New(err)
 		}
-	} else {
+	} else if locked == nil {
 		l.Warnf("Skipping verification for %s", downloadFile)
 	}
 
-	if err := extractArchive(l, downloadFile, localEngineFile); err != nil {
+	if locked != nil {
+		if opts.EngineSkipChecksumCheck {
+			l.Warnf("Skipping verification for %s against %s", downloadFile, lockFile)
+		} else {
+			l.Infof("Verifying checksum for %s against %s", downloadFile, lockFile)
+
+			if err := locked.Verify(tempDownloadFile); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := extractArchive(l, tempDownloadFile, filepath.Join(tempDir, engineFileName(e))); err != nil {
 		return errors.New(err)
 	}
 
+	if err := moveToCache(tempDir, path, engineFileName(e)); err != nil {
+		return err
+	}
+
 	l.Infof("Engine available as %s", path)
 
 	return nil
 }
 
-func lastReleaseVersion(ctx context.Context, opts *options.TerragruntOptions) (string, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", strings.TrimPrefix(opts.Engine.Source, defaultEngineRepoRoot))
+// ResolveVersion sets the version of the engine of the given options, if not specified, to the version locked in the
+// closest lock file, or to the latest release.
+func ResolveVersion(ctx context.Context, opts *options.TerragruntOptions) error {
+	_, _, err := resolveVersion(ctx, opts)
+
+	return err
+}
+
+// resolveVersion resolves the version of the engine, returning the locked engine and the path of the lock file
+// it is locked in, if any.
+func resolveVersion(ctx context.Context, opts *options.TerragruntOptions) (*LockedEngine, string, error) {
+	e := opts.Engine
+
+	locked, lockFile, err := lockedEngine(opts)
+	if err != nil {
+		return nil, "", errors.New(err)
+	}
+
+	if locked != nil {
+		if err := checkLockedEngine(e, locked, lockFile); err != nil {
+			return nil, "", err
+		}
+
+		// use the locked version, instead of the latest release
+		e.Version = locked.Version
+	}
+
+	// identify engine version if not specified
+	if len(e.Version) == 0 {
+		if !strings.Contains(e.Source, "://") {
+			tag, err := lastReleaseVersion(ctx, e.Source)
+			if err != nil {
+				return nil, "", errors.New(err)
+			}
+
+			e.Version = tag
+		}
+	}
+
+	return locked, lockFile, nil
+}
+
+func lastReleaseVersion(ctx context.Context, source string) (string, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", strings.TrimPrefix(source, defaultEngineRepoRoot))
 
 	versionCache, err := engineVersionsCacheFromContext(ctx)
 
@@ -345,6 +425,50 @@ func extractArchive(l log.Logger, downloadFile string, engineFile string) error
 	return nil
 }
 
+// acquireLockFile locks the given lock file, waiting for other Terragrunt processes holding it to release it.
+func acquireLockFile(ctx context.Context, l log.Logger, path string) (*util.Lockfile, error) {
+	lockfile := util.NewLockfile(path)
+
+	if err := util.DoWithRetry(ctx, "Acquiring lock file "+path, maxRetriesLockFile, retryDelayLockFile, l, log.DebugLevel, func(ctx context.Context) error {
+		return lockfile.TryLock()
+	}); err != nil {
+		return nil, errors.Errorf("unable to acquire lock file %s (already locked?) try to remove the file manually: %w", path, err)
+	}
+
+	return lockfile, nil
+}
+
+// moveToCache moves the files downloaded and extracted in the given temp dir to the engine dir. Each file is
+// renamed atomically, and the engine file is renamed last, so that the engine is only ever found in the cache
+// once all its files are in place.
+func moveToCache(tempDir, path, engineFile string) error {
+	files, err := os.ReadDir(tempDir)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	for _, file := range files {
+		if file.Name() == engineFile {
+			continue
+		}
+
+		if err := os.Rename(filepath.Join(tempDir, file.Name()), filepath.Join(path, file.Name())); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	// archives with several files may not contain the engine file itself
+	if !util.FileExists(filepath.Join(tempDir, engineFile)) {
+		return nil
+	}
+
+	if err := os.Rename(filepath.Join(tempDir, engineFile), filepath.Join(path, engineFile)); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
 // engineDir returns the directory path where engine files are stored.
 func engineDir(terragruntOptions *options.TerragruntOptions) (string, error) {
 	engine := terragruntOptions.Engine
@@ -352,6 +476,19 @@ func engineDir(terragruntOptions *options.TerragruntOptions) (string, error) {
 		return filepath.Dir(engine.Source), nil
 	}
 
+	cacheDir, err := CacheDir(terragruntOptions)
+	if err != nil {
+		return "", err
+	}
+
+	platform := runtime.GOOS
+	arch := runtime.GOARCH
+
+	return filepath.Join(cacheDir, engine.Type, engine.Version, platform, arch), nil
+}
+
+// CacheDir returns the directory path where downloaded engines are cached.
+func CacheDir(terragruntOptions *options.TerragruntOptions) (string, error) {
 	cacheDir := terragruntOptions.EngineCachePath
 	if len(cacheDir) == 0 {
 		homeDir, err := os.UserHomeDir()
@@ -362,10 +499,7 @@ func engineDir(terragruntOptions *options.TerragruntOptions) (string, error) {
 		cacheDir = filepath.Join(homeDir, defaultCacheDir)
 	}
 
-	platform := runtime.GOOS
-	arch := runtime.GOARCH
-
-	return filepath.Join(cacheDir, defaultEngineCachePath, engine.Type, engine.Version, platform, arch), nil
+	return filepath.Join(cacheDir, defaultEngineCachePath), nil
 }
 
 // engineFileName returns the file name for the engine.
@@ -472,8 +606,10 @@ func Shutdown(ctx context.Context, l log.Logger, opts *options.TerragruntOptions
 		if err := shutdown(ctx, l, instance.executionOptions, instance.terragruntEngine); err != nil {
 			l.Errorf("Error shutting down engine: %v", err)
 		}
-		// kill grpc client
-		instance.client.Kill()
+		// kill grpc client, the builtin engine has none
+		if instance.client != nil {
+			instance.client.Kill()
+		}
 
 		return true
 	})
@@ -483,6 +619,14 @@ func Shutdown(ctx context.Context, l log.Logger, opts *options.TerragruntOptions
 
 // createEngine create engine for working directory
 func createEngine(l log.Logger, terragruntOptions *options.TerragruntOptions) (*proto.EngineClient, *plugin.Client, error) {
+	if IsBuiltin(terragruntOptions.Engine) {
+		l.Debugf("Creating builtin engine")
+
+		terragruntEngine := NewBuiltinEngine(l)
+
+		return &terragruntEngine, nil, nil
+	}
+
 	path, err := engineDir(terragruntOptions)
 	if err != nil {
 		return nil, nil, errors.New(err)
@@ -518,14 +662,25 @@ func createEngine(l log.Logger, terragruntOptions *options.TerragruntOptions) (*
 		}
 	}
 
+	terragruntEngine, client, err := StartPlugin(l, localEnginePath, engineLogLevel)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &terragruntEngine, client, nil
+}
+
+// StartPlugin launches the engine plugin binary at the given path with the given log level, and returns the engine
+// client connected to it, along with the plugin client to kill the plugin with.
+func StartPlugin(l log.Logger, enginePath, logLevel string) (proto.EngineClient, *plugin.Client, error) {
 	logger := hclog.NewInterceptLogger(&hclog.LoggerOptions{
-		Level:  hclog.LevelFromString(engineLogLevel),
+		Level:  hclog.LevelFromString(logLevel),
 		Output: l.Writer(),
 	})
 
-	cmd := exec.Command(localEnginePath)
+	cmd := exec.Command(enginePath)
 	// pass log level to engine
-	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", engineLogLevelEnv, engineLogLevel))
+	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", engineLogLevelEnv, logLevel))
 	client := plugin.NewClient(&plugin.ClientConfig{
 		Logger: logger,
 		HandshakeConfig: plugin.HandshakeConfig{
@@ -545,17 +700,26 @@ func createEngine(l log.Logger, terragruntOptions *options.TerragruntOptions) (*
 
 	rpcClient, err := client.Client()
 	if err != nil {
+		client.Kill()
+
 		return nil, nil, errors.New(err)
 	}
 
 	rawClient, err := rpcClient.Dispense("plugin")
 	if err != nil {
+		client.Kill()
+
 		return nil, nil, errors.New(err)
 	}
 
-	terragruntEngine := rawClient.(proto.EngineClient)
+	terragruntEngine, ok := rawClient.(proto.EngineClient)
+	if !ok {
+		client.Kill()
 
-	return &terragruntEngine, client, nil
+		return nil, nil, errors.Errorf("plugin %s is not an engine", enginePath)
+	}
+
+	return terragruntEngine, client, nil
 }
 
 // invoke engine for working directory
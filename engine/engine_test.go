@@ -2,9 +2,14 @@ package engine_test
 
 import (
 	"io"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -44,3 +49,66 @@ func TestReadEngineOutput(t *testing.T) {
 	err := engine.ReadEngineOutput(runOptions, false, outputFn)
 	assert.NoError(t, err)
 }
+
+func TestDownloadEngineConcurrent(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	packageFile := filepath.Join(tmp, "terragrunt-iac-engine-test")
+	require.NoError(t, os.WriteFile(packageFile, []byte("engine binary"), 0644))
+
+	cachePath := t.TempDir()
+	l := logger.CreateLogger()
+
+	var wg sync.WaitGroup
+
+	const runs = 5
+
+	errs := make([]error, runs)
+
+	for i := range runs {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(tmp, "terragrunt.hcl"))
+			if err != nil {
+				errs[i] = err
+
+				return
+			}
+
+			opts.EngineEnabled = true
+			opts.EngineCachePath = cachePath
+			opts.Engine = &options.EngineOptions{Source: "file://" + filepath.ToSlash(packageFile), Type: "rpc"}
+
+			// separate contexts don't share the in-memory locks, the same as separate Terragrunt processes
+			errs[i] = engine.DownloadEngine(engine.WithEngineValues(t.Context()), l, opts)
+		}()
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(tmp, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.EngineCachePath = cachePath
+
+	cached, err := engine.CachedEngines(opts)
+	require.NoError(t, err)
+	require.Len(t, cached, 1)
+
+	content, err := os.ReadFile(cached[0].Path)
+	require.NoError(t, err)
+	assert.Equal(t, "engine binary", string(content))
+
+	// no temp dirs or lock files are left behind
+	entries, err := os.ReadDir(filepath.Dir(cached[0].Path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
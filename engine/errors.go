@@ -0,0 +1,37 @@
+package engine
+
+import "fmt"
+
+// EngineNotLockedForPlatformError is returned when the lock file has no checksum of an engine for the current platform.
+type EngineNotLockedForPlatformError struct {
+	Source   string
+	Platform string
+}
+
+func (err EngineNotLockedForPlatformError) Error() string {
+	return fmt.Sprintf("engine %s has no checksum for platform %s in %s, run `terragrunt engine lock --platform %s` to add it", err.Source, err.Platform, LockFileName, err.Platform)
+}
+
+// EngineChecksumMismatchError is returned when the checksum of an engine package does not match the locked checksum.
+type EngineChecksumMismatchError struct {
+	File     string
+	Expected string
+	Actual   string
+}
+
+func (err EngineChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum of engine package %s is %s, but %s is locked in %s", err.File, err.Actual, err.Expected, LockFileName)
+}
+
+// EngineLockMismatchError is returned when the engine configured for a unit does not match the locked engine.
+type EngineLockMismatchError struct {
+	Source   string
+	Field    string
+	Expected string
+	Actual   string
+	LockFile string
+}
+
+func (err EngineLockMismatchError) Error() string {
+	return fmt.Sprintf("engine %s %s %q does not match %q locked in %s, run `terragrunt engine lock` to update it", err.Source, err.Field, err.Actual, err.Expected, err.LockFile)
+}
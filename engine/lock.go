@@ -0,0 +1,338 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// LockFileName is the name of the file engine versions and checksums are locked in.
+	LockFileName = ".terragrunt-engine.lock.hcl"
+
+	hashPrefix     = "sha256:"
+	lockFileHeader = `# This file is maintained automatically by "terragrunt engine lock".
+# Manual edits may be lost in future updates.
+`
+)
+
+// LockFile pins the version and the checksums of the engine packages of every engine source.
+type LockFile struct {
+	path    string
+	Engines []*LockedEngine `hcl:"engine,block"`
+}
+
+// LockedEngine is the locked version of a single engine source.
+type LockedEngine struct {
+	// Hashes are the SHA256 checksums of the engine package by platform, e.g. `linux_amd64`.
+	Hashes  map[string]string `hcl:"hashes,attr"`
+	Source  string            `hcl:"source,label"`
+	Type    string            `hcl:"type,attr"`
+	Version string            `hcl:"version,attr"`
+}
+
+// NewLockFile creates a new empty lock file stored at the given path.
+func NewLockFile(path string) *LockFile {
+	return &LockFile{path: path}
+}
+
+// FindLockFile returns the path of the lock file in the given directory or the closest of its parent directories,
+// or an empty string if there is none.
+func FindLockFile(dir string) string {
+	for {
+		path := filepath.Join(dir, LockFileName)
+		if util.FileExists(path) {
+			return path
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+
+		dir = parent
+	}
+}
+
+// ReadLockFile reads the lock file at the given path.
+func ReadLockFile(path string) (*LockFile, error) {
+	file, diags := hclparse.NewParser().ParseHCLFile(path)
+	if diags.HasErrors() {
+		return nil, errors.New(diags)
+	}
+
+	lockFile := NewLockFile(path)
+
+	if diags := gohcl.DecodeBody(file.Body, nil, lockFile); diags.HasErrors() {
+		return nil, errors.New(diags)
+	}
+
+	return lockFile, nil
+}
+
+// Path returns the path the lock file is stored at.
+func (lockFile *LockFile) Path() string {
+	return lockFile.path
+}
+
+// Engine returns the locked engine for the given source, or nil if the source is not locked.
+func (lockFile *LockFile) Engine(source string) *LockedEngine {
+	for _, locked := range lockFile.Engines {
+		if locked.Source == source {
+			return locked
+		}
+	}
+
+	return nil
+}
+
+// SetEngine adds the given locked engine to the lock file, replacing the one with the same source.
+func (lockFile *LockFile) SetEngine(locked *LockedEngine) {
+	lockFile.Engines = slices.DeleteFunc(lockFile.Engines, func(existing *LockedEngine) bool {
+		return existing.Source == locked.Source
+	})
+
+	lockFile.Engines = append(lockFile.Engines, locked)
+}
+
+// Write writes the lock file, with the engines sorted by source.
+func (lockFile *LockFile) Write() error {
+	sort.Slice(lockFile.Engines, func(i, j int) bool {
+		return lockFile.Engines[i].Source < lockFile.Engines[j].Source
+	})
+
+	file := hclwrite.NewFile()
+	body := file.Body()
+
+	for _, locked := range lockFile.Engines {
+		body.AppendNewline()
+
+		block := body.AppendNewBlock("engine", []string{locked.Source}).Body()
+		block.SetAttributeValue("type", cty.StringVal(locked.Type))
+		block.SetAttributeValue("version", cty.StringVal(locked.Version))
+
+		hashes := make(map[string]cty.Value, len(locked.Hashes))
+		for platform, hash := range locked.Hashes {
+			hashes[platform] = cty.StringVal(hash)
+		}
+
+		if len(hashes) == 0 {
+			block.SetAttributeValue("hashes", cty.MapValEmpty(cty.String))
+		} else {
+			block.SetAttributeValue("hashes", cty.MapVal(hashes))
+		}
+	}
+
+	const ownerWriteGlobalReadPerms = 0644
+	if err := os.WriteFile(lockFile.path, append([]byte(lockFileHeader), file.Bytes()...), ownerWriteGlobalReadPerms); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// Verify checks the SHA256 checksum of the given engine package against the checksum locked for the current platform.
+func (locked *LockedEngine) Verify(packageFile string) error {
+	return locked.VerifyPlatform(packageFile, currentPlatform())
+}
+
+// VerifyPlatform checks the SHA256 checksum of the given engine package against the checksum locked for the given platform.
+func (locked *LockedEngine) VerifyPlatform(packageFile, platform string) error {
+	expected, ok := locked.Hashes[platform]
+	if !ok {
+		return errors.New(EngineNotLockedForPlatformError{Source: locked.Source, Platform: platform})
+	}
+
+	checksum, err := util.FileSHA256(packageFile)
+	if err != nil {
+		return err
+	}
+
+	if actual := hashPrefix + hex.EncodeToString(checksum); actual != expected {
+		return errors.New(EngineChecksumMismatchError{File: packageFile, Expected: expected, Actual: actual})
+	}
+
+	return nil
+}
+
+// LockEngine resolves the version of the given engine, and computes the checksums of its packages for the given
+// platforms. If no platforms are given, the checksums of all the platforms the engine is released for are locked,
+// or the current platform, if the engine is downloaded from a URL that isn't platform specific.
+func LockEngine(ctx context.Context, l log.Logger, e *options.EngineOptions, platforms []string) (*LockedEngine, error) {
+	locked := &LockedEngine{
+		Source:  e.Source,
+		Type:    e.Type,
+		Version: e.Version,
+		Hashes:  make(map[string]string),
+	}
+
+	if len(locked.Version) == 0 && !strings.Contains(e.Source, "://") {
+		tag, err := lastReleaseVersion(ctx, e.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		locked.Version = tag
+	}
+
+	tempDir, err := os.MkdirTemp("", "terragrunt-engine-lock-")
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			l.Warnf("Failed to clean temp dir %s: %v", tempDir, err)
+		}
+	}()
+
+	if strings.Contains(e.Source, "://") {
+		// The package downloaded from a URL is the same on every platform.
+		packageFile := filepath.Join(tempDir, "engine")
+
+		if err := fetchFile(ctx, l, e.Source, packageFile); err != nil {
+			return nil, err
+		}
+
+		checksum, err := util.FileSHA256(packageFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(platforms) == 0 {
+			platforms = []string{currentPlatform()}
+		}
+
+		for _, platform := range platforms {
+			locked.Hashes[platform] = hashPrefix + hex.EncodeToString(checksum)
+		}
+
+		return locked, nil
+	}
+
+	lockedOpts := &options.EngineOptions{Source: e.Source, Type: e.Type, Version: locked.Version}
+	baseURL := fmt.Sprintf("https://%s/releases/download/%s", e.Source, locked.Version)
+	checksumFile := filepath.Join(tempDir, engineChecksumName(lockedOpts))
+	checksumSigFile := filepath.Join(tempDir, engineChecksumSigName(lockedOpts))
+
+	if err := fetchFile(ctx, l, fmt.Sprintf("%s/%s", baseURL, engineChecksumName(lockedOpts)), checksumFile); err != nil {
+		return nil, err
+	}
+
+	if err := fetchFile(ctx, l, fmt.Sprintf("%s/%s.sig", baseURL, engineChecksumName(lockedOpts)), checksumSigFile); err != nil {
+		return nil, err
+	}
+
+	if err := verifyChecksumsSignature(checksumFile, checksumSigFile); err != nil {
+		return nil, err
+	}
+
+	checksums, err := os.ReadFile(checksumFile)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	for platform, hash := range platformChecksums(lockedOpts, checksums) {
+		if len(platforms) == 0 || slices.Contains(platforms, platform) {
+			locked.Hashes[platform] = hashPrefix + hash
+		}
+	}
+
+	for _, platform := range platforms {
+		if _, ok := locked.Hashes[platform]; !ok {
+			return nil, errors.Errorf("engine %s %s is not released for platform %s", e.Source, locked.Version, platform)
+		}
+	}
+
+	return locked, nil
+}
+
+// lockedEngine returns the locked engine for the engine of the given options from the closest lock file,
+// or nil if there is no lock file, or the engine is not locked in it.
+func lockedEngine(opts *options.TerragruntOptions) (*LockedEngine, string, error) {
+	path := FindLockFile(opts.WorkingDir)
+	if path == "" {
+		return nil, "", nil
+	}
+
+	lockFile, err := ReadLockFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return lockFile.Engine(opts.Engine.Source), path, nil
+}
+
+// checkLockedEngine returns an error if the given engine doesn't match the locked engine.
+func checkLockedEngine(e *options.EngineOptions, locked *LockedEngine, lockFile string) error {
+	if e.Type != locked.Type {
+		return errors.New(EngineLockMismatchError{Source: e.Source, Field: "type", Expected: locked.Type, Actual: e.Type, LockFile: lockFile})
+	}
+
+	if len(e.Version) > 0 && e.Version != locked.Version {
+		return errors.New(EngineLockMismatchError{Source: e.Source, Field: "version", Expected: locked.Version, Actual: e.Version, LockFile: lockFile})
+	}
+
+	return nil
+}
+
+// platformChecksums returns the checksums of the engine packages by platform in the given checksums file.
+func platformChecksums(e *options.EngineOptions, checksums []byte) map[string]string {
+	var (
+		result = make(map[string]string)
+		prefix = fmt.Sprintf("terragrunt-iac-%s_%s_%s_", strings.TrimPrefix(filepath.Base(e.Source), prefixTrim), e.Type, e.Version)
+	)
+
+	for _, line := range bytes.Split(checksums, []byte("\n")) {
+		parts := strings.Fields(string(line))
+		if len(parts) < 2 || !strings.HasPrefix(parts[1], prefix) || !strings.HasSuffix(parts[1], ".zip") { //nolint:mnd
+			continue
+		}
+
+		platform := strings.TrimSuffix(strings.TrimPrefix(parts[1], prefix), ".zip")
+		result[platform] = parts[0]
+	}
+
+	return result
+}
+
+// fetchFile downloads the file at the given URL to the given path.
+func fetchFile(ctx context.Context, l log.Logger, url, path string) error {
+	l.Infof("Downloading %s to %s", url, path)
+
+	client := &getter.Client{
+		Ctx:           ctx,
+		Src:           url,
+		Dst:           path,
+		Mode:          getter.ClientModeFile,
+		Decompressors: map[string]getter.Decompressor{},
+	}
+
+	if err := client.Get(); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// currentPlatform returns the platform Terragrunt runs on, in the format used in engine package names.
+func currentPlatform() string {
+	return runtime.GOOS + "_" + runtime.GOARCH
+}
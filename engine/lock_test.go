@@ -0,0 +1,117 @@
+package engine_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockFileWriteRead(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	unitDir := filepath.Join(tmp, "live", "unit")
+	require.NoError(t, os.MkdirAll(unitDir, os.ModePerm))
+
+	assert.Empty(t, engine.FindLockFile(unitDir))
+
+	lockFile := engine.NewLockFile(filepath.Join(tmp, engine.LockFileName))
+	lockFile.SetEngine(&engine.LockedEngine{
+		Source:  "github.com/acme/terragrunt-engine-b",
+		Type:    "rpc",
+		Version: "v0.1.0",
+		Hashes:  map[string]string{"linux_amd64": "sha256:aaaa", "darwin_arm64": "sha256:bbbb"},
+	})
+	lockFile.SetEngine(&engine.LockedEngine{
+		Source:  "github.com/acme/terragrunt-engine-a",
+		Type:    "rpc",
+		Version: "v0.2.0",
+		Hashes:  map[string]string{},
+	})
+	lockFile.SetEngine(&engine.LockedEngine{
+		Source:  "github.com/acme/terragrunt-engine-a",
+		Type:    "rpc",
+		Version: "v0.3.0",
+		Hashes:  map[string]string{"linux_amd64": "sha256:cccc"},
+	})
+	require.NoError(t, lockFile.Write())
+
+	path := engine.FindLockFile(unitDir)
+	assert.Equal(t, lockFile.Path(), path)
+
+	read, err := engine.ReadLockFile(path)
+	require.NoError(t, err)
+	require.Len(t, read.Engines, 2)
+
+	assert.Equal(t, "github.com/acme/terragrunt-engine-a", read.Engines[0].Source)
+	assert.Equal(t, "v0.3.0", read.Engine("github.com/acme/terragrunt-engine-a").Version)
+	assert.Equal(t, map[string]string{"linux_amd64": "sha256:aaaa", "darwin_arm64": "sha256:bbbb"}, read.Engine("github.com/acme/terragrunt-engine-b").Hashes)
+	assert.Nil(t, read.Engine("github.com/acme/terragrunt-engine-c"))
+}
+
+func TestDownloadEngineLocked(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	packageFile := filepath.Join(tmp, "terragrunt-iac-engine-test")
+	require.NoError(t, os.WriteFile(packageFile, []byte("engine binary"), 0644))
+
+	source := "file://" + filepath.ToSlash(packageFile)
+	e := &options.EngineOptions{Source: source, Type: "rpc"}
+	l := logger.CreateLogger()
+
+	locked, err := engine.LockEngine(t.Context(), l, e, []string{runtime.GOOS + "_" + runtime.GOARCH, "other_arch"})
+	require.NoError(t, err)
+	assert.Len(t, locked.Hashes, 2)
+
+	newOpts := func(t *testing.T, hash string) *options.TerragruntOptions {
+		t.Helper()
+
+		unitDir := t.TempDir()
+
+		lockedWithHash := *locked
+		lockedWithHash.Hashes = map[string]string{runtime.GOOS + "_" + runtime.GOARCH: hash}
+
+		lockFile := engine.NewLockFile(filepath.Join(unitDir, engine.LockFileName))
+		lockFile.SetEngine(&lockedWithHash)
+		require.NoError(t, lockFile.Write())
+
+		opts, err := options.NewTerragruntOptionsForTest(filepath.Join(unitDir, "terragrunt.hcl"))
+		require.NoError(t, err)
+
+		opts.WorkingDir = unitDir
+		opts.EngineEnabled = true
+		opts.EngineCachePath = t.TempDir()
+		opts.Engine = &options.EngineOptions{Source: source, Type: "rpc"}
+
+		return opts
+	}
+
+	t.Run("checksum matches", func(t *testing.T) {
+		t.Parallel()
+
+		opts := newOpts(t, locked.Hashes[runtime.GOOS+"_"+runtime.GOARCH])
+
+		ctx := engine.WithEngineValues(t.Context())
+		require.NoError(t, engine.DownloadEngine(ctx, l, opts))
+		// the cached engine is verified as well
+		require.NoError(t, engine.DownloadEngine(ctx, l, opts))
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		opts := newOpts(t, "sha256:0000")
+
+		err := engine.DownloadEngine(engine.WithEngineValues(t.Context()), l, opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "sha256:0000")
+	})
+}
@@ -15,23 +15,12 @@ import (
 
 // verifyFile verifies the checksums file and the signature file of the passed file
 func verifyFile(checkedFile, checksumsFile, signatureFile string) error {
-	checksums, err := os.ReadFile(checksumsFile)
-	if err != nil {
-		return errors.New(err)
-	}
-
-	checksumsSignature, err := os.ReadFile(signatureFile)
-	if err != nil {
-		return errors.New(err)
-	}
-
 	// validate first checksum file signature
-	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(PublicKey))
-	if err != nil {
-		return errors.New(err)
+	if err := verifyChecksumsSignature(checksumsFile, signatureFile); err != nil {
+		return err
 	}
 
-	_, err = openpgp.CheckDetachedSignature(keyring, bytes.NewReader(checksums), bytes.NewReader(checksumsSignature), nil)
+	checksums, err := os.ReadFile(checksumsFile)
 	if err != nil {
 		return errors.New(err)
 	}
@@ -60,3 +49,28 @@ func verifyFile(checkedFile, checksumsFile, signatureFile string) error {
 
 	return nil
 }
+
+// verifyChecksumsSignature verifies the signature of the checksums file
+func verifyChecksumsSignature(checksumsFile, signatureFile string) error {
+	checksums, err := os.ReadFile(checksumsFile)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	checksumsSignature, err := os.ReadFile(signatureFile)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(PublicKey))
+	if err != nil {
+		return errors.New(err)
+	}
+
+	_, err = openpgp.CheckDetachedSignature(keyring, bytes.NewReader(checksums), bytes.NewReader(checksumsSignature), nil)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
@@ -3,6 +3,12 @@
 // Blobs are copied from cloned repositories to a local store, along with trees.
 // When the same content is requested again, the content is read from the local store,
 // avoiding the need to clone the repository or read from the network.
+//
+// Content downloaded from other sources, such as archives and registry modules, can be
+// ingested into the store the same way, so that identical files are stored once.
+//
+// The store can be backed by a shared Remote, such as a directory or an S3 bucket, that cloned and
+// ingested content is read through from and written back to, so that it is shared between machines.
 package cas
 
 import (
@@ -26,6 +32,15 @@ type Options struct {
 	// StorePath specifies a custom path for the content store
 	// If empty, uses $HOME/.cache/terragrunt/cas/store
 	StorePath string
+
+	// MaxSize is the maximum size of the store in bytes
+	// If set, the least recently used content is evicted after cloning when the store is larger
+	MaxSize int64
+
+	// Remote specifies the URL of a shared tier of the store, either a directory or an s3:// URL
+	// If set, content missing from the store is read from the remote, and content cloned from the
+	// repository or ingested from a directory is written back to it
+	Remote string
 }
 
 // CloneOptions configures the behavior of a specific clone operation
@@ -48,6 +63,7 @@ type CAS struct {
 	cloneStart time.Time
 	store      *Store
 	git        *GitRunner
+	remote     Remote
 	opts       Options
 }
 
@@ -66,10 +82,21 @@ func New(opts Options) (*CAS, error) {
 
 	store := NewStore(opts.StorePath)
 
+	var remote Remote
+
+	if opts.Remote != "" {
+		var err error
+
+		if remote, err = NewRemote(opts.Remote); err != nil {
+			return nil, err
+		}
+	}
+
 	return &CAS{
-		store: store,
-		git:   NewGitRunner(),
-		opts:  opts,
+		store:  store,
+		git:    NewGitRunner(),
+		remote: remote,
+		opts:   opts,
 	}, nil
 }
 
@@ -101,29 +128,101 @@ func (c *CAS) Clone(ctx context.Context, l *log.Logger, opts *CloneOptions, url
 		return err
 	}
 
-	if c.store.NeedsWrite(hash, c.cloneStart) {
-		if err := c.cloneAndStoreContent(ctx, l, opts, url, hash); err != nil {
-			return err
+	err = c.withStoreLock(ctx, l, func() error {
+		if c.store.NeedsWrite(hash, c.cloneStart) {
+			if err := c.storeContent(ctx, l, opts, url, hash); err != nil {
+				return err
+			}
+
+			if err := c.recordRoot(hash); err != nil {
+				return err
+			}
 		}
+
+		return c.linkRoot(ctx, hash, targetDir, false)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.autoGC(l)
+
+	return nil
+}
+
+// withStoreLock calls the given function with the store locked against garbage collections and verifications, in
+// this or other processes, so that the content being stored and linked isn't removed in the meantime.
+func (c *CAS) withStoreLock(ctx context.Context, l *log.Logger, fn func() error) error {
+	unlock, err := c.store.rlock(ctx, l)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	return fn()
+}
+
+// autoGC garbage collects the store if it has a max size. It must be called once the store is unlocked.
+func (c *CAS) autoGC(l *log.Logger) {
+	if c.opts.MaxSize > 0 {
+		c.store.autoGC(l, c.opts.MaxSize)
 	}
+}
 
+// recordRoot records the git tree hash of the stored root tree of the given commit, so that Verify can check it.
+func (c *CAS) recordRoot(hash string) error {
 	content := NewContent(c.store)
 
-	treeData, err := content.Read(hash)
+	data, err := content.Read(hash)
+	if err != nil {
+		return wrapError("read_object", content.getPath(hash), err)
+	}
+
+	tree, err := ParseTree(string(data), "")
 	if err != nil {
 		return err
 	}
 
-	tree, err := ParseTree(string(treeData), targetDir)
+	treeHash, err := gitTreeHash(tree)
 	if err != nil {
 		return err
 	}
 
-	if err := tree.LinkTree(ctx, c.store, targetDir); err != nil {
+	return c.store.recordRoot(hash, treeHash)
+}
+
+// linkRoot links the root tree with the given hash into the target directory, replacing the existing files
+// if replace is true. The store must be locked with withStoreLock.
+func (c *CAS) linkRoot(ctx context.Context, hash, targetDir string, replace bool) error {
+	// Record the access, so that the least recently used content is evicted first
+	if err := c.store.touchRoot(hash); err != nil {
 		return err
 	}
 
-	return nil
+	content := NewContent(c.store)
+
+	treeData, err := content.Read(hash)
+	if err != nil {
+		return err
+	}
+
+	tree, err := ParseTree(string(treeData), targetDir)
+	if err != nil {
+		return err
+	}
+
+	return tree.linkTree(ctx, c.store, targetDir, replace)
+}
+
+// GC garbage collects the content store. See Store.GC.
+func (c *CAS) GC(l *log.Logger, opts GCOptions) (*GCResult, error) {
+	return c.store.GC(l, opts)
+}
+
+// Verify verifies the content store. See Store.Verify.
+func (c *CAS) Verify(ctx context.Context, l *log.Logger, opts VerifyOptions) (*VerifyResult, error) {
+	return c.store.Verify(ctx, l, opts)
 }
 
 func (c *CAS) prepareTargetDirectory(dir, url string) string {
@@ -152,6 +251,34 @@ func (c *CAS) resolveReference(ctx context.Context, url, branch string) (string,
 	return results[0].Hash, nil
 }
 
+// storeContent stores the content of the given commit, read through from the remote if it's there, or cloned from
+// the repository and written back to the remote otherwise. Failures of the remote aren't fatal, since the content
+// can always be cloned from the repository.
+func (c *CAS) storeContent(ctx context.Context, l *log.Logger, opts *CloneOptions, url string, hash string) error {
+	if c.remote == nil {
+		return c.cloneAndStoreContent(ctx, l, opts, url, hash)
+	}
+
+	fetched, err := c.fetchRemoteRoot(ctx, l, hash, opts.IncludedGitFiles)
+	if err != nil {
+		(*l).Warnf("Failed to read CAS content of %s from the remote, cloning it instead: %v", url, err)
+	}
+
+	if fetched {
+		return nil
+	}
+
+	if err := c.cloneAndStoreContent(ctx, l, opts, url, hash); err != nil {
+		return err
+	}
+
+	if err := c.pushRemoteRoot(ctx, l, hash, opts.IncludedGitFiles); err != nil {
+		(*l).Warnf("Failed to write CAS content of %s back to the remote: %v", url, err)
+	}
+
+	return nil
+}
+
 func (c *CAS) cloneAndStoreContent(ctx context.Context, l *log.Logger, opts *CloneOptions, url string, hash string) error {
 	if err := c.git.Clone(ctx, url, true, 1, opts.Branch); err != nil {
 		return err
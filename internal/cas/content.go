@@ -2,12 +2,14 @@ package cas
 
 import (
 	"bufio"
+	"context"
 	"io"
 	"os"
 	"path/filepath"
 	"runtime"
 	"sync"
 
+	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 )
 
@@ -34,12 +36,28 @@ func NewContent(store *Store) *Content {
 
 // Link creates a hard link from the store to the target path
 func (c *Content) Link(hash, targetPath string) error {
+	return c.link(hash, targetPath, false)
+}
+
+// link creates a hard link from the store to the target path. An existing target is kept,
+// unless replace is true, in which case it is replaced with the link.
+func (c *Content) link(hash, targetPath string, replace bool) error {
 	if err := c.ensureTargetDirectory(targetPath); err != nil {
 		return err
 	}
 
 	sourcePath := c.getPath(hash)
 
+	if replace {
+		if err := os.Remove(targetPath); err != nil && !os.IsNotExist(err) {
+			return &WrappedError{
+				Op:   "remove_target",
+				Path: targetPath,
+				Err:  err,
+			}
+		}
+	}
+
 	// Check if target exists
 	if _, err := os.Stat(targetPath); err == nil {
 		// File exists, skip creating link
@@ -217,6 +235,40 @@ func (c *Content) Ensure(l *log.Logger, hash string, data []byte) error {
 
 // EnsureCopy ensures that a content item exists in the store by copying from a file
 func (c *Content) EnsureCopy(l *log.Logger, hash, src string) error {
+	return c.ensureWrite(l, hash, func(f *os.File) error {
+		r, err := os.Open(src)
+		if err != nil {
+			return wrapError("open_source", src, err)
+		}
+
+		defer r.Close()
+
+		if _, err := io.Copy(f, r); err != nil {
+			return wrapError("copy_file", src, err)
+		}
+
+		return nil
+	})
+}
+
+// ensureFetch ensures that a blob exists in the store by fetching it from the remote. The fetched content is
+// verified against its hash before being stored.
+func (c *Content) ensureFetch(ctx context.Context, l *log.Logger, remote Remote, hash string) error {
+	return c.ensureWrite(l, hash, func(f *os.File) error {
+		if err := remote.Get(ctx, hash, f); err != nil {
+			return err
+		}
+
+		if _, valid, _, err := verifyObject(hash, f.Name(), nil); err != nil || !valid {
+			return errors.Join(wrapError("fetch_remote_blob", hash, ErrCorruptedRemoteObject), err)
+		}
+
+		return nil
+	})
+}
+
+// ensureWrite ensures that a content item exists in the store by writing it with the given function.
+func (c *Content) ensureWrite(l *log.Logger, hash string, write func(f *os.File) error) error {
 	path := c.getPath(hash)
 	if c.store.hasContent(path) {
 		return nil
@@ -239,22 +291,41 @@ func (c *Content) EnsureCopy(l *log.Logger, hash, src string) error {
 		return wrapError("create_partition_dir", partitionDir, ErrCreateDir)
 	}
 
-	f, err := os.Create(path)
+	// Write to a unique temporary file first, so that other processes never see partial content
+	f, err := os.CreateTemp(partitionDir, hash+"-*.tmp")
 	if err != nil {
-		return wrapError("create_file", path, err)
+		return wrapError("create_temp_file", partitionDir, err)
 	}
 
-	defer f.Close()
+	tempPath := f.Name()
 
-	r, err := os.Open(src)
-	if err != nil {
-		return wrapError("open_source", src, err)
+	defer func() {
+		if err := os.Remove(tempPath); err != nil && !os.IsNotExist(err) {
+			(*l).Warnf("failed to remove temp file %s: %v", tempPath, err)
+		}
+	}()
+
+	if err := write(f); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return wrapError("close_file", tempPath, err)
+	}
+
+	if err := os.Chmod(tempPath, StoredFilePerms); err != nil {
+		return wrapError("chmod_temp_file", tempPath, err)
 	}
 
-	defer r.Close()
+	if err := os.Rename(tempPath, path); err != nil {
+		// Another process may have stored the same content in the meantime
+		if c.store.hasContent(path) {
+			return nil
+		}
 
-	if _, err := io.Copy(f, r); err != nil {
-		return wrapError("copy_file", src, err)
+		return wrapError("finalize_store", path, err)
 	}
 
 	return nil
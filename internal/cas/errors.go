@@ -28,6 +28,16 @@ const (
 	ErrCreateTempDir Error = "failed to create temporary directory"
 	// ErrCleanupTempDir is returned when failing to clean up a temporary directory
 	ErrCleanupTempDir Error = "failed to clean up temporary directory"
+	// ErrStoreLocked is returned when the store is already being garbage collected, verified, or cloned into
+	ErrStoreLocked Error = "store is locked by another garbage collection, verification, or clone"
+	// ErrUnsupportedRemote is returned when the URL of a remote has an unsupported scheme
+	ErrUnsupportedRemote Error = "unsupported remote, expected a directory or an s3:// URL"
+	// ErrRemoteObjectNotFound is returned when an object isn't in the remote
+	ErrRemoteObjectNotFound Error = "object not found in remote"
+	// ErrCorruptedRemoteObject is returned when the content of an object read from the remote doesn't match its hash
+	ErrCorruptedRemoteObject Error = "object read from remote is corrupted"
+	// ErrRootTreeMismatch is returned when the stored root tree of a commit doesn't match the tree of the commit
+	ErrRootTreeMismatch Error = "stored root tree doesn't match the tree of its commit"
 )
 
 // WrappedError provides additional context for errors
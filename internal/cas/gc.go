@@ -0,0 +1,486 @@
+package cas
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	// DefaultGCMinAge is the default age under which content is never removed by garbage collection,
+	// since it may belong to a clone in progress.
+	DefaultGCMinAge = time.Hour
+
+	// autoGCInterval is the minimum interval between two garbage collections run automatically after a clone.
+	autoGCInterval = 10 * time.Minute
+
+	// storeLockRetryDelay is the interval at which a clone waiting for a garbage collection or a verification of the
+	// store retries to lock it.
+	storeLockRetryDelay = 100 * time.Millisecond
+
+	lockFile    = "store.lock"
+	gcStampFile = "last-gc"
+	tmpSuffix   = ".tmp"
+)
+
+// GCOptions configures the garbage collection of the store.
+type GCOptions struct {
+	// MaxSize is the maximum size of the store in bytes. When the store is larger, the least recently used
+	// root trees are evicted, along with the content only they reference, until it fits. Zero means no limit.
+	MaxSize int64
+
+	// MinAge is the age under which content is never removed. Defaults to DefaultGCMinAge.
+	MinAge time.Duration
+
+	// DryRun reports the content that would be removed, without removing it.
+	DryRun bool
+}
+
+// GCResult is the outcome of a garbage collection of the store.
+type GCResult struct {
+	// Objects and Size are the number and the size of the blobs and trees left in the store.
+	Objects int
+	Size    int64
+
+	// RemovedObjects and RemovedSize are the number and the size of the blobs and trees removed from the store.
+	RemovedObjects int
+	RemovedSize    int64
+
+	// Roots is the number of root trees left in the store, and EvictedRoots the number of the ones evicted.
+	Roots        int
+	EvictedRoots int
+}
+
+// storeObject is a blob or a tree in the store.
+type storeObject struct {
+	modTime time.Time
+	path    string
+	size    int64
+	// refs is the number of root trees referencing the object.
+	refs int
+}
+
+// storeRoot is a root tree of the store, with all the objects reachable from it.
+type storeRoot struct {
+	accessed  time.Time
+	hash      string
+	path      string
+	reachable []string
+}
+
+// GC garbage collects the store. Blobs and trees not reachable from any root tree are removed, then, if the
+// store is larger than the max size, the least recently used root trees are evicted. Content hard linked into
+// a working directory, or more recent than the min age, is never removed.
+//
+// Access is recorded per root tree, so blobs and trees are evicted along with the last root referencing them.
+// Stores written before roots were recorded have none, so all their content is removed as unreachable.
+func (s *Store) GC(l *log.Logger, opts GCOptions) (*GCResult, error) {
+	if opts.MinAge == 0 {
+		opts.MinAge = DefaultGCMinAge
+	}
+
+	if err := os.MkdirAll(s.path, DefaultDirPerms); err != nil {
+		return nil, wrapError("create_store_dir", s.path, ErrCreateDir)
+	}
+
+	unlock, err := s.lock(l)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	gc := &storeGC{store: s, logger: l, opts: opts, result: &GCResult{}}
+
+	if err := gc.run(); err != nil {
+		return nil, err
+	}
+
+	if !opts.DryRun {
+		if err := os.WriteFile(filepath.Join(s.path, gcStampFile), nil, RegularFilePerms); err != nil {
+			return nil, wrapError("write_gc_stamp", filepath.Join(s.path, gcStampFile), err)
+		}
+	}
+
+	return gc.result, nil
+}
+
+// lock exclusively locks the store against concurrent garbage collections, verifications, and clones, returning the
+// function to unlock it. Returns ErrStoreLocked if any of them is in progress, in this or another process.
+func (s *Store) lock(l *log.Logger) (func(), error) {
+	lock := flock.New(filepath.Join(s.path, lockFile))
+
+	locked, err := lock.TryLock()
+	if err != nil {
+		return nil, wrapError("lock_store", lock.Path(), err)
+	}
+
+	if !locked {
+		return nil, wrapError("lock_store", lock.Path(), ErrStoreLocked)
+	}
+
+	return func() { s.unlock(l, lock) }, nil
+}
+
+// rlock locks the store against garbage collections and verifications while content is stored and linked, waiting
+// for the ones in progress, returning the function to unlock it. Any number of clones can hold the lock at once.
+func (s *Store) rlock(ctx context.Context, l *log.Logger) (func(), error) {
+	if err := os.MkdirAll(s.path, DefaultDirPerms); err != nil {
+		return nil, wrapError("create_store_dir", s.path, ErrCreateDir)
+	}
+
+	lock := flock.New(filepath.Join(s.path, lockFile))
+
+	if _, err := lock.TryRLockContext(ctx, storeLockRetryDelay); err != nil {
+		return nil, wrapError("lock_store", lock.Path(), err)
+	}
+
+	return func() { s.unlock(l, lock) }, nil
+}
+
+// unlock unlocks the store. The lock file is kept, since other processes may be waiting to lock it.
+func (s *Store) unlock(l *log.Logger, lock *flock.Flock) {
+	if err := lock.Unlock(); err != nil {
+		(*l).Warnf("failed to unlock %s: %v", lock.Path(), err)
+	}
+}
+
+// walkObjects calls the given function with every blob and tree of the store, and every temporary file of
+// interrupted writes.
+func (s *Store) walkObjects(fn func(name, path string, info os.FileInfo) error) error {
+	partitions, err := os.ReadDir(s.path)
+	if err != nil {
+		return wrapError("read_store_dir", s.path, err)
+	}
+
+	for _, partition := range partitions {
+		if !partition.IsDir() || !isPartition(partition.Name()) {
+			continue
+		}
+
+		partitionDir := filepath.Join(s.path, partition.Name())
+
+		entries, err := os.ReadDir(partitionDir)
+		if err != nil {
+			return wrapError("read_partition_dir", partitionDir, err)
+		}
+
+		for _, entry := range entries {
+			path := filepath.Join(partitionDir, entry.Name())
+
+			info, err := entry.Info()
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			if err != nil {
+				return wrapError("stat_object", path, err)
+			}
+
+			if err := fn(entry.Name(), path, info); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// autoGC garbage collects the store with the given max size, unless it was garbage collected recently.
+func (s *Store) autoGC(l *log.Logger, maxSize int64) {
+	if stat, err := os.Stat(filepath.Join(s.path, gcStampFile)); err == nil && time.Since(stat.ModTime()) < autoGCInterval {
+		return
+	}
+
+	result, err := s.GC(l, GCOptions{MaxSize: maxSize})
+	if errors.Is(err, ErrStoreLocked) {
+		(*l).Debugf("Skipping garbage collection of CAS store %s, which is in use", s.path)
+
+		return
+	}
+
+	if err != nil {
+		(*l).Warnf("failed to garbage collect CAS store %s: %v", s.path, err)
+
+		return
+	}
+
+	(*l).Debugf("Garbage collected CAS store %s: removed %d objects (%s), evicted %d roots", s.path, result.RemovedObjects, util.FormatByteSize(result.RemovedSize), result.EvictedRoots)
+}
+
+// storeGC is a single garbage collection of the store.
+type storeGC struct {
+	store   *Store
+	logger  *log.Logger
+	result  *GCResult
+	objects map[string]*storeObject
+	roots   []*storeRoot
+	opts    GCOptions
+}
+
+func (gc *storeGC) run() error {
+	if err := gc.readObjects(); err != nil {
+		return err
+	}
+
+	if err := gc.readRoots(); err != nil {
+		return err
+	}
+
+	// remove the objects not reachable from any root
+	for hash, object := range gc.objects {
+		if object.refs > 0 || time.Since(object.modTime) < gc.opts.MinAge || gc.isLinked(object) {
+			continue
+		}
+
+		if err := gc.removeObject(hash); err != nil {
+			return err
+		}
+	}
+
+	for _, object := range gc.objects {
+		gc.result.Objects++
+		gc.result.Size += object.size
+	}
+
+	gc.result.Roots = len(gc.roots)
+
+	if gc.opts.MaxSize > 0 && gc.result.Size > gc.opts.MaxSize {
+		return gc.evict()
+	}
+
+	return nil
+}
+
+// readObjects reads the blobs and trees of the store, removing the temporary files of interrupted writes.
+func (gc *storeGC) readObjects() error {
+	gc.objects = make(map[string]*storeObject)
+
+	return gc.store.walkObjects(func(name, path string, info os.FileInfo) error {
+		if strings.HasSuffix(name, tmpSuffix) {
+			if time.Since(info.ModTime()) >= gc.opts.MinAge {
+				return gc.remove(path)
+			}
+
+			return nil
+		}
+
+		gc.objects[name] = &storeObject{path: path, size: info.Size(), modTime: info.ModTime()}
+
+		return nil
+	})
+}
+
+// readRoots reads the root trees of the store and counts the references to the objects reachable from them.
+// Roots whose content is incomplete are dropped.
+func (gc *storeGC) readRoots() error {
+	dir := filepath.Join(gc.store.path, rootsDir)
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return wrapError("read_roots_dir", dir, err)
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		root := &storeRoot{hash: entry.Name(), path: filepath.Join(dir, entry.Name()), accessed: info.ModTime()}
+
+		reachable, ok := gc.reachable(root.hash)
+		if !ok {
+			(*gc.logger).Debugf("Dropping CAS root %s with incomplete content", root.hash)
+
+			if err := gc.remove(root.path); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		root.reachable = reachable
+
+		for _, hash := range reachable {
+			gc.objects[hash].refs++
+		}
+
+		gc.roots = append(gc.roots, root)
+	}
+
+	return nil
+}
+
+// reachable returns the hashes of all the objects reachable from the given root tree, including itself,
+// or false if any of them is missing from the store.
+func (gc *storeGC) reachable(rootHash string) ([]string, bool) {
+	var (
+		seen   = map[string]struct{}{rootHash: {}}
+		result = []string{rootHash}
+		trees  = []string{rootHash}
+	)
+
+	for len(trees) > 0 {
+		hash := trees[len(trees)-1]
+		trees = trees[:len(trees)-1]
+
+		object, ok := gc.objects[hash]
+		if !ok {
+			return nil, false
+		}
+
+		data, err := os.ReadFile(object.path)
+		if err != nil {
+			return nil, false
+		}
+
+		tree, err := ParseTree(string(data), "")
+		if err != nil {
+			return nil, false
+		}
+
+		for _, entry := range tree.Entries() {
+			if _, ok := seen[entry.Hash]; ok {
+				continue
+			}
+
+			if _, ok := gc.objects[entry.Hash]; !ok {
+				return nil, false
+			}
+
+			seen[entry.Hash] = struct{}{}
+			result = append(result, entry.Hash)
+
+			if entry.Type == "tree" {
+				trees = append(trees, entry.Hash)
+			}
+		}
+	}
+
+	return result, true
+}
+
+// evict evicts the least recently used roots, along with the objects only they reference, until the store fits
+// in the max size. Roots accessed more recently than the min age, or with content linked into a working
+// directory, are kept.
+func (gc *storeGC) evict() error {
+	sort.Slice(gc.roots, func(i, j int) bool {
+		return gc.roots[i].accessed.Before(gc.roots[j].accessed)
+	})
+
+	for _, root := range gc.roots {
+		if gc.result.Size <= gc.opts.MaxSize || time.Since(root.accessed) < gc.opts.MinAge {
+			break
+		}
+
+		if gc.isRootLinked(root) {
+			continue
+		}
+
+		if err := gc.remove(root.path); err != nil {
+			return err
+		}
+
+		gc.result.Roots--
+		gc.result.EvictedRoots++
+
+		for _, hash := range root.reachable {
+			object := gc.objects[hash]
+
+			if object.refs--; object.refs > 0 {
+				continue
+			}
+
+			gc.result.Objects--
+			gc.result.Size -= object.size
+
+			if err := gc.removeObject(hash); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isRootLinked returns true if any of the objects reachable from the given root is linked into a working directory.
+func (gc *storeGC) isRootLinked(root *storeRoot) bool {
+	for _, hash := range root.reachable {
+		if gc.isLinked(gc.objects[hash]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isLinked returns true if the given object is hard linked into a working directory.
+func (gc *storeGC) isLinked(object *storeObject) bool {
+	count, err := util.LinkCount(object.path)
+
+	// when in doubt, consider the object as linked
+	return err != nil || count > 1
+}
+
+func (gc *storeGC) removeObject(hash string) error {
+	object := gc.objects[hash]
+
+	if err := gc.remove(object.path); err != nil {
+		return err
+	}
+
+	gc.result.RemovedObjects++
+	gc.result.RemovedSize += object.size
+
+	delete(gc.objects, hash)
+
+	return nil
+}
+
+func (gc *storeGC) remove(path string) error {
+	if gc.opts.DryRun {
+		(*gc.logger).Debugf("Would remove %s", path)
+
+		return nil
+	}
+
+	return removeStoredFile(path)
+}
+
+// removeStoredFile removes a file of the store, which may be read-only.
+func removeStoredFile(path string) error {
+	// read-only files can't be removed on Windows
+	if runtime.GOOS == "windows" {
+		if err := os.Chmod(path, RegularFilePerms); err != nil && !os.IsNotExist(err) {
+			return wrapError("chmod_object", path, err)
+		}
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return wrapError("remove_object", path, err)
+	}
+
+	return nil
+}
+
+// isPartition returns true if the given directory name is a partition of the store, the first two hex
+// characters of the hashes stored in it.
+func isPartition(name string) bool {
+	const partitionLength = 2
+
+	return len(name) == partitionLength && strings.Trim(name, "0123456789abcdef") == ""
+}
@@ -0,0 +1,281 @@
+package cas_test
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/gruntwork-io/terragrunt/internal/cas"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const oldContentAge = 2 * cas.DefaultGCMinAge
+
+// writeObject writes an object to the store, last modified the given time ago.
+func writeObject(t *testing.T, store *cas.Store, hash, data string, age time.Duration) string {
+	t.Helper()
+
+	path := filepath.Join(store.Path(), hash[:2], hash)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), cas.DefaultDirPerms))
+	require.NoError(t, os.WriteFile(path, []byte(data), cas.StoredFilePerms))
+
+	modTime := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+
+	return path
+}
+
+// writeRoot writes a root tree referencing the given blobs to the store, last accessed the given time ago.
+func writeRoot(t *testing.T, store *cas.Store, hash string, age time.Duration, blobs ...string) {
+	t.Helper()
+
+	var data string
+	for i, blob := range blobs {
+		data += fmt.Sprintf("100644 blob %s\tfile%d\n", blob, i)
+	}
+
+	writeObject(t, store, hash, data, oldContentAge)
+
+	path := filepath.Join(store.Path(), "roots", hash)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), cas.DefaultDirPerms))
+	require.NoError(t, os.WriteFile(path, nil, cas.RegularFilePerms))
+
+	accessed := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, accessed, accessed))
+}
+
+func TestStore_GC(t *testing.T) {
+	t.Parallel()
+
+	l := logger.CreateLogger()
+
+	t.Run("removes unreferenced content", func(t *testing.T) {
+		t.Parallel()
+
+		store := cas.NewStore(t.TempDir())
+
+		writeRoot(t, store, "aa00", oldContentAge, "bb01")
+		blob := writeObject(t, store, "bb01", "referenced", oldContentAge)
+		unreferenced := writeObject(t, store, "cc02", "unreferenced", oldContentAge)
+		recent := writeObject(t, store, "dd03", "recent", 0)
+		staleTmp := writeObject(t, store, "ee04.tmp", "stale", oldContentAge)
+		recentTmp := writeObject(t, store, "ff05.tmp", "in progress", 0)
+
+		result, err := store.GC(&l, cas.GCOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.RemovedObjects)
+		assert.Equal(t, int64(len("unreferenced")), result.RemovedSize)
+		assert.Equal(t, 3, result.Objects)
+		assert.Equal(t, 1, result.Roots)
+
+		assert.FileExists(t, blob)
+		assert.FileExists(t, recent)
+		assert.FileExists(t, recentTmp)
+		assert.NoFileExists(t, unreferenced)
+		assert.NoFileExists(t, staleTmp)
+	})
+
+	t.Run("drops roots with missing content", func(t *testing.T) {
+		t.Parallel()
+
+		store := cas.NewStore(t.TempDir())
+
+		writeRoot(t, store, "aa00", oldContentAge, "bb01")
+
+		result, err := store.GC(&l, cas.GCOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, result.Roots)
+		assert.Equal(t, 1, result.RemovedObjects)
+		assert.NoFileExists(t, filepath.Join(store.Path(), "roots", "aa00"))
+	})
+
+	t.Run("evicts least recently used roots", func(t *testing.T) {
+		t.Parallel()
+
+		store := cas.NewStore(t.TempDir())
+
+		writeRoot(t, store, "aa00", 3*oldContentAge, "bb01", "ee04")
+		writeRoot(t, store, "cc02", 2*oldContentAge, "dd03", "ee04")
+		writeRoot(t, store, "ff05", 0, "ab06")
+
+		oldest := writeObject(t, store, "bb01", "oldest content", oldContentAge)
+		older := writeObject(t, store, "dd03", "older content", oldContentAge)
+		shared := writeObject(t, store, "ee04", "shared content", oldContentAge)
+		recent := writeObject(t, store, "ab06", "recent content", oldContentAge)
+
+		result, err := store.GC(&l, cas.GCOptions{MaxSize: 1})
+		require.NoError(t, err)
+
+		// the recently accessed root is kept, even though the store is still too large
+		assert.Equal(t, 2, result.EvictedRoots)
+		assert.Equal(t, 1, result.Roots)
+		assert.Equal(t, 2, result.Objects)
+
+		assert.NoFileExists(t, oldest)
+		assert.NoFileExists(t, older)
+		assert.NoFileExists(t, shared)
+		assert.FileExists(t, recent)
+		assert.FileExists(t, filepath.Join(store.Path(), "roots", "ff05"))
+	})
+
+	t.Run("stops evicting once the store fits", func(t *testing.T) {
+		t.Parallel()
+
+		store := cas.NewStore(t.TempDir())
+
+		writeRoot(t, store, "aa00", 3*oldContentAge, "bb01")
+		writeRoot(t, store, "cc02", 2*oldContentAge, "dd03")
+
+		oldest := writeObject(t, store, "bb01", "oldest content", oldContentAge)
+		older := writeObject(t, store, "dd03", "older content", oldContentAge)
+
+		result, err := store.GC(&l, cas.GCOptions{MaxSize: 50})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.EvictedRoots)
+		assert.NoFileExists(t, oldest)
+		assert.FileExists(t, older)
+	})
+
+	t.Run("keeps content linked into working directories", func(t *testing.T) {
+		t.Parallel()
+
+		store := cas.NewStore(t.TempDir())
+
+		writeRoot(t, store, "aa00", 2*oldContentAge, "bb01")
+		blob := writeObject(t, store, "bb01", "linked content", oldContentAge)
+		unreferenced := writeObject(t, store, "cc02", "unreferenced linked content", oldContentAge)
+
+		workDir := t.TempDir()
+		require.NoError(t, os.Link(blob, filepath.Join(workDir, "main.tf")))
+		require.NoError(t, os.Link(unreferenced, filepath.Join(workDir, "other.tf")))
+
+		result, err := store.GC(&l, cas.GCOptions{MaxSize: 1})
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, result.EvictedRoots)
+		assert.Equal(t, 0, result.RemovedObjects)
+		assert.FileExists(t, blob)
+		assert.FileExists(t, unreferenced)
+	})
+
+	t.Run("dry run removes nothing", func(t *testing.T) {
+		t.Parallel()
+
+		store := cas.NewStore(t.TempDir())
+
+		writeRoot(t, store, "aa00", oldContentAge, "bb01")
+		blob := writeObject(t, store, "bb01", "content", oldContentAge)
+		unreferenced := writeObject(t, store, "cc02", "unreferenced", oldContentAge)
+
+		result, err := store.GC(&l, cas.GCOptions{MaxSize: 1, DryRun: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.EvictedRoots)
+		assert.Equal(t, 3, result.RemovedObjects)
+		assert.FileExists(t, blob)
+		assert.FileExists(t, unreferenced)
+		assert.FileExists(t, filepath.Join(store.Path(), "roots", "aa00"))
+	})
+}
+
+// ageStore makes all the content of the store, and the access times of its roots, older than the min age.
+func ageStore(t *testing.T, storePath string) {
+	t.Helper()
+
+	modTime := time.Now().Add(-oldContentAge)
+
+	err := filepath.WalkDir(storePath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		return os.Chtimes(path, modTime, modTime)
+	})
+	require.NoError(t, err)
+}
+
+func TestCAS_GCWithConcurrentLink(t *testing.T) {
+	t.Parallel()
+
+	var (
+		l         = logger.CreateLogger()
+		storePath = t.TempDir()
+		source    = t.TempDir()
+		lockPath  = filepath.Join(storePath, "store.lock")
+	)
+
+	c, err := cas.New(cas.Options{StorePath: storePath})
+	require.NoError(t, err)
+
+	writeModule(t, source)
+
+	hash, err := c.Ingest(t.Context(), &l, source)
+	require.NoError(t, err)
+
+	// old unreferenced content reused by a new ingest is referenced again before it's linked
+	ageStore(t, storePath)
+	require.NoError(t, os.RemoveAll(filepath.Join(storePath, "roots")))
+
+	hash, err = c.Ingest(t.Context(), &l, source)
+	require.NoError(t, err)
+
+	result, err := c.GC(&l, cas.GCOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.RemovedObjects)
+
+	ageStore(t, storePath)
+
+	// a link in progress in another process keeps the garbage collection from removing the content it links
+	link := flock.New(lockPath)
+
+	locked, err := link.TryRLock()
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	_, err = c.GC(&l, cas.GCOptions{MaxSize: 1})
+	require.ErrorIs(t, err, cas.ErrStoreLocked)
+	require.NoError(t, link.Unlock())
+
+	// a link waits for the garbage collection in progress in another process
+	gc := flock.New(lockPath)
+
+	locked, err = gc.TryLock()
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	var (
+		target = t.TempDir()
+		done   = make(chan error, 1)
+	)
+
+	go func() {
+		done <- c.Link(t.Context(), &l, hash, target)
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("link didn't wait for the garbage collection: %v", err)
+	case <-time.After(time.Second):
+	}
+
+	assert.NoFileExists(t, filepath.Join(target, "main.tf"))
+
+	require.NoError(t, gc.Unlock())
+	require.NoError(t, <-done)
+	assert.FileExists(t, filepath.Join(target, "main.tf"))
+
+	// the linked content is kept
+	result, err = c.GC(&l, cas.GCOptions{MaxSize: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.EvictedRoots)
+	assert.Equal(t, 0, result.RemovedObjects)
+}
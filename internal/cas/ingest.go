@@ -0,0 +1,139 @@
+package cas
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// treeMode is the mode of the tree entries, the same as in git trees.
+const treeMode = "040000"
+
+// Ingest stores the files of the given directory in the store as blobs and its directories as trees, the same
+// way git content is stored, and returns the hash of its root tree. Blobs and trees are addressed by the hash of
+// their content, so identical files and directories are stored once, whatever source they were downloaded from.
+//
+// Symbolic links to files are stored as the files they point to. Symbolic links to directories are skipped.
+//
+// If the store has a remote, the ingested content is written back to it, the same way cloned content is.
+func (c *CAS) Ingest(ctx context.Context, l *log.Logger, dir string) (string, error) {
+	var hash string
+
+	err := c.withStoreLock(ctx, l, func() error {
+		var err error
+
+		if hash, err = c.ingestDir(ctx, l, NewContent(c.store), dir); err != nil {
+			return err
+		}
+
+		// Record the root, so that its content, which may reuse old unreferenced blobs, isn't removed before it's linked
+		if err := c.store.touchRoot(hash); err != nil {
+			return err
+		}
+
+		if c.remote != nil {
+			if err := c.pushRemote(ctx, l, hash); err != nil {
+				(*l).Warnf("Failed to write CAS content of %s back to the remote: %v", dir, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
+
+// Link links the root tree with the given hash, returned by Ingest, into the target directory. Files already in the
+// target directory are replaced. If the tree is missing from the store, it is read through from the remote.
+func (c *CAS) Link(ctx context.Context, l *log.Logger, hash, targetDir string) error {
+	err := c.withStoreLock(ctx, l, func() error {
+		if c.remote != nil && c.store.NeedsWrite(hash, c.cloneStart) {
+			if _, err := c.fetchRemote(ctx, l, hash); err != nil {
+				return err
+			}
+		}
+
+		return c.linkRoot(ctx, hash, filepath.Clean(targetDir), true)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.autoGC(l)
+
+	return nil
+}
+
+func (c *CAS) ingestDir(ctx context.Context, l *log.Logger, content *Content, dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", wrapError("read_dir", dir, err)
+	}
+
+	var data []byte
+
+	// os.ReadDir sorts the entries by name, so the same directory always results in the same tree
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		path := filepath.Join(dir, entry.Name())
+
+		info, err := os.Stat(path)
+		if err != nil {
+			(*l).Warnf("Skipping %s, which can't be stored in the CAS: %v", path, err)
+
+			continue
+		}
+
+		switch {
+		case info.IsDir():
+			if entry.Type()&os.ModeSymlink != 0 {
+				(*l).Warnf("Skipping %s, symbolic links to directories can't be stored in the CAS", path)
+
+				continue
+			}
+
+			hash, err := c.ingestDir(ctx, l, content, path)
+			if err != nil {
+				return "", err
+			}
+
+			data = fmt.Appendf(data, "%s tree %s\t%s\n", treeMode, hash, entry.Name())
+		case info.Mode().IsRegular():
+			hash, err := hashFile(path)
+			if err != nil {
+				return "", wrapError("hash_file", path, err)
+			}
+
+			if err := content.EnsureCopy(l, hash, path); err != nil {
+				return "", err
+			}
+
+			data = fmt.Appendf(data, "%06o blob %s\t%s\n", info.Mode().Perm(), hash, entry.Name())
+		}
+	}
+
+	hash := hashData(data)
+
+	if err := content.Ensure(l, hash, data); err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
+
+func hashData(data []byte) string {
+	h := sha1.Sum(data)
+
+	return hex.EncodeToString(h[:])
+}
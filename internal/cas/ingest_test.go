@@ -0,0 +1,130 @@
+package cas_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/codegen"
+	"github.com/gruntwork-io/terragrunt/internal/cas"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeModule writes a module with a nested directory to the given directory.
+func writeModule(t *testing.T, dir string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "modules", "nested"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "null_resource" "main" {}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "modules", "nested", "main.tf"), []byte(`output "nested" {}`), 0644))
+}
+
+func TestCAS_Ingest(t *testing.T) {
+	t.Parallel()
+
+	l := logger.CreateLogger()
+
+	c, err := cas.New(cas.Options{StorePath: t.TempDir()})
+	require.NoError(t, err)
+
+	first, second := t.TempDir(), t.TempDir()
+	writeModule(t, first)
+	writeModule(t, second)
+
+	firstHash, err := c.Ingest(t.Context(), &l, first)
+	require.NoError(t, err)
+
+	secondHash, err := c.Ingest(t.Context(), &l, second)
+	require.NoError(t, err)
+
+	// identical content results in the same root tree
+	assert.Equal(t, firstHash, secondHash)
+
+	require.NoError(t, os.WriteFile(filepath.Join(second, "variables.tf"), []byte(`variable "name" {}`), 0644))
+
+	changedHash, err := c.Ingest(t.Context(), &l, second)
+	require.NoError(t, err)
+	assert.NotEqual(t, firstHash, changedHash)
+
+	t.Run("link", func(t *testing.T) {
+		t.Parallel()
+
+		firstTarget, secondTarget := t.TempDir(), t.TempDir()
+
+		// existing files are replaced
+		require.NoError(t, os.WriteFile(filepath.Join(firstTarget, "main.tf"), []byte("outdated"), 0644))
+
+		require.NoError(t, c.Link(t.Context(), &l, firstHash, firstTarget))
+		require.NoError(t, c.Link(t.Context(), &l, firstHash, secondTarget))
+
+		for _, path := range []string{"main.tf", filepath.Join("modules", "nested", "main.tf")} {
+			expected, err := os.ReadFile(filepath.Join(first, path))
+			require.NoError(t, err)
+
+			actual, err := os.ReadFile(filepath.Join(firstTarget, path))
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+
+			// the files of both targets are links to the same stored blob
+			firstInfo, err := os.Stat(filepath.Join(firstTarget, path))
+			require.NoError(t, err)
+
+			secondInfo, err := os.Stat(filepath.Join(secondTarget, path))
+			require.NoError(t, err)
+
+			assert.True(t, os.SameFile(firstInfo, secondInfo))
+		}
+
+		assert.NoFileExists(t, filepath.Join(firstTarget, "variables.tf"))
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		t.Parallel()
+
+		firstTarget, secondTarget := t.TempDir(), t.TempDir()
+
+		require.NoError(t, c.Link(t.Context(), &l, firstHash, firstTarget))
+		require.NoError(t, c.Link(t.Context(), &l, firstHash, secondTarget))
+
+		opts, err := options.NewTerragruntOptionsForTest(filepath.Join(firstTarget, "terragrunt.hcl"))
+		require.NoError(t, err)
+
+		// codegen overwrites a linked file
+		require.NoError(t, codegen.WriteToFile(l, opts, firstTarget, codegen.GenerateConfig{
+			Path:             "main.tf",
+			IfExists:         codegen.ExistsOverwrite,
+			Contents:         `resource "null_resource" "generated" {}`,
+			DisableSignature: true,
+		}))
+
+		// the working dir copy overwrites a linked file
+		source := filepath.Join(t.TempDir(), "main.tf")
+		require.NoError(t, os.WriteFile(source, []byte(`output "copied" {}`), 0644))
+		require.NoError(t, util.CopyFile(source, filepath.Join(firstTarget, "modules", "nested", "main.tf")))
+
+		for path, overwritten := range map[string]string{
+			"main.tf": `resource "null_resource" "generated" {}`,
+			filepath.Join("modules", "nested", "main.tf"): `output "copied" {}`,
+		} {
+			actual, err := os.ReadFile(filepath.Join(firstTarget, path))
+			require.NoError(t, err)
+			assert.Contains(t, string(actual), overwritten)
+
+			// the stored blob, linked into the other target, is left unchanged
+			expected, err := os.ReadFile(filepath.Join(first, path))
+			require.NoError(t, err)
+
+			linked, err := os.ReadFile(filepath.Join(secondTarget, path))
+			require.NoError(t, err)
+			assert.Equal(t, expected, linked)
+		}
+
+		result, err := c.Verify(t.Context(), &l, cas.VerifyOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, result.Corrupted)
+	})
+}
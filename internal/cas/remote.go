@@ -0,0 +1,639 @@
+package cas
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// remoteConcurrency is the maximum number of blobs transferred concurrently from or to a remote.
+const remoteConcurrency = 16
+
+// Remote is a shared tier of the store, such as a directory on a shared filesystem or an S3 bucket, which local
+// stores read through and write back to, so that the content cloned on one machine is reused on the others.
+//
+// Objects are addressed by the same hashes as in the local store, except for the root trees of git repositories,
+// which are addressed by their git tree hash, with the commit object stored under the hash of the commit, so that
+// every object read from a remote is verified against its hash. A tree is only written to a remote once all the blobs
+// and trees it references are, so a tree found in a remote is always complete.
+type Remote interface {
+	// Has returns whether the object with the given hash is in the remote.
+	Has(ctx context.Context, hash string) (bool, error)
+
+	// Get writes the content of the object with the given hash to w, returning ErrRemoteObjectNotFound if the
+	// object isn't in the remote.
+	Get(ctx context.Context, hash string, w io.Writer) error
+
+	// Put writes the content of the file at the given path to the remote as the object with the given hash.
+	Put(ctx context.Context, hash, path string) error
+}
+
+// NewRemote returns the remote at the given URL, either a directory, as a path or a `file://` URL, or an S3
+// bucket, as an `s3://bucket/prefix` URL. The `region` and `endpoint` query parameters of S3 URLs configure the
+// region of the bucket and the endpoint of S3-compatible stores.
+func NewRemote(remoteURL string) (Remote, error) {
+	u, err := url.Parse(remoteURL)
+	if err != nil || len(u.Scheme) <= 1 {
+		// plain paths, including Windows paths starting with a drive letter
+		return NewDirRemote(remoteURL), nil
+	}
+
+	switch u.Scheme {
+	case "file":
+		return NewDirRemote(filepath.FromSlash(u.Host + u.Path)), nil
+	case "s3":
+		return newS3RemoteFromURL(u)
+	}
+
+	return nil, wrapErrorWithContext("new_remote", remoteURL, ErrUnsupportedRemote)
+}
+
+// DirRemote is a remote stored in a directory, typically on a filesystem shared between machines.
+// Objects are laid out the same way as in the local store.
+type DirRemote struct {
+	path string
+}
+
+// NewDirRemote returns a remote stored in the given directory.
+func NewDirRemote(path string) *DirRemote {
+	return &DirRemote{path: path}
+}
+
+// Has returns whether the object with the given hash is in the remote.
+func (r *DirRemote) Has(_ context.Context, hash string) (bool, error) {
+	_, err := os.Stat(r.objectPath(hash))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, wrapError("stat_remote_object", r.objectPath(hash), err)
+	}
+
+	return true, nil
+}
+
+// Get writes the content of the object with the given hash to w.
+func (r *DirRemote) Get(_ context.Context, hash string, w io.Writer) error {
+	path := r.objectPath(hash)
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return wrapError("get_remote_object", path, ErrRemoteObjectNotFound)
+	}
+
+	if err != nil {
+		return wrapError("get_remote_object", path, err)
+	}
+
+	defer f.Close()
+
+	if _, err := io.Copy(w, f); err != nil {
+		return wrapError("get_remote_object", path, err)
+	}
+
+	return nil
+}
+
+// Put copies the file at the given path to the remote. The file is copied to a temporary file first, so that other
+// machines never see partial content.
+func (r *DirRemote) Put(_ context.Context, hash, src string) error {
+	path := r.objectPath(hash)
+
+	partitionDir := filepath.Dir(path)
+	if err := os.MkdirAll(partitionDir, DefaultDirPerms); err != nil {
+		return wrapError("create_partition_dir", partitionDir, ErrCreateDir)
+	}
+
+	f, err := os.CreateTemp(partitionDir, hash+"-*"+tmpSuffix)
+	if err != nil {
+		return wrapError("create_temp_file", partitionDir, err)
+	}
+
+	tempPath := f.Name()
+
+	defer os.Remove(tempPath) //nolint:errcheck
+
+	in, err := os.Open(src)
+	if err != nil {
+		f.Close()
+
+		return wrapError("open_source", src, err)
+	}
+
+	defer in.Close()
+
+	if _, err := io.Copy(f, in); err != nil {
+		f.Close()
+
+		return wrapError("put_remote_object", tempPath, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return wrapError("close_file", tempPath, err)
+	}
+
+	if err := os.Chmod(tempPath, StoredFilePerms); err != nil {
+		return wrapError("chmod_temp_file", tempPath, err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		return wrapError("put_remote_object", path, err)
+	}
+
+	return nil
+}
+
+func (r *DirRemote) objectPath(hash string) string {
+	return filepath.Join(r.path, hash[:2], hash)
+}
+
+// S3Remote is a remote stored in an S3 bucket, or in any S3-compatible object store.
+type S3Remote struct {
+	client s3iface.S3API
+	bucket string
+	prefix string
+}
+
+// NewS3Remote returns a remote stored in the given bucket, under the given key prefix.
+func NewS3Remote(client s3iface.S3API, bucket, prefix string) *S3Remote {
+	return &S3Remote{
+		client: client,
+		bucket: bucket,
+		prefix: strings.Trim(prefix, "/"),
+	}
+}
+
+func newS3RemoteFromURL(u *url.URL) (*S3Remote, error) {
+	query := u.Query()
+	config := aws.Config{}
+
+	if region := query.Get("region"); region != "" {
+		config.Region = aws.String(region)
+	}
+
+	// S3-compatible stores are usually only addressable by path
+	if endpoint := query.Get("endpoint"); endpoint != "" {
+		config.Endpoint = aws.String(endpoint)
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, wrapErrorWithContext("new_remote", u.String(), err)
+	}
+
+	return NewS3Remote(s3.New(sess), u.Host, u.Path), nil
+}
+
+// Has returns whether the object with the given hash is in the remote.
+func (r *S3Remote) Has(ctx context.Context, hash string) (bool, error) {
+	_, err := r.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(r.objectKey(hash)),
+	})
+	if isS3NotFound(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, wrapError("head_remote_object", r.objectKey(hash), err)
+	}
+
+	return true, nil
+}
+
+// Get writes the content of the object with the given hash to w.
+func (r *S3Remote) Get(ctx context.Context, hash string, w io.Writer) error {
+	key := r.objectKey(hash)
+
+	out, err := r.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(key),
+	})
+	if isS3NotFound(err) {
+		return wrapError("get_remote_object", key, ErrRemoteObjectNotFound)
+	}
+
+	if err != nil {
+		return wrapError("get_remote_object", key, err)
+	}
+
+	defer out.Body.Close()
+
+	if _, err := io.Copy(w, out.Body); err != nil {
+		return wrapError("get_remote_object", key, err)
+	}
+
+	return nil
+}
+
+// Put uploads the file at the given path to the remote.
+func (r *S3Remote) Put(ctx context.Context, hash, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return wrapError("open_source", src, err)
+	}
+
+	defer f.Close()
+
+	key := r.objectKey(hash)
+
+	if _, err := r.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(key),
+		Body:   f,
+	}); err != nil {
+		return wrapError("put_remote_object", key, err)
+	}
+
+	return nil
+}
+
+func (r *S3Remote) objectKey(hash string) string {
+	return path.Join(r.prefix, hash[:2], hash)
+}
+
+func isS3NotFound(err error) bool {
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) {
+		return false
+	}
+
+	// HEAD requests have no body, so a missing object is only reported by its status code
+	return awsErr.Code() == s3.ErrCodeNoSuchKey || awsErr.Code() == "NotFound"
+}
+
+// fetchRemote reads the tree with the given hash, and the blobs and trees it references, through from the remote
+// to the store. It returns false if the tree isn't in the remote.
+func (c *CAS) fetchRemote(ctx context.Context, l *log.Logger, hash string) (bool, error) {
+	if ok, err := c.remote.Has(ctx, hash); err != nil || !ok {
+		return false, err
+	}
+
+	(*l).Debugf("Reading CAS tree %s through from the remote", hash)
+
+	if err := c.fetchTree(ctx, l, NewContent(c.store), hash, hash, nil); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// fetchRemoteRoot reads the root tree of the commit with the given hash, along with the given files of its .git
+// directory, through from the remote to the store, under the hash of the commit. It returns false if the commit or
+// the git files aren't in the remote.
+//
+// The commit object is verified against the hash of the commit, and the root tree against the tree hash of the
+// commit. The git files aren't part of the commit, so they are only checked to be the files asked for.
+func (c *CAS) fetchRemoteRoot(ctx context.Context, l *log.Logger, hash string, gitFiles []string) (bool, error) {
+	treeHash, err := c.fetchCommitTree(ctx, hash)
+	if err != nil || treeHash == "" {
+		return false, err
+	}
+
+	content := NewContent(c.store)
+
+	var gitFilesData []byte
+
+	if len(gitFiles) > 0 {
+		var ok bool
+
+		if gitFilesData, ok, err = c.fetchGitFiles(ctx, l, content, hash, gitFiles); err != nil || !ok {
+			return false, err
+		}
+	}
+
+	if ok, err := c.remote.Has(ctx, treeHash); err != nil || !ok {
+		return false, err
+	}
+
+	(*l).Debugf("Reading CAS tree %s of commit %s through from the remote", treeHash, hash)
+
+	if err := c.fetchTree(ctx, l, content, treeHash, hash, gitFilesData); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// fetchCommitTree returns the tree hash of the commit with the given hash read from the remote, or an empty hash if
+// the commit isn't in the remote.
+func (c *CAS) fetchCommitTree(ctx context.Context, hash string) (string, error) {
+	var buf bytes.Buffer
+
+	err := c.remote.Get(ctx, hash, &buf)
+	if errors.Is(err, ErrRemoteObjectNotFound) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	treeHash := commitTreeHash(buf.Bytes())
+	if gitObjectHash("commit", buf.Bytes()) != hash || treeHash == "" {
+		return "", wrapError("fetch_remote_commit", hash, ErrCorruptedRemoteObject)
+	}
+
+	return treeHash, nil
+}
+
+// fetchGitFiles reads the entries of the given git files of the commit with the given hash, and their blobs, through
+// from the remote. It returns false if they aren't in the remote.
+func (c *CAS) fetchGitFiles(ctx context.Context, l *log.Logger, content *Content, hash string, gitFiles []string) ([]byte, bool, error) {
+	var (
+		buf bytes.Buffer
+		key = gitFilesKey(hash, gitFiles)
+	)
+
+	err := c.remote.Get(ctx, key, &buf)
+	if errors.Is(err, ErrRemoteObjectNotFound) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The git files that are directories aren't included, so there may be none
+	tree := &Tree{}
+	if buf.Len() > 0 {
+		tree = parseStoredTree(buf.Bytes())
+	}
+
+	if tree == nil || !isGitFilesTree(tree, gitFiles) {
+		return nil, false, wrapError("fetch_remote_git_files", key, ErrCorruptedRemoteObject)
+	}
+
+	for _, entry := range tree.Entries() {
+		if err := content.ensureFetch(ctx, l, c.remote, entry.Hash); err != nil {
+			return nil, false, err
+		}
+	}
+
+	return buf.Bytes(), true, nil
+}
+
+// fetchTree reads the tree with the given hash through from the remote, storing it under storedHash, followed by the
+// given entries of git files.
+func (c *CAS) fetchTree(ctx context.Context, l *log.Logger, content *Content, hash, storedHash string, gitFilesData []byte) error {
+	var buf bytes.Buffer
+
+	if err := c.remote.Get(ctx, hash, &buf); err != nil {
+		return err
+	}
+
+	tree, valid, _ := verifyData(hash, buf.Bytes(), nil)
+	if !valid || tree == nil {
+		return wrapError("fetch_remote_tree", hash, ErrCorruptedRemoteObject)
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(remoteConcurrency)
+
+	for _, entry := range tree.Entries() {
+		if !c.store.NeedsWrite(entry.Hash, c.cloneStart) {
+			continue
+		}
+
+		switch entry.Type {
+		case "tree":
+			if err := c.fetchTree(ctx, l, content, entry.Hash, entry.Hash, nil); err != nil {
+				return err
+			}
+		case "blob":
+			group.Go(func() error {
+				return content.ensureFetch(groupCtx, l, c.remote, entry.Hash)
+			})
+		}
+	}
+
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	// The tree is only stored once the content it references is, so that a tree in the store is always complete
+	return content.Ensure(l, storedHash, append(buf.Bytes(), gitFilesData...))
+}
+
+// pushRemote writes the tree with the given hash, and the blobs and trees it references, back from the store to
+// the remote.
+func (c *CAS) pushRemote(ctx context.Context, l *log.Logger, hash string) error {
+	(*l).Debugf("Writing CAS tree %s back to the remote", hash)
+
+	return c.pushTree(ctx, NewContent(c.store), hash, hash)
+}
+
+// pushRemoteRoot writes the root tree of the cloned commit with the given hash back to the remote under its tree
+// hash, followed by the entries of the given git files included in the root tree, and finally by the commit object
+// under the hash of the commit, so that the commit is only in the remote once all its content is.
+func (c *CAS) pushRemoteRoot(ctx context.Context, l *log.Logger, hash string, gitFiles []string) error {
+	var commit bytes.Buffer
+
+	if err := c.git.CatFile(ctx, hash, &commit); err != nil {
+		return err
+	}
+
+	treeHash := commitTreeHash(commit.Bytes())
+	if gitObjectHash("commit", commit.Bytes()) != hash || treeHash == "" {
+		return wrapError("read_commit", hash, ErrReadTree)
+	}
+
+	content := NewContent(c.store)
+
+	data, err := content.Read(hash)
+	if err != nil {
+		return wrapError("read_object", content.getPath(hash), err)
+	}
+
+	treeData, gitFilesData := splitGitFiles(data)
+
+	tree, err := ParseTree(string(treeData), "")
+	if err != nil {
+		return err
+	}
+
+	if storedTreeHash, err := gitTreeHash(tree); err != nil || storedTreeHash != treeHash {
+		return wrapError("push_remote_root", hash, ErrRootTreeMismatch)
+	}
+
+	gitFilesTree, err := ParseTree(string(gitFilesData), "")
+	if err != nil {
+		return err
+	}
+
+	// The root tree is stored once per commit, along with the git files of the first clone of the commit, which may
+	// not be the ones asked for
+	if !isGitFilesTree(gitFilesTree, gitFiles) {
+		return wrapError("push_remote_root", hash, ErrRootTreeMismatch)
+	}
+
+	(*l).Debugf("Writing CAS tree %s of commit %s back to the remote", treeHash, hash)
+
+	if len(gitFiles) == 0 {
+		if err := c.pushTree(ctx, content, treeHash, hash); err != nil {
+			return err
+		}
+	} else {
+		// The tree is written back without the git files, so it is stored under its own hash as well
+		if err := content.Ensure(l, treeHash, treeData); err != nil {
+			return err
+		}
+
+		if err := c.pushTree(ctx, content, treeHash, treeHash); err != nil {
+			return err
+		}
+
+		for _, entry := range gitFilesTree.Entries() {
+			if err := c.remote.Put(ctx, entry.Hash, content.getPath(entry.Hash)); err != nil {
+				return err
+			}
+		}
+
+		if err := c.putRemoteData(ctx, gitFilesKey(hash, gitFiles), gitFilesData); err != nil {
+			return err
+		}
+	}
+
+	// The commit is written even if the remote has it, to replace the root trees stored under the hash of their
+	// commit by earlier versions
+	return c.putRemoteData(ctx, hash, commit.Bytes())
+}
+
+// putRemoteData writes the given data to the remote as the object with the given key.
+func (c *CAS) putRemoteData(ctx context.Context, key string, data []byte) error {
+	f, err := os.CreateTemp(c.git.WorkDir, key+"-*"+tmpSuffix)
+	if err != nil {
+		return wrapError("create_temp_file", c.git.WorkDir, err)
+	}
+
+	defer os.Remove(f.Name()) //nolint:errcheck
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+
+		return wrapError("write_file", f.Name(), err)
+	}
+
+	if err := f.Close(); err != nil {
+		return wrapError("close_file", f.Name(), err)
+	}
+
+	return c.remote.Put(ctx, key, f.Name())
+}
+
+// commitTreeHash returns the hash of the root tree referenced by the given git commit object, or an empty hash if
+// it isn't a commit.
+func commitTreeHash(data []byte) string {
+	line, _, _ := bytes.Cut(data, []byte("\n"))
+
+	treeHash, ok := bytes.CutPrefix(line, []byte("tree "))
+	if !ok || len(treeHash) != sha1.Size*2 {
+		return ""
+	}
+
+	return string(treeHash)
+}
+
+// splitGitFiles splits the given root tree into the entries of the git tree and the entries of the git files
+// included in it, which are the only paths under .git, since git doesn't allow them.
+func splitGitFiles(data []byte) ([]byte, []byte) {
+	var treeData, gitFilesData []byte
+
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if _, path, _ := bytes.Cut(line, []byte("\t")); bytes.HasPrefix(path, []byte(".git/")) {
+			gitFilesData = append(gitFilesData, line...)
+		} else {
+			treeData = append(treeData, line...)
+		}
+	}
+
+	return treeData, gitFilesData
+}
+
+// isGitFilesTree returns whether the entries of the given tree are blobs of distinct files among the given files of
+// the .git directory.
+func isGitFilesTree(tree *Tree, gitFiles []string) bool {
+	seen := make(map[string]bool, len(gitFiles))
+
+	for _, entry := range tree.Entries() {
+		name, ok := strings.CutPrefix(entry.Path, ".git/")
+		if !ok || entry.Type != "blob" || seen[name] || !slices.Contains(gitFiles, name) {
+			return false
+		}
+
+		seen[name] = true
+	}
+
+	return true
+}
+
+// gitFilesKey returns the key of the entries of the given git files of the commit with the given hash in the
+// remote. Unlike the other objects, their content can't be verified against their key.
+func gitFilesKey(hash string, gitFiles []string) string {
+	return hashData([]byte(hash + "\n" + strings.Join(gitFiles, "\n")))
+}
+
+// pushTree writes the tree stored under storedHash back to the remote under the given hash.
+func (c *CAS) pushTree(ctx context.Context, content *Content, hash, storedHash string) error {
+	// Trees are only written once the content they reference is, so the content of a tree in the remote is too
+	if ok, err := c.remote.Has(ctx, hash); err != nil || ok {
+		return err
+	}
+
+	data, err := content.Read(storedHash)
+	if err != nil {
+		return wrapError("read_object", content.getPath(storedHash), err)
+	}
+
+	tree, err := ParseTree(string(data), "")
+	if err != nil {
+		return err
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(remoteConcurrency)
+
+	for _, entry := range tree.Entries() {
+		switch entry.Type {
+		case "tree":
+			if err := c.pushTree(ctx, content, entry.Hash, entry.Hash); err != nil {
+				return err
+			}
+		case "blob":
+			group.Go(func() error {
+				if ok, err := c.remote.Has(groupCtx, entry.Hash); err != nil || ok {
+					return err
+				}
+
+				return c.remote.Put(groupCtx, entry.Hash, content.getPath(entry.Hash))
+			})
+		}
+	}
+
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	return c.remote.Put(ctx, hash, content.getPath(storedHash))
+}
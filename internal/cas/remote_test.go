@@ -0,0 +1,419 @@
+package cas_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gruntwork-io/terragrunt/internal/cas"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a minimal S3-compatible object store, addressed by path.
+type fakeS3 struct {
+	objects map[string][]byte
+	mu      sync.Mutex
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	t.Helper()
+
+	store := &fakeS3{objects: make(map[string][]byte)}
+
+	server := httptest.NewServer(store)
+	t.Cleanup(server.Close)
+
+	return store, server
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		s.objects[key] = data
+	case http.MethodHead, http.MethodGet:
+		data, ok := s.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<Error><Code>NoSuchKey</Code></Error>`)) //nolint:errcheck
+
+			return
+		}
+
+		w.Write(data) //nolint:errcheck
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *fakeS3) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+func TestRemote(t *testing.T) {
+	t.Parallel()
+
+	_, server := newFakeS3(t)
+
+	sess, err := session.NewSession(&aws.Config{
+		Credentials:      credentials.NewStaticCredentials("test", "test", ""),
+		Endpoint:         aws.String(server.URL),
+		Region:           aws.String("us-east-1"),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	require.NoError(t, err)
+
+	remotes := map[string]cas.Remote{
+		"directory": cas.NewDirRemote(t.TempDir()),
+		"s3":        cas.NewS3Remote(s3.New(sess), "bucket", "/cas/"),
+	}
+
+	for name, remote := range remotes {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			hash := "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+			ok, err := remote.Has(t.Context(), hash)
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			err = remote.Get(t.Context(), hash, io.Discard)
+			require.ErrorIs(t, err, cas.ErrRemoteObjectNotFound)
+
+			src := filepath.Join(t.TempDir(), "object")
+			require.NoError(t, os.WriteFile(src, []byte("content"), 0644))
+			require.NoError(t, remote.Put(t.Context(), hash, src))
+
+			ok, err = remote.Has(t.Context(), hash)
+			require.NoError(t, err)
+			assert.True(t, ok)
+
+			var buf bytes.Buffer
+			require.NoError(t, remote.Get(t.Context(), hash, &buf))
+			assert.Equal(t, "content", buf.String())
+		})
+	}
+}
+
+func TestNewRemote(t *testing.T) {
+	t.Parallel()
+
+	for _, url := range []string{"/mnt/shared/cas", "file:///mnt/shared/cas"} {
+		remote, err := cas.NewRemote(url)
+		require.NoError(t, err)
+		assert.IsType(t, &cas.DirRemote{}, remote)
+	}
+
+	remote, err := cas.NewRemote("s3://bucket/cas?region=us-east-1")
+	require.NoError(t, err)
+	assert.IsType(t, &cas.S3Remote{}, remote)
+
+	_, err = cas.NewRemote("gcs://bucket/cas")
+	require.ErrorIs(t, err, cas.ErrUnsupportedRemote)
+}
+
+// removeRepoObjects removes the objects of the given git repository, so that it can still be listed, but no longer
+// cloned.
+func removeRepoObjects(t *testing.T, dir string) {
+	t.Helper()
+
+	objects, err := filepath.Glob(filepath.Join(dir, ".git", "objects", "??"))
+	require.NoError(t, err)
+
+	for _, path := range objects {
+		require.NoError(t, os.RemoveAll(path))
+	}
+}
+
+func TestCAS_CloneWithRemote(t *testing.T) {
+	t.Parallel()
+
+	l := logger.CreateLogger()
+
+	t.Run("read through", func(t *testing.T) {
+		t.Parallel()
+
+		repoDir := t.TempDir()
+		writeModule(t, repoDir)
+		commitRepo(t, repoDir)
+
+		remoteDir := t.TempDir()
+
+		first, err := cas.New(cas.Options{StorePath: t.TempDir(), Remote: remoteDir})
+		require.NoError(t, err)
+
+		err = first.Clone(t.Context(), &l, &cas.CloneOptions{Dir: filepath.Join(t.TempDir(), "repo")}, "file://"+repoDir)
+		require.NoError(t, err)
+
+		// the repository can no longer be cloned, so the content can only be read from the remote
+		removeRepoObjects(t, repoDir)
+
+		second, err := cas.New(cas.Options{StorePath: t.TempDir(), Remote: remoteDir})
+		require.NoError(t, err)
+
+		targetDir := filepath.Join(t.TempDir(), "repo")
+
+		err = second.Clone(t.Context(), &l, &cas.CloneOptions{Dir: targetDir}, "file://"+repoDir)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(targetDir, "modules", "nested", "main.tf"))
+		require.NoError(t, err)
+		assert.Equal(t, `output "nested" {}`, string(data))
+
+		result, err := second.Verify(t.Context(), &l, cas.VerifyOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, result.Corrupted)
+	})
+
+	t.Run("included git files", func(t *testing.T) {
+		t.Parallel()
+
+		repoDir := t.TempDir()
+		writeModule(t, repoDir)
+		commitRepo(t, repoDir)
+
+		remoteDir := t.TempDir()
+		opts := cas.CloneOptions{IncludedGitFiles: []string{"HEAD", "config"}}
+
+		first, err := cas.New(cas.Options{StorePath: t.TempDir(), Remote: remoteDir})
+		require.NoError(t, err)
+
+		opts.Dir = filepath.Join(t.TempDir(), "repo")
+
+		err = first.Clone(t.Context(), &l, &opts, "file://"+repoDir)
+		require.NoError(t, err)
+
+		removeRepoObjects(t, repoDir)
+
+		second, err := cas.New(cas.Options{StorePath: t.TempDir(), Remote: remoteDir})
+		require.NoError(t, err)
+
+		opts.Dir = filepath.Join(t.TempDir(), "repo")
+
+		err = second.Clone(t.Context(), &l, &opts, "file://"+repoDir)
+		require.NoError(t, err)
+
+		assert.FileExists(t, filepath.Join(opts.Dir, "modules", "nested", "main.tf"))
+		assert.FileExists(t, filepath.Join(opts.Dir, ".git", "HEAD"))
+		assert.FileExists(t, filepath.Join(opts.Dir, ".git", "config"))
+
+		result, err := second.Verify(t.Context(), &l, cas.VerifyOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, result.Corrupted)
+	})
+
+	t.Run("corrupted remote", func(t *testing.T) {
+		t.Parallel()
+
+		repoDir := t.TempDir()
+		writeModule(t, repoDir)
+		commitRepo(t, repoDir)
+
+		remoteDir := t.TempDir()
+
+		first, err := cas.New(cas.Options{StorePath: t.TempDir(), Remote: remoteDir})
+		require.NoError(t, err)
+
+		err = first.Clone(t.Context(), &l, &cas.CloneOptions{Dir: filepath.Join(t.TempDir(), "repo")}, "file://"+repoDir)
+		require.NoError(t, err)
+
+		blobs, err := filepath.Glob(filepath.Join(remoteDir, "*", "*"))
+		require.NoError(t, err)
+
+		for _, path := range blobs {
+			require.NoError(t, os.Chmod(path, 0644))
+			require.NoError(t, os.WriteFile(path, []byte("corrupted"), 0644))
+		}
+
+		// the corrupted content is cloned from the repository instead
+		second, err := cas.New(cas.Options{StorePath: t.TempDir(), Remote: remoteDir})
+		require.NoError(t, err)
+
+		targetDir := filepath.Join(t.TempDir(), "repo")
+
+		err = second.Clone(t.Context(), &l, &cas.CloneOptions{Dir: targetDir}, "file://"+repoDir)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(targetDir, "main.tf"))
+		require.NoError(t, err)
+		assert.Equal(t, `resource "null_resource" "main" {}`, string(data))
+	})
+}
+
+func TestCAS_CloneWithForgedRemoteRoot(t *testing.T) {
+	t.Parallel()
+
+	l := logger.CreateLogger()
+
+	repoDir := t.TempDir()
+	writeModule(t, repoDir)
+	commitRepo(t, repoDir)
+
+	out, err := exec.Command("git", "-C", repoDir, "rev-parse", "HEAD").Output()
+	require.NoError(t, err)
+
+	commitHash := strings.TrimSpace(string(out))
+
+	// a valid tree of other content, stored in the remote under the hash of the commit
+	forgedDir, remoteDir := t.TempDir(), t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(forgedDir, "main.tf"), []byte(`resource "null_resource" "forged" {}`), 0644))
+
+	first, err := cas.New(cas.Options{StorePath: t.TempDir(), Remote: remoteDir})
+	require.NoError(t, err)
+
+	forgedHash, err := first.Ingest(t.Context(), &l, forgedDir)
+	require.NoError(t, err)
+
+	var forged bytes.Buffer
+
+	remote := cas.NewDirRemote(remoteDir)
+	require.NoError(t, remote.Get(t.Context(), forgedHash, &forged))
+
+	forgedPath := filepath.Join(t.TempDir(), "forged")
+	require.NoError(t, os.WriteFile(forgedPath, forged.Bytes(), 0644))
+	require.NoError(t, remote.Put(t.Context(), commitHash, forgedPath))
+
+	// the forged tree doesn't match the commit, so the repository is cloned instead
+	second, err := cas.New(cas.Options{StorePath: t.TempDir(), Remote: remoteDir})
+	require.NoError(t, err)
+
+	targetDir := filepath.Join(t.TempDir(), "repo")
+
+	err = second.Clone(t.Context(), &l, &cas.CloneOptions{Dir: targetDir}, "file://"+repoDir)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(targetDir, "main.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, `resource "null_resource" "main" {}`, string(data))
+
+	// the forged tree is replaced by the commit in the remote, so the content is read through from it next time
+	removeRepoObjects(t, repoDir)
+
+	third, err := cas.New(cas.Options{StorePath: t.TempDir(), Remote: remoteDir})
+	require.NoError(t, err)
+
+	targetDir = filepath.Join(t.TempDir(), "repo")
+
+	err = third.Clone(t.Context(), &l, &cas.CloneOptions{Dir: targetDir}, "file://"+repoDir)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(targetDir, "modules", "nested", "main.tf"))
+
+	result, err := third.Verify(t.Context(), &l, cas.VerifyOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, result.Corrupted)
+	assert.Empty(t, result.Unverified)
+}
+
+func TestCAS_CloneWithS3Remote(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+
+	l := logger.CreateLogger()
+
+	store, server := newFakeS3(t)
+	remoteURL := "s3://bucket/cas?region=us-east-1&endpoint=" + server.URL
+
+	repoDir := t.TempDir()
+	writeModule(t, repoDir)
+	commitRepo(t, repoDir)
+
+	first, err := cas.New(cas.Options{StorePath: t.TempDir(), Remote: remoteURL})
+	require.NoError(t, err)
+
+	err = first.Clone(t.Context(), &l, &cas.CloneOptions{Dir: filepath.Join(t.TempDir(), "repo")}, "file://"+repoDir)
+	require.NoError(t, err)
+
+	// two blobs, two trees, the root tree and the commit
+	assert.Len(t, store.keys(), 6)
+
+	for _, key := range store.keys() {
+		assert.True(t, strings.HasPrefix(key, "bucket/cas/"), key)
+	}
+
+	removeRepoObjects(t, repoDir)
+
+	second, err := cas.New(cas.Options{StorePath: t.TempDir(), Remote: remoteURL})
+	require.NoError(t, err)
+
+	targetDir := filepath.Join(t.TempDir(), "repo")
+
+	err = second.Clone(t.Context(), &l, &cas.CloneOptions{Dir: targetDir}, "file://"+repoDir)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(targetDir, "modules", "nested", "main.tf"))
+}
+
+func TestCAS_IngestWithRemote(t *testing.T) {
+	t.Parallel()
+
+	l := logger.CreateLogger()
+
+	sourceDir, remoteDir := t.TempDir(), t.TempDir()
+	writeModule(t, sourceDir)
+
+	first, err := cas.New(cas.Options{StorePath: t.TempDir(), Remote: remoteDir})
+	require.NoError(t, err)
+
+	hash, err := first.Ingest(t.Context(), &l, sourceDir)
+	require.NoError(t, err)
+
+	// the ingested tree is written back to the remote
+	ok, err := cas.NewDirRemote(remoteDir).Has(t.Context(), hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// the ingested tree is missing from the store of the second instance, so it is read through from the remote
+	second, err := cas.New(cas.Options{StorePath: t.TempDir(), Remote: remoteDir})
+	require.NoError(t, err)
+
+	targetDir := t.TempDir()
+
+	require.NoError(t, second.Link(t.Context(), &l, hash, targetDir))
+
+	data, err := os.ReadFile(filepath.Join(targetDir, "modules", "nested", "main.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, `output "nested" {}`, string(data))
+
+	result, err := second.Verify(t.Context(), &l, cas.VerifyOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, result.Corrupted)
+
+	// without the remote, the tree can't be linked
+	third, err := cas.New(cas.Options{StorePath: t.TempDir()})
+	require.NoError(t, err)
+	require.Error(t, third.Link(t.Context(), &l, hash, t.TempDir()))
+}
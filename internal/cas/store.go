@@ -7,6 +7,9 @@ import (
 	"time"
 )
 
+// rootsDir is the directory of the store where the root trees are recorded, along with their last access time.
+const rootsDir = "roots"
+
 // Store manages the store directory and locks to prevent concurrent writes
 type Store struct {
 	locks   map[string]*sync.Mutex
@@ -58,3 +61,46 @@ func (s *Store) writeInProgress(path string, cloneStart time.Time) bool {
 
 	return modifiedTime.After(cloneStart)
 }
+
+// recordRoot records the given hash as a root tree of the store, along with the git tree hash of its content.
+// The root trees of git repositories are stored under the hash of their commit, so the recorded tree hash is what
+// their content is verified against.
+func (s *Store) recordRoot(hash, treeHash string) error {
+	path := filepath.Join(s.path, rootsDir, hash)
+
+	if err := os.MkdirAll(filepath.Dir(path), DefaultDirPerms); err != nil {
+		return wrapError("create_roots_dir", filepath.Dir(path), ErrCreateDir)
+	}
+
+	if err := os.WriteFile(path, []byte(treeHash), RegularFilePerms); err != nil {
+		return wrapError("record_root", path, err)
+	}
+
+	return nil
+}
+
+// touchRoot records the given hash as a root tree of the store, last accessed now.
+func (s *Store) touchRoot(hash string) error {
+	path := filepath.Join(s.path, rootsDir, hash)
+
+	if err := os.MkdirAll(filepath.Dir(path), DefaultDirPerms); err != nil {
+		return wrapError("create_roots_dir", filepath.Dir(path), ErrCreateDir)
+	}
+
+	now := time.Now()
+
+	err := os.Chtimes(path, now, now)
+	if err == nil {
+		return nil
+	}
+
+	if !os.IsNotExist(err) {
+		return wrapError("touch_root", path, err)
+	}
+
+	if err := os.WriteFile(path, nil, RegularFilePerms); err != nil {
+		return wrapError("create_root", path, err)
+	}
+
+	return nil
+}
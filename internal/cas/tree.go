@@ -101,6 +101,11 @@ func ParseTree(output, path string) (*Tree, error) {
 
 // LinkTree writes the tree to a target directory
 func (t *Tree) LinkTree(ctx context.Context, store *Store, targetDir string) error {
+	return t.linkTree(ctx, store, targetDir, false)
+}
+
+// linkTree writes the tree to a target directory, replacing the existing files if replace is true.
+func (t *Tree) linkTree(ctx context.Context, store *Store, targetDir string, replace bool) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -146,7 +151,7 @@ func (t *Tree) LinkTree(ctx context.Context, store *Store, targetDir string) err
 
 			switch entry.Type {
 			case "blob":
-				if err := content.Link(entry.Hash, entryPath); err != nil {
+				if err := content.link(entry.Hash, entryPath, replace); err != nil {
 					errMu.Lock()
 					errs = append(errs, wrapError("link_blob", entryPath, err))
 					errMu.Unlock()
@@ -175,7 +180,7 @@ func (t *Tree) LinkTree(ctx context.Context, store *Store, targetDir string) err
 					return
 				}
 
-				if err := subTree.LinkTree(ctx, store, entryPath); err != nil {
+				if err := subTree.linkTree(ctx, store, entryPath, replace); err != nil {
 					errMu.Lock()
 					errs = append(errs, wrapError("link_subtree", entryPath, err))
 					errMu.Unlock()
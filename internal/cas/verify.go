@@ -0,0 +1,307 @@
+package cas
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// writablePerms are the permission bits of writable files.
+const writablePerms = 0222
+
+// VerifyOptions configures the verification of the store.
+type VerifyOptions struct {
+	// Repair removes the corrupted blobs and trees from the store, along with the trees referencing them, so that
+	// they are fetched again by the next clone.
+	Repair bool
+
+	// EnforceReadOnly makes the writable blobs and trees of the store read-only.
+	EnforceReadOnly bool
+}
+
+// VerifyResult is the outcome of a verification of the store.
+type VerifyResult struct {
+	// Corrupted are the hashes of the blobs and trees whose content doesn't match their hash.
+	Corrupted []string
+
+	// Writable are the hashes of the blobs and trees that are not read-only.
+	Writable []string
+
+	// Unverified are the hashes of the root trees of git repositories without a recorded tree hash, such as the
+	// ones stored by older versions of Terragrunt, which are only checked to be valid trees.
+	Unverified []string
+
+	// Removed are the hashes of the blobs and trees removed by the repair: the corrupted ones and the trees
+	// referencing them.
+	Removed []string
+
+	// Objects is the number of blobs and trees verified.
+	Objects int
+}
+
+// Verify rehashes every blob and tree of the store, reporting the ones whose content doesn't match their hash.
+//
+// Content is hashed the way it was stored: either with hashFile, for content ingested from a directory, or as a
+// git object, for content cloned from a git repository. The root trees of git repositories are stored under the
+// hash of their commit, so they are hashed as git trees and compared with the tree hash recorded in the roots
+// directory when they were stored. Root trees without a recorded tree hash are reported as unverified.
+func (s *Store) Verify(ctx context.Context, l *log.Logger, opts VerifyOptions) (*VerifyResult, error) {
+	result := &VerifyResult{}
+
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		return result, nil
+	}
+
+	unlock, err := s.lock(l)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	roots, err := s.rootHashes()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		paths = make(map[string]string)
+		// parents are the trees referencing every blob and tree
+		parents = make(map[string][]string)
+	)
+
+	err = s.walkObjects(func(hash, path string, info os.FileInfo) error {
+		if strings.HasSuffix(hash, tmpSuffix) {
+			return nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		result.Objects++
+		paths[hash] = path
+
+		tree, valid, unverified, err := verifyObject(hash, path, roots)
+		if err != nil {
+			return err
+		}
+
+		if !valid {
+			(*l).Warnf("CAS object %s is corrupted", path)
+
+			result.Corrupted = append(result.Corrupted, hash)
+		}
+
+		if unverified {
+			(*l).Debugf("CAS root tree %s has no recorded tree hash, only checked it is a valid tree", path)
+
+			result.Unverified = append(result.Unverified, hash)
+		}
+
+		if tree != nil {
+			for _, entry := range tree.Entries() {
+				parents[entry.Hash] = append(parents[entry.Hash], hash)
+			}
+		}
+
+		if info.Mode().Perm()&writablePerms != 0 {
+			result.Writable = append(result.Writable, hash)
+
+			if opts.EnforceReadOnly {
+				if err := os.Chmod(path, StoredFilePerms); err != nil {
+					return wrapError("chmod_object", path, err)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.Repair || len(result.Corrupted) == 0 {
+		return result, nil
+	}
+
+	// The trees referencing corrupted content are removed as well, all the way up to the roots, since the content
+	// of the trees that are already stored isn't fetched again.
+	removed := make(map[string]struct{})
+	pending := append([]string{}, result.Corrupted...)
+
+	for len(pending) > 0 {
+		hash := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if _, ok := removed[hash]; ok {
+			continue
+		}
+
+		removed[hash] = struct{}{}
+		pending = append(pending, parents[hash]...)
+
+		path, ok := paths[hash]
+		if !ok {
+			continue
+		}
+
+		if err := removeStoredFile(path); err != nil {
+			return nil, err
+		}
+
+		if _, ok := roots[hash]; ok {
+			if err := removeStoredFile(filepath.Join(s.path, rootsDir, hash)); err != nil {
+				return nil, err
+			}
+		}
+
+		result.Removed = append(result.Removed, hash)
+	}
+
+	return result, nil
+}
+
+// rootHashes returns the hashes of the root trees of the store, mapped to their recorded tree hashes, which are empty
+// for the root trees of ingested content and the ones recorded by older versions of Terragrunt.
+func (s *Store) rootHashes() (map[string]string, error) {
+	dir := filepath.Join(s.path, rootsDir)
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, wrapError("read_roots_dir", dir, err)
+	}
+
+	roots := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, wrapError("read_root", path, err)
+		}
+
+		roots[entry.Name()] = strings.TrimSpace(string(data))
+	}
+
+	return roots, nil
+}
+
+// verifyObject returns whether the object with the given hash matches its content, along with its parsed content if
+// it's a tree. See verifyData.
+func verifyObject(hash, path string, roots map[string]string) (*Tree, bool, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, false, wrapError("read_object", path, err)
+	}
+
+	tree, valid, unverified := verifyData(hash, data, roots)
+
+	return tree, valid, unverified, nil
+}
+
+// verifyData returns whether the object with the given hash matches the given content, along with the parsed
+// content if it's a tree, and whether it is a root tree without a recorded tree hash, which is only checked to be
+// a valid tree.
+func verifyData(hash string, data []byte, roots map[string]string) (*Tree, bool, bool) {
+	tree := parseStoredTree(data)
+
+	// content ingested from a directory, or git files stored along with the root trees
+	if hashData(data) == hash || gitObjectHash("blob", data) == hash {
+		return tree, true, false
+	}
+
+	if tree == nil {
+		return nil, false, false
+	}
+
+	expected := hash
+
+	if rootTreeHash, ok := roots[hash]; ok {
+		if rootTreeHash == "" {
+			return tree, true, true
+		}
+
+		expected = rootTreeHash
+	}
+
+	treeHash, err := gitTreeHash(tree)
+
+	return tree, err == nil && treeHash == expected, false
+}
+
+// parseStoredTree parses the given content as a tree, returning nil if it isn't one.
+func parseStoredTree(data []byte) *Tree {
+	if len(data) == 0 {
+		return nil
+	}
+
+	tree, err := ParseTree(string(data), "")
+	if err != nil {
+		return nil
+	}
+
+	for _, entry := range tree.Entries() {
+		if entry.Type != "blob" && entry.Type != "tree" && entry.Type != "commit" {
+			return nil
+		}
+
+		if _, err := hex.DecodeString(entry.Hash); err != nil || len(entry.Hash) != sha1.Size*2 {
+			return nil
+		}
+	}
+
+	return tree
+}
+
+// gitTreeHash returns the hash of the given tree as a git object.
+func gitTreeHash(tree *Tree) (string, error) {
+	var body bytes.Buffer
+
+	for _, entry := range tree.Entries() {
+		name := entry.Path
+
+		// git ls-tree quotes the paths with special characters the same way as Go
+		if strings.HasPrefix(name, `"`) {
+			unquoted, err := strconv.Unquote(name)
+			if err != nil {
+				return "", err
+			}
+
+			name = unquoted
+		}
+
+		hash, err := hex.DecodeString(entry.Hash)
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(&body, "%s %s\x00", strings.TrimLeft(entry.Mode, "0"), name)
+		body.Write(hash)
+	}
+
+	return gitObjectHash("tree", body.Bytes()), nil
+}
+
+// gitObjectHash returns the hash of the given content as a git object of the given type.
+func gitObjectHash(objectType string, data []byte) string {
+	h := sha1.New()
+
+	fmt.Fprintf(h, "%s %d\x00", objectType, len(data))
+	h.Write(data)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,146 @@
+package cas_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/internal/cas"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitRepo initializes a git repository in the given directory and commits its files.
+func commitRepo(t *testing.T, dir string) {
+	t.Helper()
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "initial commit"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+}
+
+func TestCAS_Verify(t *testing.T) {
+	t.Parallel()
+
+	l := logger.CreateLogger()
+
+	t.Run("ingested content", func(t *testing.T) {
+		t.Parallel()
+
+		storePath := t.TempDir()
+
+		c, err := cas.New(cas.Options{StorePath: storePath})
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		writeModule(t, dir)
+
+		rootHash, err := c.Ingest(t.Context(), &l, dir)
+		require.NoError(t, err)
+
+		result, err := c.Verify(t.Context(), &l, cas.VerifyOptions{})
+		require.NoError(t, err)
+
+		// two blobs and three trees
+		assert.Equal(t, 5, result.Objects)
+		assert.Empty(t, result.Corrupted)
+		assert.Empty(t, result.Writable)
+
+		// corrupt the nested module through a working directory it is linked into
+		workDir := t.TempDir()
+		require.NoError(t, c.Link(t.Context(), &l, rootHash, workDir))
+
+		nestedPath := filepath.Join(workDir, "modules", "nested", "main.tf")
+		require.NoError(t, os.Chmod(nestedPath, 0644))
+		require.NoError(t, os.WriteFile(nestedPath, []byte("errant edit"), 0644))
+
+		result, err = c.Verify(t.Context(), &l, cas.VerifyOptions{})
+		require.NoError(t, err)
+		assert.Len(t, result.Corrupted, 1)
+		assert.Len(t, result.Writable, 1)
+		assert.Empty(t, result.Removed)
+
+		result, err = c.Verify(t.Context(), &l, cas.VerifyOptions{Repair: true, EnforceReadOnly: true})
+		require.NoError(t, err)
+
+		// the corrupted blob is removed along with the trees up to the root, but not the intact blob
+		assert.Len(t, result.Removed, 4)
+		assert.Contains(t, result.Removed, rootHash)
+
+		result, err = c.Verify(t.Context(), &l, cas.VerifyOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Objects)
+		assert.Empty(t, result.Corrupted)
+
+		// the content is stored again by the next ingestion
+		repairedHash, err := c.Ingest(t.Context(), &l, dir)
+		require.NoError(t, err)
+		assert.Equal(t, rootHash, repairedHash)
+	})
+
+	t.Run("git content", func(t *testing.T) {
+		t.Parallel()
+
+		repoDir := t.TempDir()
+		writeModule(t, repoDir)
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "file with spaces.tf"), nil, 0644))
+		commitRepo(t, repoDir)
+
+		storePath := t.TempDir()
+
+		c, err := cas.New(cas.Options{StorePath: storePath})
+		require.NoError(t, err)
+
+		err = c.Clone(t.Context(), &l, &cas.CloneOptions{
+			Dir:              filepath.Join(t.TempDir(), "repo"),
+			IncludedGitFiles: []string{"HEAD"},
+		}, "file://"+repoDir)
+		require.NoError(t, err)
+
+		result, err := c.Verify(t.Context(), &l, cas.VerifyOptions{})
+		require.NoError(t, err)
+
+		assert.Positive(t, result.Objects)
+		assert.Empty(t, result.Corrupted)
+		assert.Empty(t, result.Unverified)
+
+		// the root tree is stored under the hash of the commit
+		roots, err := os.ReadDir(filepath.Join(storePath, "roots"))
+		require.NoError(t, err)
+		require.Len(t, roots, 1)
+
+		commitHash := roots[0].Name()
+		rootPath := filepath.Join(storePath, commitHash[:2], commitHash)
+
+		// a root tree which is still a valid tree, but not the stored one, is reported as corrupted
+		data, err := os.ReadFile(rootPath)
+		require.NoError(t, err)
+
+		lines := strings.SplitAfter(string(data), "\n")
+
+		require.NoError(t, os.Chmod(rootPath, 0644))
+		require.NoError(t, os.WriteFile(rootPath, []byte(strings.Join(lines[1:], "")), 0644))
+
+		result, err = c.Verify(t.Context(), &l, cas.VerifyOptions{EnforceReadOnly: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{commitHash}, result.Corrupted)
+
+		// root trees without a recorded tree hash can only be checked to be valid trees
+		require.NoError(t, os.WriteFile(filepath.Join(storePath, "roots", commitHash), nil, 0644))
+
+		result, err = c.Verify(t.Context(), &l, cas.VerifyOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, result.Corrupted)
+		assert.Equal(t, []string{commitHash}, result.Unverified)
+	})
+}
@@ -211,9 +211,18 @@ func (cmd *Command) parseFlags(ctx *Context, args Args) ([]string, error) {
 	args, builtinCmd := args.Split(BuiltinCmdSep)
 
 	for i := 0; len(args) > 0; i++ {
-		if i == 0 {
+		switch shadowedNames := cmd.shadowedFlagNames(undefArgs); {
+		case i == 0:
 			args, err = cmd.flagSetParse(ctx, flagSet, args)
-		} else {
+		case len(shadowedNames) > 0:
+			// The subcommand defines its own flags with the same names, which take precedence.
+			shadowedFlagSet, flagSetErr := cmd.Flags.WithSubcommandScope().Exclude(shadowedNames...).NewFlagSet(cmd.Name, errHandler)
+			if flagSetErr != nil {
+				return nil, flagSetErr
+			}
+
+			args, err = cmd.flagSetParse(ctx, shadowedFlagSet, args)
+		default:
 			args, err = cmd.flagSetParse(ctx, flagSetWithSubcommandScope, args)
 		}
 
@@ -240,6 +249,26 @@ func (cmd *Command) parseFlags(ctx *Context, args Args) ([]string, error) {
 	return undefArgs, nil
 }
 
+// shadowedFlagNames returns the names of the flags defined by the subcommands named in the given args.
+func (cmd *Command) shadowedFlagNames(args Args) []string {
+	var (
+		names []string
+		cmds  = cmd.Subcommands
+	)
+
+	for _, arg := range args {
+		subCmd := cmds.Get(arg)
+		if subCmd == nil {
+			continue
+		}
+
+		names = append(names, subCmd.Flags.Names()...)
+		cmds = subCmd.Subcommands
+	}
+
+	return names
+}
+
 func (cmd *Command) flagSetParse(ctx *Context, flagSet *libflag.FlagSet, args Args) ([]string, error) {
 	var (
 		undefArgs []string
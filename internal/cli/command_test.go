@@ -191,6 +191,66 @@ func TestCommandRun(t *testing.T) {
 	}
 }
 
+func TestCommandRunShadowedFlag(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		args                  []string
+		expectedGlobalVersion bool
+		expectedStateVersion  string
+	}{
+		{
+			args:                 []string{"cmd-bar", "--version", "2025-01-01"},
+			expectedStateVersion: "2025-01-01",
+		},
+		{
+			args:                 []string{"cmd-bar", "--state-version", "2025-01-01"},
+			expectedStateVersion: "2025-01-01",
+		},
+		{
+			args:                  []string{"--version", "cmd-bar"},
+			expectedGlobalVersion: true,
+		},
+		{
+			args:                  []string{"cmd-baz", "--version"},
+			expectedGlobalVersion: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("testCase-%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				globalVersion bool
+				stateVersion  string
+			)
+
+			command := cli.Command{
+				Flags: cli.Flags{&cli.BoolFlag{Name: "version", Destination: &globalVersion}},
+				Subcommands: cli.Commands{
+					&cli.Command{
+						Name:   "cmd-bar",
+						Flags:  cli.Flags{&cli.GenericFlag[string]{Name: "state-version", Aliases: []string{"version"}, Destination: &stateVersion}},
+						Action: func(ctx *cli.Context) error { return nil },
+					},
+					&cli.Command{
+						Name:   "cmd-baz",
+						Action: func(ctx *cli.Context) error { return nil },
+					},
+				},
+			}
+
+			app := &cli.App{App: &urfaveCli.App{Writer: io.Discard}}
+			ctx := cli.NewAppContext(t.Context(), app, tc.args)
+
+			require.NoError(t, command.Run(ctx, tc.args))
+			assert.Equal(t, tc.expectedGlobalVersion, globalVersion)
+			assert.Equal(t, tc.expectedStateVersion, stateVersion)
+		})
+	}
+}
+
 func TestCommandHasName(t *testing.T) {
 	t.Parallel()
 
@@ -3,6 +3,7 @@ package cli
 import (
 	libflag "flag"
 	"io"
+	"slices"
 	"sort"
 
 	"github.com/gruntwork-io/go-commons/collections"
@@ -67,6 +68,19 @@ func (flags Flags) Filter(names ...string) Flags {
 	return filtered
 }
 
+// Exclude returns a list of flags without the flags that have any of the given names.
+func (flags Flags) Exclude(names ...string) Flags {
+	var filtered = make(Flags, 0, len(flags))
+
+	for _, flag := range flags {
+		if !slices.ContainsFunc(flag.Names(), func(name string) bool { return slices.Contains(names, name) }) {
+			filtered = append(filtered, flag)
+		}
+	}
+
+	return filtered
+}
+
 // Add adds a new flag to the list.
 func (flags Flags) Add(newFlags ...Flag) Flags {
 	return append(flags, newFlags...)
@@ -144,6 +144,14 @@ func (d *Discovery) WithDiscoverDependencies() *Discovery {
 	return d
 }
 
+// WithParse sets the RequiresParse flag to true, so that discovered configurations are parsed even if nothing else
+// requires it.
+func (d *Discovery) WithParse() *Discovery {
+	d.requiresParse = true
+
+	return d
+}
+
 // WithParseExclude sets the ParseExclude flag to true.
 func (d *Discovery) WithParseExclude() *Discovery {
 	d.parseExclude = true
@@ -0,0 +1,283 @@
+// Package filter implements the expression language of the `--filter` flag of the find and list commands, which
+// selects discovered configurations by their attributes, such as:
+//
+//	type == "unit" && source =~ "vpc" && has_dependency("../network")
+//
+// Expressions support string, number, bool and null literals, the `==`, `!=`, `=~` (regular expression match), `!~`,
+// `&&`, `||` and `!` operators, parentheses, and the following attributes and functions of a configuration:
+//
+//   - `type`: the type of the configuration, `unit` or `stack`.
+//   - `path`: the path of the configuration, relative to the working directory.
+//   - `external`: whether the configuration is an external dependency.
+//   - `source`: the source of the `terraform` block, or null if there is none.
+//   - `remote_state.backend`: the backend of the `remote_state` block, or null if there is none.
+//   - `remote_state.<name>`: the value of the given attribute of the backend config, such as `remote_state.bucket`.
+//   - `feature.<name>`: the default value of the given feature flag, or null if there is no such flag.
+//   - `has_dependency(path)`: whether the configuration depends on the configuration at the given path, relative to
+//     the configuration.
+package filter
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/internal/discovery"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// Expression is a parsed filter expression.
+type Expression struct {
+	root                 node
+	src                  string
+	requiresParse        bool
+	requiresDependencies bool
+}
+
+// Parse parses the given filter expression.
+func Parse(src string) (*Expression, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	expr := &Expression{src: src}
+	p := &parser{expr: expr, tokens: tokens}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, unexpectedToken(tok, "end of expression")
+	}
+
+	expr.root = root
+
+	return expr, nil
+}
+
+// String returns the source of the expression.
+func (expr *Expression) String() string {
+	return expr.src
+}
+
+// RequiresParse returns true if the expression references attributes of the parsed configuration, so the discovered
+// configurations have to be parsed before they are matched.
+func (expr *Expression) RequiresParse() bool {
+	return expr.requiresParse
+}
+
+// RequiresDependencies returns true if the expression references dependencies, so the dependencies of the
+// discovered configurations have to be discovered before they are matched.
+func (expr *Expression) RequiresDependencies() bool {
+	return expr.requiresDependencies
+}
+
+// Match returns true if the given configuration matches the expression. Paths are relative to the given working
+// directory.
+func (expr *Expression) Match(workingDir string, cfg *discovery.DiscoveredConfig) (bool, error) {
+	env := &env{workingDir: workingDir, cfg: cfg}
+
+	value, err := expr.root.eval(env)
+	if err != nil {
+		return false, errors.Errorf("evaluating filter %q for %s: %w", expr.src, cfg.Path, err)
+	}
+
+	if value.IsNull() {
+		return false, nil
+	}
+
+	if value.Type() != cty.Bool {
+		return false, errors.Errorf("filter %q must evaluate to a bool, got %s", expr.src, value.Type().FriendlyName())
+	}
+
+	return value.True(), nil
+}
+
+// Filter returns the configurations matching the expression.
+func (expr *Expression) Filter(workingDir string, cfgs discovery.DiscoveredConfigs) (discovery.DiscoveredConfigs, error) {
+	filtered := make(discovery.DiscoveredConfigs, 0, len(cfgs))
+
+	for _, cfg := range cfgs {
+		ok, err := expr.Match(workingDir, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			filtered = append(filtered, cfg)
+		}
+	}
+
+	return filtered, nil
+}
+
+// env is the environment an expression is evaluated in.
+type env struct {
+	cfg        *discovery.DiscoveredConfig
+	workingDir string
+}
+
+// attribute is an attribute of a configuration that can be referenced in an expression.
+type attribute struct {
+	value func(env *env, key string) cty.Value
+	// keyed is true for attributes that must be followed by a key, such as `feature.<name>`.
+	keyed bool
+	// requiresParse is true for attributes of the parsed configuration.
+	requiresParse bool
+}
+
+var attributes = map[string]attribute{
+	"type": {
+		value: func(env *env, _ string) cty.Value {
+			return cty.StringVal(string(env.cfg.Type))
+		},
+	},
+	"path": {
+		value: func(env *env, _ string) cty.Value {
+			path := env.cfg.Path
+			if rel, err := filepath.Rel(env.workingDir, path); err == nil {
+				path = rel
+			}
+
+			return cty.StringVal(filepath.ToSlash(path))
+		},
+	},
+	"external": {
+		value: func(env *env, _ string) cty.Value {
+			return cty.BoolVal(env.cfg.External)
+		},
+	},
+	"source": {
+		requiresParse: true,
+		value: func(env *env, _ string) cty.Value {
+			parsed := env.cfg.Parsed
+			if parsed == nil || parsed.Terraform == nil || parsed.Terraform.Source == nil {
+				return cty.NullVal(cty.String)
+			}
+
+			return cty.StringVal(*parsed.Terraform.Source)
+		},
+	},
+	"remote_state": {
+		keyed:         true,
+		requiresParse: true,
+		value: func(env *env, key string) cty.Value {
+			parsed := env.cfg.Parsed
+			if parsed == nil || parsed.RemoteState == nil || parsed.RemoteState.Config == nil {
+				return cty.NullVal(cty.DynamicPseudoType)
+			}
+
+			if key == "backend" {
+				return cty.StringVal(parsed.RemoteState.BackendName)
+			}
+
+			value, ok := parsed.RemoteState.BackendConfig[key]
+			if !ok {
+				return cty.NullVal(cty.DynamicPseudoType)
+			}
+
+			return toCtyValue(value)
+		},
+	},
+	"feature": {
+		keyed:         true,
+		requiresParse: true,
+		value: func(env *env, key string) cty.Value {
+			if env.cfg.Parsed == nil {
+				return cty.NullVal(cty.DynamicPseudoType)
+			}
+
+			for _, flag := range env.cfg.Parsed.FeatureFlags {
+				if flag.Name == key && flag.Default != nil {
+					return *flag.Default
+				}
+			}
+
+			return cty.NullVal(cty.DynamicPseudoType)
+		},
+	},
+}
+
+// attributeNames returns the sorted names of the attributes, for error messages.
+func attributeNames() []string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// function is a function that can be called in an expression.
+type function struct {
+	call   func(env *env, args []cty.Value) (cty.Value, error)
+	params int
+	// requiresDependencies is true for functions of the dependencies of the configuration.
+	requiresDependencies bool
+}
+
+var functions = map[string]function{
+	"has_dependency": {
+		params:               1,
+		requiresDependencies: true,
+		call: func(env *env, args []cty.Value) (cty.Value, error) {
+			if args[0].Type() != cty.String || args[0].IsNull() {
+				return cty.NilVal, errors.Errorf("argument of has_dependency must be a string, got %s", args[0].Type().FriendlyName())
+			}
+
+			path := args[0].AsString()
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(env.cfg.Path, path)
+			}
+
+			path = filepath.Clean(path)
+
+			return cty.BoolVal(slices.ContainsFunc(env.cfg.Dependencies, func(dep *discovery.DiscoveredConfig) bool {
+				return filepath.Clean(dep.Path) == path
+			})), nil
+		},
+	},
+}
+
+// toCtyValue converts a value of a backend config to a cty value. Values which aren't strings, numbers or bools are
+// converted to their string representation.
+func toCtyValue(value any) cty.Value {
+	switch value := value.(type) {
+	case nil:
+		return cty.NullVal(cty.DynamicPseudoType)
+	case string:
+		return cty.StringVal(value)
+	case bool:
+		return cty.BoolVal(value)
+	case int:
+		return cty.NumberIntVal(int64(value))
+	case int64:
+		return cty.NumberIntVal(value)
+	case float64:
+		return cty.NumberFloatVal(value)
+	default:
+		return cty.StringVal(fmt.Sprint(value))
+	}
+}
+
+// ParseError is an error in the syntax of a filter expression.
+type ParseError struct {
+	Msg string
+	Pos int
+}
+
+func newParseError(pos int, msg string) error {
+	return errors.New(ParseError{Pos: pos, Msg: msg})
+}
+
+func (err ParseError) Error() string {
+	return fmt.Sprintf("invalid filter expression at position %d: %s", err.Pos+1, err.Msg)
+}
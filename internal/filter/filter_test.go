@@ -0,0 +1,164 @@
+package filter_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/discovery"
+	"github.com/gruntwork-io/terragrunt/internal/filter"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expr                 string
+		expectedError        string
+		requiresParse        bool
+		requiresDependencies bool
+	}{
+		{expr: `type == "unit"`},
+		{expr: `!external && (path =~ "^prod/" || path =~ "^stage/")`},
+		{expr: `source =~ "vpc"`, requiresParse: true},
+		{expr: `remote_state.bucket == "state"`, requiresParse: true},
+		{expr: `feature.enable-logging == true`, requiresParse: true},
+		{expr: `has_dependency("../network")`, requiresParse: true, requiresDependencies: true},
+		{expr: `type ==`, expectedError: "position 8: unexpected end of expression, expected a value"},
+		{expr: `type = "unit"`, expectedError: `position 6: unexpected character '='`},
+		{expr: `name == "vpc"`, expectedError: "position 1: unknown attribute name, expected one of external, feature, path, remote_state, source, type"},
+		{expr: `feature == true`, expectedError: `position 9: unexpected ==, expected "." after feature`},
+		{expr: `path =~ "("`, expectedError: "position 9: invalid regular expression"},
+		{expr: `path =~ type`, expectedError: "position 9: unexpected type, expected a regular expression string"},
+		{expr: `has_dependencies("../vpc")`, expectedError: "position 1: unknown function has_dependencies"},
+		{expr: `has_dependency()`, expectedError: "position 1: function has_dependency expects 1 argument(s), got 0"},
+		{expr: `type == "unit" type`, expectedError: "position 16: unexpected type, expected end of expression"},
+		{expr: `source == "vpc`, expectedError: "position 11: unterminated string"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			t.Parallel()
+
+			expr, err := filter.Parse(tc.expr)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expr, expr.String())
+			assert.Equal(t, tc.requiresParse, expr.RequiresParse())
+			assert.Equal(t, tc.requiresDependencies, expr.RequiresDependencies())
+		})
+	}
+}
+
+func TestExpression_Match(t *testing.T) {
+	t.Parallel()
+
+	workingDir := filepath.FromSlash("/live")
+	source := "git::https://github.com/acme/modules.git//vpc?ref=v1.0.0"
+	enabled := cty.True
+
+	network := &discovery.DiscoveredConfig{
+		Type: discovery.ConfigTypeUnit,
+		Path: filepath.Join(workingDir, "prod", "network"),
+	}
+
+	vpc := &discovery.DiscoveredConfig{
+		Type: discovery.ConfigTypeUnit,
+		Path: filepath.Join(workingDir, "prod", "vpc"),
+		Parsed: &config.TerragruntConfig{
+			Terraform: &config.TerraformConfig{Source: &source},
+			RemoteState: remotestate.New(&remotestate.Config{
+				BackendName:   "s3",
+				BackendConfig: map[string]any{"bucket": "prod-state", "encrypt": true},
+			}),
+			FeatureFlags: config.FeatureFlags{{Name: "enable-logging", Default: &enabled}},
+		},
+		Dependencies: discovery.DiscoveredConfigs{network},
+	}
+
+	stack := &discovery.DiscoveredConfig{
+		Type: discovery.ConfigTypeStack,
+		Path: filepath.Join(workingDir, "stage"),
+	}
+
+	testCases := []struct {
+		cfg           *discovery.DiscoveredConfig
+		expr          string
+		expectedError string
+		expected      bool
+	}{
+		{cfg: vpc, expr: `type == "unit"`, expected: true},
+		{cfg: stack, expr: `type == "unit"`, expected: false},
+		{cfg: stack, expr: `type != "unit"`, expected: true},
+		{cfg: vpc, expr: `path == "prod/vpc"`, expected: true},
+		{cfg: vpc, expr: `path =~ "^stage/"`, expected: false},
+		{cfg: vpc, expr: `path !~ "^stage/"`, expected: true},
+		{cfg: vpc, expr: `type == "unit" && source =~ "vpc" && has_dependency("../network")`, expected: true},
+		{cfg: vpc, expr: `has_dependency("../vpc")`, expected: false},
+		{cfg: vpc, expr: `has_dependency("/live/prod/network")`, expected: true},
+		{cfg: network, expr: `source =~ "vpc"`, expected: false},
+		{cfg: network, expr: `source == null`, expected: true},
+		{cfg: vpc, expr: `source == null`, expected: false},
+		{cfg: vpc, expr: `remote_state.backend == "s3" && remote_state.bucket =~ "^prod-"`, expected: true},
+		{cfg: vpc, expr: `remote_state.encrypt`, expected: true},
+		{cfg: vpc, expr: `remote_state.encrypt == "true"`, expected: false},
+		{cfg: network, expr: `remote_state.backend == "s3"`, expected: false},
+		{cfg: vpc, expr: `feature.enable-logging`, expected: true},
+		{cfg: vpc, expr: `!feature.missing`, expected: true},
+		{cfg: vpc, expr: `external || type == "stack"`, expected: false},
+		{cfg: vpc, expr: `!(external || type == "stack")`, expected: true},
+		{cfg: vpc, expr: `source`, expectedError: "must evaluate to a bool, got string"},
+		{cfg: vpc, expr: `source && external`, expectedError: "operand of && must be a bool, got string"},
+		{cfg: vpc, expr: `has_dependency(true)`, expectedError: "argument of has_dependency must be a string, got bool"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.cfg.Path+" "+tc.expr, func(t *testing.T) {
+			t.Parallel()
+
+			expr, err := filter.Parse(tc.expr)
+			require.NoError(t, err)
+
+			match, err := expr.Match(workingDir, tc.cfg)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, match)
+		})
+	}
+}
+
+func TestExpression_Filter(t *testing.T) {
+	t.Parallel()
+
+	workingDir := filepath.FromSlash("/live")
+
+	cfgs := discovery.DiscoveredConfigs{
+		{Type: discovery.ConfigTypeUnit, Path: filepath.Join(workingDir, "unit1")},
+		{Type: discovery.ConfigTypeStack, Path: filepath.Join(workingDir, "stack1")},
+		{Type: discovery.ConfigTypeUnit, Path: filepath.Join(workingDir, "unit2")},
+	}
+
+	expr, err := filter.Parse(`type == "unit"`)
+	require.NoError(t, err)
+
+	filtered, err := expr.Filter(workingDir, cfgs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(workingDir, "unit1"), filepath.Join(workingDir, "unit2")}, filtered.Paths())
+}
@@ -0,0 +1,143 @@
+package filter
+
+import (
+	"strconv"
+	"strings"
+)
+
+// tokenKind is the kind of a token of a filter expression.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenDot
+	tokenComma
+	tokenLParen
+	tokenRParen
+	tokenNot
+	tokenAnd
+	tokenOr
+	tokenEqual
+	tokenNotEqual
+	tokenMatch
+	tokenNotMatch
+)
+
+// operators are the operator tokens, longest first so that `!=` isn't lexed as `!` and `=`.
+var operators = []struct {
+	text string
+	kind tokenKind
+}{
+	{"&&", tokenAnd},
+	{"||", tokenOr},
+	{"==", tokenEqual},
+	{"!=", tokenNotEqual},
+	{"=~", tokenMatch},
+	{"!~", tokenNotMatch},
+	{"!", tokenNot},
+	{".", tokenDot},
+	{",", tokenComma},
+	{"(", tokenLParen},
+	{")", tokenRParen},
+}
+
+// token is a token of a filter expression.
+type token struct {
+	text string
+	kind tokenKind
+	pos  int
+}
+
+// lex splits the given filter expression into tokens, terminated by a `tokenEOF` token.
+func lex(src string) ([]token, error) {
+	var tokens []token
+
+	for pos := 0; pos < len(src); {
+		char := src[pos]
+
+		switch {
+		case char == ' ' || char == '\t' || char == '\n' || char == '\r':
+			pos++
+		case char == '"':
+			end, err := stringEnd(src, pos)
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := strconv.Unquote(src[pos:end])
+			if err != nil {
+				return nil, newParseError(pos, "invalid string "+src[pos:end])
+			}
+
+			tokens = append(tokens, token{kind: tokenString, text: value, pos: pos})
+			pos = end
+		case isDigit(char) || (char == '-' && pos+1 < len(src) && isDigit(src[pos+1])):
+			end := pos + 1
+			for end < len(src) && (isDigit(src[end]) || src[end] == '.') {
+				end++
+			}
+
+			tokens = append(tokens, token{kind: tokenNumber, text: src[pos:end], pos: pos})
+			pos = end
+		case isIdentStart(char):
+			end := pos + 1
+			for end < len(src) && isIdentChar(src[end]) {
+				end++
+			}
+
+			tokens = append(tokens, token{kind: tokenIdent, text: src[pos:end], pos: pos})
+			pos = end
+		default:
+			kind, text, ok := lexOperator(src[pos:])
+			if !ok {
+				return nil, newParseError(pos, "unexpected character "+strconv.QuoteRune(rune(char)))
+			}
+
+			tokens = append(tokens, token{kind: kind, text: text, pos: pos})
+			pos += len(text)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(src)}), nil
+}
+
+// stringEnd returns the position after the closing quote of the string starting at the given position.
+func stringEnd(src string, start int) (int, error) {
+	for pos := start + 1; pos < len(src); pos++ {
+		switch src[pos] {
+		case '\\':
+			pos++
+		case '"':
+			return pos + 1, nil
+		}
+	}
+
+	return 0, newParseError(start, "unterminated string")
+}
+
+func lexOperator(src string) (tokenKind, string, bool) {
+	for _, op := range operators {
+		if strings.HasPrefix(src, op.text) {
+			return op.kind, op.text, true
+		}
+	}
+
+	return tokenEOF, "", false
+}
+
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
+
+func isIdentStart(char byte) bool {
+	return char == '_' || (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
+
+// isIdentChar returns true if the given character can be part of an identifier. As in HCL, identifiers can contain
+// dashes, so that feature flags such as `feature.enable-logging` can be referenced.
+func isIdentChar(char byte) bool {
+	return isIdentStart(char) || isDigit(char) || char == '-'
+}
@@ -0,0 +1,420 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// node is a node of the syntax tree of a filter expression.
+type node interface {
+	eval(env *env) (cty.Value, error)
+}
+
+// parser is a recursive descent parser of filter expressions, with the following grammar, from lowest to highest
+// precedence:
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | comparison
+//	comparison = primary [ ( "==" | "!=" | "=~" | "!~" ) primary ]
+//	primary    = string | number | "true" | "false" | "null" | call | attribute | "(" or ")"
+//	call       = ident "(" [ or { "," or } ] ")"
+//	attribute  = ident { "." ident }
+type parser struct {
+	expr   *Expression
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, unexpectedToken(tok, what)
+	}
+
+	return tok, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOr {
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenAnd {
+		p.next()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.peek().kind == tokenNot {
+		p.next()
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notNode{operand: operand}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	switch op := p.peek(); op.kind {
+	case tokenEqual, tokenNotEqual:
+		p.next()
+
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &equalNode{left: left, right: right, negate: op.kind == tokenNotEqual}, nil
+	case tokenMatch, tokenNotMatch:
+		p.next()
+
+		pattern, err := p.expect(tokenString, "a regular expression string")
+		if err != nil {
+			return nil, err
+		}
+
+		re, err := regexp.Compile(pattern.text)
+		if err != nil {
+			return nil, newParseError(pattern.pos, "invalid regular expression: "+err.Error())
+		}
+
+		return &matchNode{left: left, re: re, negate: op.kind == tokenNotMatch}, nil
+	}
+
+	return left, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenString:
+		return &literalNode{value: cty.StringVal(tok.text)}, nil
+	case tokenNumber:
+		value, err := cty.ParseNumberVal(tok.text)
+		if err != nil {
+			return nil, newParseError(tok.pos, "invalid number "+tok.text)
+		}
+
+		return &literalNode{value: value}, nil
+	case tokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := p.expect(tokenRParen, `")"`); err != nil {
+			return nil, err
+		}
+
+		return inner, nil
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return &literalNode{value: cty.True}, nil
+		case "false":
+			return &literalNode{value: cty.False}, nil
+		case "null":
+			return &literalNode{value: cty.NullVal(cty.DynamicPseudoType)}, nil
+		}
+
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(tok)
+		}
+
+		return p.parseAttribute(tok)
+	}
+
+	return nil, unexpectedToken(tok, "a value")
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	fn, ok := functions[name.text]
+	if !ok {
+		return nil, newParseError(name.pos, "unknown function "+name.text)
+	}
+
+	p.next()
+
+	var args []node
+
+	for p.peek().kind != tokenRParen {
+		if len(args) > 0 {
+			if _, err := p.expect(tokenComma, `"," or ")"`); err != nil {
+				return nil, err
+			}
+		}
+
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+	}
+
+	p.next()
+
+	if len(args) != fn.params {
+		return nil, newParseError(name.pos, fmt.Sprintf("function %s expects %d argument(s), got %d", name.text, fn.params, len(args)))
+	}
+
+	if fn.requiresDependencies {
+		p.expr.requiresDependencies = true
+	}
+
+	p.expr.requiresParse = true
+
+	return &callNode{name: name.text, fn: fn, args: args}, nil
+}
+
+func (p *parser) parseAttribute(root token) (node, error) {
+	attr, ok := attributes[root.text]
+	if !ok {
+		return nil, newParseError(root.pos, "unknown attribute "+root.text+", expected one of "+strings.Join(attributeNames(), ", "))
+	}
+
+	var key string
+
+	if attr.keyed {
+		if _, err := p.expect(tokenDot, `"." after `+root.text); err != nil {
+			return nil, err
+		}
+
+		name, err := p.expect(tokenIdent, "an attribute name after "+root.text+".")
+		if err != nil {
+			return nil, err
+		}
+
+		key = name.text
+	}
+
+	if attr.requiresParse {
+		p.expr.requiresParse = true
+	}
+
+	return &attributeNode{attr: attr, key: key}, nil
+}
+
+// literalNode is a string, number, bool or null literal.
+type literalNode struct {
+	value cty.Value
+}
+
+func (n *literalNode) eval(*env) (cty.Value, error) {
+	return n.value, nil
+}
+
+// attributeNode is a reference to an attribute of the config, such as `type` or `remote_state.bucket`.
+type attributeNode struct {
+	attr attribute
+	key  string
+}
+
+func (n *attributeNode) eval(env *env) (cty.Value, error) {
+	return n.attr.value(env, n.key), nil
+}
+
+// callNode is a call of a function, such as `has_dependency("../vpc")`.
+type callNode struct {
+	fn   function
+	name string
+	args []node
+}
+
+func (n *callNode) eval(env *env) (cty.Value, error) {
+	args := make([]cty.Value, 0, len(n.args))
+
+	for _, arg := range n.args {
+		value, err := arg.eval(env)
+		if err != nil {
+			return cty.NilVal, err
+		}
+
+		args = append(args, value)
+	}
+
+	return n.fn.call(env, args)
+}
+
+// notNode negates a bool.
+type notNode struct {
+	operand node
+}
+
+func (n *notNode) eval(env *env) (cty.Value, error) {
+	value, err := evalBool(env, n.operand, "!")
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	return cty.BoolVal(!value), nil
+}
+
+// andNode is the conjunction of two bools. The right operand is only evaluated if the left one is true.
+type andNode struct {
+	left, right node
+}
+
+func (n *andNode) eval(env *env) (cty.Value, error) {
+	left, err := evalBool(env, n.left, "&&")
+	if err != nil || !left {
+		return cty.False, err
+	}
+
+	right, err := evalBool(env, n.right, "&&")
+
+	return cty.BoolVal(right), err
+}
+
+// orNode is the disjunction of two bools. The right operand is only evaluated if the left one is false.
+type orNode struct {
+	left, right node
+}
+
+func (n *orNode) eval(env *env) (cty.Value, error) {
+	left, err := evalBool(env, n.left, "||")
+	if err != nil || left {
+		return cty.BoolVal(left), err
+	}
+
+	right, err := evalBool(env, n.right, "||")
+
+	return cty.BoolVal(right), err
+}
+
+// equalNode compares two values. Values of different types are never equal, and null is only equal to null.
+type equalNode struct {
+	left, right node
+	negate      bool
+}
+
+func (n *equalNode) eval(env *env) (cty.Value, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	right, err := n.right.eval(env)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	equal := left.Equals(right).True()
+
+	return cty.BoolVal(equal != n.negate), nil
+}
+
+// matchNode matches a value against a regular expression. Numbers and bools are matched against their string
+// representation, and null never matches.
+type matchNode struct {
+	left   node
+	re     *regexp.Regexp
+	negate bool
+}
+
+func (n *matchNode) eval(env *env) (cty.Value, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if left.IsNull() {
+		return cty.BoolVal(n.negate), nil
+	}
+
+	str, err := convert.Convert(left, cty.String)
+	if err != nil {
+		return cty.NilVal, errors.Errorf("cannot match %s against a regular expression", left.Type().FriendlyName())
+	}
+
+	return cty.BoolVal(n.re.MatchString(str.AsString()) != n.negate), nil
+}
+
+// evalBool evaluates the given operand of the given operator, which must be a bool. Null is treated as false, so that
+// unset attributes can be used as conditions.
+func evalBool(env *env, operand node, operator string) (bool, error) {
+	value, err := operand.eval(env)
+	if err != nil {
+		return false, err
+	}
+
+	if value.IsNull() {
+		return false, nil
+	}
+
+	if value.Type() != cty.Bool {
+		return false, errors.Errorf("operand of %s must be a bool, got %s", operator, value.Type().FriendlyName())
+	}
+
+	return value.True(), nil
+}
+
+func unexpectedToken(tok token, expected string) error {
+	if tok.kind == tokenEOF {
+		return newParseError(tok.pos, "unexpected end of expression, expected "+expected)
+	}
+
+	return newParseError(tok.pos, "unexpected "+tok.text+", expected "+expected)
+}
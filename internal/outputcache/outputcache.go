@@ -0,0 +1,146 @@
+// Package outputcache provides an on-disk cache of the outputs of dependencies, so that repeated runs don't have to
+// read the outputs of dependencies whose state hasn't changed again.
+//
+// Outputs are cached by the dependency and the location of the state they are read from, and are valid as long as the
+// entity tag of the state is unchanged, or for a fixed time to live for backends which cannot tell whether the state
+// changed.
+package outputcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	// dirName is the name of the default cache directory in the user cache directory.
+	dirName = "dependency-outputs"
+
+	// Outputs can contain sensitive values, so they are only readable by the owner.
+	dirPerm  = 0700
+	filePerm = 0600
+)
+
+// Entry is the cached outputs of a dependency.
+type Entry struct {
+	// CachedAt is the time the outputs were read from the state.
+	CachedAt time.Time `json:"cached_at"`
+	// ETag is the entity tag of the state the outputs were read from, if the backend supports it.
+	ETag string `json:"etag,omitempty"`
+	// Outputs are the outputs in the same JSON format as `tofu output -json`.
+	Outputs json.RawMessage `json:"outputs"`
+}
+
+// IsValid returns true if the entry is still valid, either because its entity tag matches the given one, or because
+// it was cached less than ttl ago.
+func (entry *Entry) IsValid(etag string, ttl time.Duration) bool {
+	if etag != "" && entry.ETag == etag {
+		return true
+	}
+
+	return ttl > 0 && time.Since(entry.CachedAt) < ttl
+}
+
+// Store is a cache of dependency outputs stored in a directory, one file per state location.
+type Store struct {
+	dir string
+}
+
+// New returns a store in the given directory, or in the user cache directory if dir is empty.
+func New(dir string) (*Store, error) {
+	if dir == "" {
+		cacheDir, err := util.GetCacheDir()
+		if err != nil {
+			return nil, err
+		}
+
+		dir = filepath.Join(cacheDir, dirName)
+	}
+
+	return &Store{dir: dir}, nil
+}
+
+// Key returns the cache key of the outputs of the dependency with the given config path, whose state is stored with
+// the given backend and backend config. The config path is part of the key, since the location of the state can be
+// relative to the dependency, as with the local backend.
+func Key(configPath, backendName string, backendConfig map[string]any) (string, error) {
+	// Map keys are sorted by `json.Marshal`, so the same config always has the same key
+	data, err := json.Marshal(map[string]any{
+		"path":    configPath,
+		"backend": backendName,
+		"config":  backendConfig,
+	})
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Get returns the entry with the given key, or nil if there is none. Unreadable entries are treated as missing, so
+// that they are overwritten by the next `Put`.
+func (store *Store) Get(key string) *Entry {
+	data, err := os.ReadFile(store.path(key))
+	if err != nil {
+		return nil
+	}
+
+	entry := new(Entry)
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil
+	}
+
+	return entry
+}
+
+// Put stores the given entry under the given key. The entry is written to a temporary file first, so that concurrent
+// runs never read partial entries.
+func (store *Store) Put(key string, entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.MkdirAll(store.dir, dirPerm); err != nil {
+		return errors.New(err)
+	}
+
+	f, err := os.CreateTemp(store.dir, key+"-*.tmp")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer os.Remove(f.Name()) //nolint:errcheck
+
+	if _, err := f.Write(data); err != nil {
+		f.Close() //nolint:errcheck
+
+		return errors.New(err)
+	}
+
+	if err := f.Close(); err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.Chmod(f.Name(), filePerm); err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.Rename(f.Name(), store.path(key)); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func (store *Store) path(key string) string {
+	return filepath.Join(store.dir, key+".json")
+}
@@ -0,0 +1,80 @@
+package outputcache_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/outputcache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := outputcache.Key("/live/vpc/terragrunt.hcl", "s3", map[string]any{"bucket": "state", "key": "vpc/terraform.tfstate"})
+	require.NoError(t, err)
+
+	sameKey, err := outputcache.Key("/live/vpc/terragrunt.hcl", "s3", map[string]any{"key": "vpc/terraform.tfstate", "bucket": "state"})
+	require.NoError(t, err)
+	assert.Equal(t, key, sameKey)
+
+	otherKey, err := outputcache.Key("/live/vpc/terragrunt.hcl", "s3", map[string]any{"bucket": "state", "key": "app/terraform.tfstate"})
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherKey)
+
+	otherKey, err = outputcache.Key("/live/vpc/terragrunt.hcl", "gcs", map[string]any{"bucket": "state", "key": "vpc/terraform.tfstate"})
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherKey)
+
+	// local state paths are relative to the dependency
+	otherKey, err = outputcache.Key("/live/app/terragrunt.hcl", "s3", map[string]any{"bucket": "state", "key": "vpc/terraform.tfstate"})
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherKey)
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	store, err := outputcache.New(dir)
+	require.NoError(t, err)
+
+	assert.Nil(t, store.Get("missing"))
+
+	entry := &outputcache.Entry{
+		CachedAt: time.Now(),
+		ETag:     `"abc"`,
+		Outputs:  json.RawMessage(`{"vpc_id":{"value":"vpc-123","type":"string"}}`),
+	}
+	require.NoError(t, store.Put("key", entry))
+
+	cached := store.Get("key")
+	require.NotNil(t, cached)
+	assert.Equal(t, entry.ETag, cached.ETag)
+	assert.JSONEq(t, string(entry.Outputs), string(cached.Outputs))
+
+	info, err := os.Stat(filepath.Join(dir, "key.json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// corrupted entries are treated as missing
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "key.json"), []byte("corrupted"), 0600))
+	assert.Nil(t, store.Get("key"))
+}
+
+func TestEntry_IsValid(t *testing.T) {
+	t.Parallel()
+
+	entry := &outputcache.Entry{CachedAt: time.Now().Add(-time.Hour), ETag: `"abc"`}
+
+	assert.True(t, entry.IsValid(`"abc"`, 0))
+	assert.False(t, entry.IsValid(`"def"`, 0))
+	assert.False(t, entry.IsValid("", 0))
+	assert.True(t, entry.IsValid("", 2*time.Hour))
+	assert.False(t, entry.IsValid(`"def"`, 30*time.Minute))
+}
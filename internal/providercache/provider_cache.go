@@ -62,21 +62,33 @@ type ProviderCache struct {
 	providerService *services.ProviderService
 }
 
-func InitServer(l log.Logger, opts *options.TerragruntOptions) (*ProviderCache, error) {
-	// ProviderCacheDir has the same file structure as terraform plugin_cache_dir.
-	// https://developer.hashicorp.com/terraform/cli/config/config-file#provider-plugin-cache
-	if opts.ProviderCacheDir == "" {
-		cacheDir, err := util.GetCacheDir()
+// CacheDir returns the absolute path of the provider cache directory, by default the `providers` directory of the
+// Terragrunt cache directory. It has the same file structure as the terraform plugin_cache_dir.
+// https://developer.hashicorp.com/terraform/cli/config/config-file#provider-plugin-cache
+func CacheDir(opts *options.TerragruntOptions) (string, error) {
+	cacheDir := opts.ProviderCacheDir
+
+	if cacheDir == "" {
+		userCacheDir, err := util.GetCacheDir()
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 
-		opts.ProviderCacheDir = filepath.Join(cacheDir, "providers")
+		cacheDir = filepath.Join(userCacheDir, "providers")
+	}
+
+	absCacheDir, err := filepath.Abs(cacheDir)
+	if err != nil {
+		return "", errors.New(err)
 	}
 
+	return absCacheDir, nil
+}
+
+func InitServer(l log.Logger, opts *options.TerragruntOptions) (*ProviderCache, error) {
 	var err error
-	if opts.ProviderCacheDir, err = filepath.Abs(opts.ProviderCacheDir); err != nil {
-		return nil, errors.New(err)
+	if opts.ProviderCacheDir, err = CacheDir(opts); err != nil {
+		return nil, err
 	}
 
 	if opts.ProviderCacheToken == "" {
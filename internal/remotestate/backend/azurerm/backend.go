@@ -0,0 +1,290 @@
+// Package azurerm represents Azure Blob Storage backend for interacting with remote state.
+package azurerm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/puzpuzpuz/xsync/v3"
+)
+
+const BackendName = "azurerm"
+
+var _ backend.Backend = new(Backend)
+
+type Backend struct {
+	*backend.CommonBackend
+
+	// leaseIDs are the IDs of the leases acquired on the state blobs by LockState, by blob URL, which the writes and
+	// deletes of the leased blobs must pass.
+	leaseIDs *xsync.MapOf[string, string]
+}
+
+func NewBackend() *Backend {
+	return &Backend{
+		CommonBackend: backend.NewCommonBackend(BackendName),
+		leaseIDs:      xsync.NewMapOf[string, string](),
+	}
+}
+
+// NeedsBootstrap returns true if the storage container specified in the given config does not exist.
+func (backend *Backend) NeedsBootstrap(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (bool, error) {
+	extAzurermCfg, err := Config(backendConfig).ExtendedAzurermConfig()
+	if err != nil {
+		return false, err
+	}
+
+	client, err := NewClient(extAzurermCfg)
+	if err != nil {
+		return false, err
+	}
+
+	if exists, err := client.DoesContainerExist(ctx, extAzurermCfg.RemoteStateConfigAzurerm.ContainerName); err != nil || !exists {
+		return true, err
+	}
+
+	return false, nil
+}
+
+// Bootstrap the remote state storage container specified in the given config. This function will validate the config
+// parameters, create the container if it doesn't already exist, and check that blob versioning is enabled.
+func (backend *Backend) Bootstrap(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) error {
+	extAzurermCfg, err := Config(backendConfig).ExtendedAzurermConfig()
+	if err != nil {
+		return err
+	}
+
+	var (
+		azurermCfg    = &extAzurermCfg.RemoteStateConfigAzurerm
+		containerName = azurermCfg.ContainerName
+	)
+
+	// ensure that only one goroutine can initialize container
+	mu := backend.GetBucketMutex(azurermCfg.CacheKey())
+	mu.Lock()
+	defer mu.Unlock()
+
+	if backend.IsConfigInited(azurermCfg) {
+		l.Debugf("%s container %s has already been confirmed to be initialized, skipping initialization checks", backend.Name(), containerName)
+
+		return nil
+	}
+
+	client, err := NewClient(extAzurermCfg)
+	if err != nil {
+		return err
+	}
+
+	if !extAzurermCfg.SkipContainerCreation {
+		if err := client.CreateContainerIfNecessary(ctx, l, containerName, opts); err != nil {
+			return err
+		}
+	}
+
+	if !extAzurermCfg.SkipBlobVersioning {
+		if _, err := client.CheckIfVersioningEnabled(ctx, l, containerName, azurermCfg.Key); err != nil && !isVersionControlUnknown(err) {
+			return err
+		}
+	}
+
+	backend.MarkConfigInited(azurermCfg)
+
+	return nil
+}
+
+// IsVersionControlEnabled returns true if blob versioning is enabled for the storage account. Returns
+// `backend.VersionControlUnknownError` if the state blob does not exist.
+func (backend *Backend) IsVersionControlEnabled(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (bool, error) {
+	extAzurermCfg, err := Config(backendConfig).ExtendedAzurermConfig()
+	if err != nil {
+		return false, err
+	}
+
+	client, err := NewClient(extAzurermCfg)
+	if err != nil {
+		return false, err
+	}
+
+	azurermCfg := extAzurermCfg.RemoteStateConfigAzurerm
+
+	return client.CheckIfVersioningEnabled(ctx, l, azurermCfg.ContainerName, azurermCfg.Key)
+}
+
+// Migrate moves the state blob located at src config to dst config. The storage accounts of both configs may differ.
+func (backend *Backend) Migrate(ctx context.Context, l log.Logger, srcBackendConfig, dstBackendConfig backend.Config, opts *options.TerragruntOptions) error {
+	srcExtAzurermCfg, err := Config(srcBackendConfig).ExtendedAzurermConfig()
+	if err != nil {
+		return err
+	}
+
+	dstExtAzurermCfg, err := Config(dstBackendConfig).ExtendedAzurermConfig()
+	if err != nil {
+		return err
+	}
+
+	var (
+		srcContainerName = srcExtAzurermCfg.RemoteStateConfigAzurerm.ContainerName
+		srcKey           = srcExtAzurermCfg.RemoteStateConfigAzurerm.Key
+
+		dstContainerName = dstExtAzurermCfg.RemoteStateConfigAzurerm.ContainerName
+		dstKey           = dstExtAzurermCfg.RemoteStateConfigAzurerm.Key
+	)
+
+	srcClient, err := NewClient(srcExtAzurermCfg)
+	if err != nil {
+		return err
+	}
+
+	dstClient, err := NewClient(dstExtAzurermCfg)
+	if err != nil {
+		return err
+	}
+
+	return srcClient.MoveBlobIfNecessary(ctx, l, srcContainerName, srcKey, dstClient, dstContainerName, dstKey)
+}
+
+// Delete deletes the remote state specified in the given config.
+func (backend *Backend) Delete(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) error {
+	extAzurermCfg, err := Config(backendConfig).ExtendedAzurermConfig()
+	if err != nil {
+		return err
+	}
+
+	var (
+		containerName = extAzurermCfg.RemoteStateConfigAzurerm.ContainerName
+		key           = extAzurermCfg.RemoteStateConfigAzurerm.Key
+	)
+
+	client, err := NewClient(extAzurermCfg)
+	if err != nil {
+		return err
+	}
+
+	prompt := fmt.Sprintf("Azure storage container %s blob %s will be deleted. Do you want to continue?", containerName, key)
+	if yes, err := shell.PromptUserForYesNo(ctx, l, prompt, opts); err != nil {
+		return err
+	} else if yes {
+		return client.DeleteBlobIfNecessary(ctx, l, containerName, key, backend.leaseID(client, containerName, key))
+	}
+
+	return nil
+}
+
+// DeleteBucket deletes the entire storage container specified in the given config.
+func (backend *Backend) DeleteBucket(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) error {
+	extAzurermCfg, err := Config(backendConfig).ExtendedAzurermConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(extAzurermCfg)
+	if err != nil {
+		return err
+	}
+
+	var containerName = extAzurermCfg.RemoteStateConfigAzurerm.ContainerName
+
+	prompt := fmt.Sprintf("Azure storage container %s will be completely deleted. Do you want to continue?", containerName)
+	if yes, err := shell.PromptUserForYesNo(ctx, l, prompt, opts); err != nil {
+		return err
+	} else if yes {
+		return client.DeleteContainerIfNecessary(ctx, l, containerName)
+	}
+
+	return nil
+}
+
+// ReadState returns the content of the state blob specified in the given config, or nil if the blob does not exist.
+func (backend *Backend) ReadState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) ([]byte, error) {
+	extAzurermCfg, err := Config(backendConfig).ExtendedAzurermConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(extAzurermCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		containerName = extAzurermCfg.RemoteStateConfigAzurerm.ContainerName
+		key           = extAzurermCfg.RemoteStateConfigAzurerm.Key
+	)
+
+	if exists, err := client.DoesBlobExistWithLogging(ctx, l, containerName, key); err != nil || !exists {
+		return nil, err
+	}
+
+	return client.GetBlob(ctx, containerName, key)
+}
+
+// WriteState uploads the given state to the blob specified in the given config.
+func (backend *Backend) WriteState(ctx context.Context, l log.Logger, backendConfig backend.Config, state []byte, opts *options.TerragruntOptions) error {
+	extAzurermCfg, err := Config(backendConfig).ExtendedAzurermConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(extAzurermCfg)
+	if err != nil {
+		return err
+	}
+
+	var (
+		containerName = extAzurermCfg.RemoteStateConfigAzurerm.ContainerName
+		key           = extAzurermCfg.RemoteStateConfigAzurerm.Key
+	)
+
+	l.Debugf("Uploading Azure storage container %s blob %s", containerName, key)
+
+	return client.PutBlob(ctx, containerName, key, state, backend.leaseID(client, containerName, key))
+}
+
+// LockState locks the state specified in the given config by acquiring a lease on the state blob.
+func (backend *Backend) LockState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (backend.UnlockFunc, error) {
+	extAzurermCfg, err := Config(backendConfig).ExtendedAzurermConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(extAzurermCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		containerName = extAzurermCfg.RemoteStateConfigAzurerm.ContainerName
+		key           = extAzurermCfg.RemoteStateConfigAzurerm.Key
+	)
+
+	leaseID, unlock, err := client.LockState(ctx, l, containerName, key)
+	if err != nil || leaseID == "" {
+		return unlock, err
+	}
+
+	blobURL := client.BlobURL(containerName, key)
+	backend.leaseIDs.Store(blobURL, leaseID)
+
+	return func(ctx context.Context) error {
+		backend.leaseIDs.Delete(blobURL)
+
+		return unlock(ctx)
+	}, nil
+}
+
+// leaseID returns the ID of the lease acquired on the specified blob by LockState, or an empty ID if it is not leased.
+func (backend *Backend) leaseID(client *Client, containerName, key string) string {
+	leaseID, _ := backend.leaseIDs.Load(client.BlobURL(containerName, key))
+
+	return leaseID
+}
+
+// GetTFInitArgs returns the subset of the given config that should be passed to terraform init
+// when initializing the remote state.
+func (backend *Backend) GetTFInitArgs(config backend.Config) map[string]any {
+	return Config(config).FilterOutTerragruntKeys()
+}
@@ -0,0 +1,546 @@
+package azurerm_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/azurerm"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testStorageAccount = "devstoreaccount1"
+	testAccessKey      = "dGVycmFncnVudC10ZXN0LWtleQ=="
+)
+
+func TestBackend_GetTFInitArgs(t *testing.T) {
+	t.Parallel()
+
+	remoteBackend := azurerm.NewBackend()
+
+	actual := remoteBackend.GetTFInitArgs(backend.Config{
+		"storage_account_name":    "tfstate",
+		"container_name":          "state",
+		"key":                     "prod.tfstate",
+		"use_azuread_auth":        true,
+		"skip_container_creation": true,
+		"skip_blob_versioning":    true,
+		"container_metadata":      map[string]string{"team": "infra"},
+		"blob_endpoint":           "http://127.0.0.1:10000/devstoreaccount1",
+	})
+
+	assert.Equal(t, map[string]any{
+		"storage_account_name": "tfstate",
+		"container_name":       "state",
+		"key":                  "prod.tfstate",
+		"use_azuread_auth":     true,
+	}, actual)
+}
+
+func TestBackend_Bootstrap(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeBlobService(true)
+	defer server.Close()
+
+	var (
+		l              = logger.CreateLogger()
+		remoteBackend  = azurerm.NewBackend()
+		opts, err      = options.NewTerragruntOptionsForTest("")
+		backendConfig  = server.backendConfig("tfstate", "prod/terraform.tfstate")
+		needsBootstrap bool
+	)
+
+	require.NoError(t, err)
+
+	needsBootstrap, err = remoteBackend.NeedsBootstrap(t.Context(), l, backendConfig, opts)
+	require.NoError(t, err)
+	assert.True(t, needsBootstrap)
+
+	require.NoError(t, remoteBackend.Bootstrap(t.Context(), l, backendConfig, opts))
+	assert.True(t, server.hasContainer("tfstate"))
+
+	needsBootstrap, err = remoteBackend.NeedsBootstrap(t.Context(), l, backendConfig, opts)
+	require.NoError(t, err)
+	assert.False(t, needsBootstrap)
+}
+
+func TestBackend_BootstrapFailIfContainerCreationRequired(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeBlobService(true)
+	defer server.Close()
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.FailIfBucketCreationRequired = true
+
+	err = azurerm.NewBackend().Bootstrap(t.Context(), logger.CreateLogger(), server.backendConfig("tfstate", "terraform.tfstate"), opts)
+	require.ErrorIs(t, err, backend.BucketCreationNotAllowed("tfstate"))
+	assert.False(t, server.hasContainer("tfstate"))
+}
+
+func TestBackend_IsVersionControlEnabled(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		versioning      bool
+		blobs           []string
+		unversioned     []string
+		expected        bool
+		expectedUnknown bool
+	}{
+		{
+			name:       "versioning-enabled",
+			versioning: true,
+			blobs:      []string{"terraform.tfstate"},
+			expected:   true,
+		},
+		{
+			name:  "versioning-disabled",
+			blobs: []string{"terraform.tfstate"},
+		},
+		{
+			name:        "state-written-before-versioning",
+			versioning:  true,
+			blobs:       []string{"terraform.tfstate", "other/terraform.tfstate"},
+			unversioned: []string{"terraform.tfstate"},
+			expected:    true,
+		},
+		{
+			name:            "missing-state-with-sibling",
+			versioning:      true,
+			blobs:           []string{"terraform.tfstate.backup"},
+			expectedUnknown: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newFakeBlobService(tc.versioning)
+			defer server.Close()
+
+			for _, key := range tc.blobs {
+				server.putBlob("tfstate", key, []byte("{}"))
+			}
+
+			for _, key := range tc.unversioned {
+				server.markUnversioned("tfstate", key)
+			}
+
+			opts, err := options.NewTerragruntOptionsForTest("")
+			require.NoError(t, err)
+
+			enabled, err := azurerm.NewBackend().IsVersionControlEnabled(t.Context(), logger.CreateLogger(), server.backendConfig("tfstate", "terraform.tfstate"), opts)
+			if tc.expectedUnknown {
+				require.ErrorAs(t, err, new(backend.VersionControlUnknownError))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, enabled)
+		})
+	}
+}
+
+func TestBackend_IsVersionControlEnabledMissingContainer(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeBlobService(true)
+	defer server.Close()
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	_, err = azurerm.NewBackend().IsVersionControlEnabled(t.Context(), logger.CreateLogger(), server.backendConfig("tfstate", "terraform.tfstate"), opts)
+	require.ErrorAs(t, err, new(*backend.BucketDoesNotExistError))
+}
+
+func TestBackend_Migrate(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeBlobService(true)
+	defer server.Close()
+
+	server.putBlob("src", "app/terraform.tfstate", []byte(`{"serial": 1}`))
+	server.putBlob("dst", "other.tfstate", nil)
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	var (
+		l         = logger.CreateLogger()
+		srcConfig = server.backendConfig("src", "app/terraform.tfstate")
+		dstConfig = server.backendConfig("dst", "app/terraform.tfstate")
+	)
+
+	require.NoError(t, azurerm.NewBackend().Migrate(t.Context(), l, srcConfig, dstConfig, opts))
+
+	_, srcExists := server.getBlob("src", "app/terraform.tfstate")
+	assert.False(t, srcExists)
+
+	data, dstExists := server.getBlob("dst", "app/terraform.tfstate")
+	assert.True(t, dstExists)
+	assert.JSONEq(t, `{"serial": 1}`, string(data))
+
+	// Migrating again is a no-op since the source state no longer exists.
+	require.NoError(t, azurerm.NewBackend().Migrate(t.Context(), l, srcConfig, dstConfig, opts))
+}
+
+func TestBackend_MigrateDestinationExists(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeBlobService(true)
+	defer server.Close()
+
+	server.putBlob("src", "terraform.tfstate", []byte("{}"))
+	server.putBlob("dst", "terraform.tfstate", []byte("{}"))
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	err = azurerm.NewBackend().Migrate(t.Context(), logger.CreateLogger(), server.backendConfig("src", "terraform.tfstate"), server.backendConfig("dst", "terraform.tfstate"), opts)
+	require.ErrorContains(t, err, "destination Azure storage container dst blob terraform.tfstate already exists")
+
+	_, srcExists := server.getBlob("src", "terraform.tfstate")
+	assert.True(t, srcExists)
+}
+
+func TestBackend_Delete(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeBlobService(true)
+	defer server.Close()
+
+	server.putBlob("tfstate", "app/terraform.tfstate", []byte("{}"))
+	server.putBlob("tfstate", "other/terraform.tfstate", []byte("{}"))
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	var (
+		l             = logger.CreateLogger()
+		remoteBackend = azurerm.NewBackend()
+	)
+
+	require.NoError(t, remoteBackend.Delete(t.Context(), l, server.backendConfig("tfstate", "app/terraform.tfstate"), opts))
+
+	_, exists := server.getBlob("tfstate", "app/terraform.tfstate")
+	assert.False(t, exists)
+
+	_, exists = server.getBlob("tfstate", "other/terraform.tfstate")
+	assert.True(t, exists)
+
+	require.NoError(t, remoteBackend.DeleteBucket(t.Context(), l, server.backendConfig("tfstate", "app/terraform.tfstate"), opts))
+	assert.False(t, server.hasContainer("tfstate"))
+}
+
+func TestBackend_LockState(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeBlobService(true)
+	defer server.Close()
+
+	server.putBlob("tfstate", "terraform.tfstate", []byte("{}"))
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	var (
+		l             = logger.CreateLogger()
+		backendConfig = server.backendConfig("tfstate", "terraform.tfstate")
+		remoteBackend = azurerm.NewBackend()
+		// otherBackend stands for another Terragrunt process
+		otherBackend = azurerm.NewBackend()
+	)
+
+	unlock, err := remoteBackend.LockState(t.Context(), l, backendConfig, opts)
+	require.NoError(t, err)
+
+	_, err = otherBackend.LockState(t.Context(), l, backendConfig, opts)
+	require.ErrorAs(t, err, new(backend.StateLockedError))
+	require.Error(t, otherBackend.WriteState(t.Context(), l, backendConfig, []byte(`{"serial": 2}`), opts))
+
+	require.NoError(t, remoteBackend.WriteState(t.Context(), l, backendConfig, []byte(`{"serial": 1}`), opts))
+
+	data, _ := server.getBlob("tfstate", "terraform.tfstate")
+	assert.JSONEq(t, `{"serial": 1}`, string(data))
+
+	require.NoError(t, unlock(t.Context()))
+	require.NoError(t, otherBackend.WriteState(t.Context(), l, backendConfig, []byte(`{"serial": 2}`), opts))
+}
+
+// fakeBlobService is a minimal in-memory stand-in for the Azure Blob Storage REST API, similar to Azurite,
+// which implements only the operations used by the azurerm backend.
+type fakeBlobService struct {
+	*httptest.Server
+	containers map[string]map[string][]byte
+	// unversioned holds the "<container>/<blob>" names of the blobs written before versioning was enabled.
+	unversioned map[string]bool
+	// leases holds the IDs of the leases held on the blobs, by "<container>/<blob>" name.
+	leases     map[string]string
+	mu         sync.Mutex
+	versioning bool
+}
+
+func newFakeBlobService(versioning bool) *fakeBlobService {
+	service := &fakeBlobService{
+		containers:  make(map[string]map[string][]byte),
+		unversioned: make(map[string]bool),
+		leases:      make(map[string]string),
+		versioning:  versioning,
+	}
+
+	service.Server = httptest.NewServer(http.HandlerFunc(service.handle))
+
+	return service
+}
+
+func (service *fakeBlobService) backendConfig(containerName, key string) backend.Config {
+	return backend.Config{
+		"storage_account_name": testStorageAccount,
+		"container_name":       containerName,
+		"key":                  key,
+		"access_key":           testAccessKey,
+		"blob_endpoint":        service.URL + "/" + testStorageAccount,
+	}
+}
+
+func (service *fakeBlobService) hasContainer(containerName string) bool {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	_, ok := service.containers[containerName]
+
+	return ok
+}
+
+func (service *fakeBlobService) putBlob(containerName, key string, data []byte) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	if _, ok := service.containers[containerName]; !ok {
+		service.containers[containerName] = make(map[string][]byte)
+	}
+
+	service.containers[containerName][key] = data
+}
+
+// markUnversioned marks the blob as written before versioning was enabled, so it has no version until it is written again.
+func (service *fakeBlobService) markUnversioned(containerName, key string) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	service.unversioned[containerName+"/"+key] = true
+}
+
+func (service *fakeBlobService) getBlob(containerName, key string) ([]byte, bool) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	data, ok := service.containers[containerName][key]
+
+	return data, ok
+}
+
+func (service *fakeBlobService) handle(w http.ResponseWriter, r *http.Request) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	// Path-style URLs as used by Azurite: /<account>/<container>[/<blob>]
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"+testStorageAccount+"/"), "/", 2)
+	containerName := parts[0]
+	blobs, containerExists := service.containers[containerName]
+
+	if r.URL.Query().Get("restype") == "container" {
+		service.handleContainer(w, r, containerName, blobs, containerExists)
+		return
+	}
+
+	if !containerExists {
+		writeError(w, r, http.StatusNotFound, "ContainerNotFound")
+		return
+	}
+
+	key := parts[1]
+	data, blobExists := blobs[key]
+
+	if r.URL.Query().Get("comp") == "lease" {
+		service.handleLease(w, r, containerName+"/"+key, blobExists)
+		return
+	}
+
+	// Leased blobs can only be written or deleted with the ID of their lease.
+	if leaseID, leased := service.leases[containerName+"/"+key]; leased && (r.Method == http.MethodPut || r.Method == http.MethodDelete) {
+		switch r.Header.Get("X-Ms-Lease-Id") {
+		case leaseID:
+		case "":
+			writeError(w, r, http.StatusPreconditionFailed, "LeaseIdMissing")
+			return
+		default:
+			writeError(w, r, http.StatusPreconditionFailed, "LeaseIdMismatchWithBlobOperation")
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		blobs[key] = body
+		delete(service.unversioned, containerName+"/"+key)
+
+		service.writeVersionHeader(w)
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet, http.MethodHead:
+		if !blobExists {
+			writeError(w, r, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+
+		service.writeVersionHeader(w)
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("X-Ms-Blob-Type", "BlockBlob")
+		w.WriteHeader(http.StatusOK)
+
+		if r.Method == http.MethodGet {
+			w.Write(data) //nolint:errcheck
+		}
+	case http.MethodDelete:
+		if !blobExists {
+			writeError(w, r, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+
+		delete(blobs, key)
+		delete(service.unversioned, containerName+"/"+key)
+		delete(service.leases, containerName+"/"+key)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (service *fakeBlobService) handleLease(w http.ResponseWriter, r *http.Request, name string, blobExists bool) {
+	if !blobExists {
+		writeError(w, r, http.StatusNotFound, "BlobNotFound")
+		return
+	}
+
+	leaseID, leased := service.leases[name]
+
+	switch r.Header.Get("X-Ms-Lease-Action") {
+	case "acquire":
+		if leased {
+			writeError(w, r, http.StatusConflict, "LeaseAlreadyPresent")
+			return
+		}
+
+		service.leases[name] = r.Header.Get("X-Ms-Proposed-Lease-Id")
+
+		w.Header().Set("X-Ms-Lease-Id", service.leases[name])
+		w.WriteHeader(http.StatusCreated)
+	case "release":
+		if !leased || r.Header.Get("X-Ms-Lease-Id") != leaseID {
+			writeError(w, r, http.StatusConflict, "LeaseIdMismatchWithLeaseOperation")
+			return
+		}
+
+		delete(service.leases, name)
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (service *fakeBlobService) handleContainer(w http.ResponseWriter, r *http.Request, containerName string, blobs map[string][]byte, exists bool) {
+	switch {
+	case r.Method == http.MethodPut:
+		if exists {
+			writeError(w, r, http.StatusConflict, "ContainerAlreadyExists")
+			return
+		}
+
+		service.containers[containerName] = make(map[string][]byte)
+		w.WriteHeader(http.StatusCreated)
+	case !exists:
+		writeError(w, r, http.StatusNotFound, "ContainerNotFound")
+	case r.Method == http.MethodDelete:
+		delete(service.containers, containerName)
+		w.WriteHeader(http.StatusAccepted)
+	case r.URL.Query().Get("comp") == "list":
+		service.listBlobs(w, r, containerName, blobs)
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (service *fakeBlobService) listBlobs(w http.ResponseWriter, r *http.Request, containerName string, blobs map[string][]byte) {
+	type blobItem struct {
+		Name             string `xml:"Name"`
+		VersionID        string `xml:"VersionId,omitempty"`
+		IsCurrentVersion bool   `xml:"IsCurrentVersion,omitempty"`
+	}
+
+	type enumerationResults struct {
+		XMLName       xml.Name   `xml:"EnumerationResults"`
+		ContainerName string     `xml:"ContainerName,attr"`
+		Blobs         []blobItem `xml:"Blobs>Blob"`
+	}
+
+	var (
+		prefix          = r.URL.Query().Get("prefix")
+		includeVersions = strings.Contains(r.URL.Query().Get("include"), "versions")
+		result          = enumerationResults{ContainerName: containerName}
+	)
+
+	for name := range blobs {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		item := blobItem{Name: name}
+
+		if service.versioning && includeVersions && !service.unversioned[containerName+"/"+name] {
+			item.VersionID = "2025-01-01T00:00:00.0000000Z"
+			item.IsCurrentVersion = true
+		}
+
+		result.Blobs = append(result.Blobs, item)
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+
+	xml.NewEncoder(w).Encode(result) //nolint:errcheck
+}
+
+func (service *fakeBlobService) writeVersionHeader(w http.ResponseWriter) {
+	if service.versioning {
+		w.Header().Set("X-Ms-Version-Id", "2025-01-01T00:00:00.0000000Z")
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, code string) {
+	w.Header().Set("X-Ms-Error-Code", code)
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+
+	if r.Method != http.MethodHead {
+		fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"utf-8\"?><Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+	}
+}
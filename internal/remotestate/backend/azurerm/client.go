@@ -0,0 +1,490 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/lease"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	azureMaxRetries          = 3
+	azureSleepBetweenRetries = 10 * time.Second
+
+	// infiniteLeaseDuration is the lease duration that never expires, as used by OpenTofu/Terraform for state locks.
+	infiniteLeaseDuration = -1
+)
+
+type Client struct {
+	*ExtendedRemoteStateConfigAzurerm
+	*azblob.Client
+}
+
+// NewClient inits Azure Blob Storage client.
+//
+// The credentials are resolved the same way the azurerm backend does it: an access key or a SAS token, either from
+// the config or from the `ARM_ACCESS_KEY` and `ARM_SAS_TOKEN` env vars, takes precedence over Azure AD authentication.
+func NewClient(config *ExtendedRemoteStateConfigAzurerm) (*Client, error) {
+	var (
+		azCfg      = config.RemoteStateConfigAzurerm
+		serviceURL = config.BlobServiceURL()
+		accessKey  = valueOrEnv(azCfg.AccessKey, "ARM_ACCESS_KEY")
+		sasToken   = valueOrEnv(azCfg.SASToken, "ARM_SAS_TOKEN")
+
+		blobClient *azblob.Client
+		err        error
+	)
+
+	switch {
+	case accessKey != "" && !azCfg.UseAzureADAuth:
+		cred, err := azblob.NewSharedKeyCredential(azCfg.StorageAccountName, accessKey)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		blobClient, err = azblob.NewClientWithSharedKeyCredential(serviceURL, cred, nil)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+	case sasToken != "" && !azCfg.UseAzureADAuth:
+		blobClient, err = azblob.NewClientWithNoCredential(serviceURL+"?"+strings.TrimPrefix(sasToken, "?"), nil)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+	default:
+		cred, err := newTokenCredential(&azCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		blobClient, err = azblob.NewClient(serviceURL, cred, nil)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+	}
+
+	client := &Client{
+		ExtendedRemoteStateConfigAzurerm: config,
+		Client:                           blobClient,
+	}
+
+	return client, nil
+}
+
+// newTokenCredential returns the Azure AD credential for the given config. A service principal is used if its client ID,
+// secret and tenant ID are all known, otherwise the default credential chain (environment, workload identity,
+// managed identity, Azure CLI) is used.
+func newTokenCredential(cfg *RemoteStateConfigAzurerm) (azcore.TokenCredential, error) {
+	var (
+		tenantID     = valueOrEnv(cfg.TenantID, "ARM_TENANT_ID")
+		clientID     = valueOrEnv(cfg.ClientID, "ARM_CLIENT_ID")
+		clientSecret = valueOrEnv(cfg.ClientSecret, "ARM_CLIENT_SECRET")
+	)
+
+	if tenantID != "" && clientID != "" && clientSecret != "" {
+		cred, err := azidentity.NewClientSecretCredential(tenantID, clientID, clientSecret, nil)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		return cred, nil
+	}
+
+	cred, err := azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+		TenantID: tenantID,
+	})
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return cred, nil
+}
+
+// CreateContainerIfNecessary prompts the user to create the given storage container if it doesn't already exist and
+// if the user confirms, creates the container.
+func (client *Client) CreateContainerIfNecessary(ctx context.Context, l log.Logger, containerName string, opts *options.TerragruntOptions) error {
+	if exists, err := client.DoesContainerExist(ctx, containerName); err != nil || exists {
+		return err
+	}
+
+	l.Debugf("Remote state Azure storage container %s does not exist. Attempting to create it", containerName)
+
+	if opts.FailIfBucketCreationRequired {
+		return backend.BucketCreationNotAllowed(containerName)
+	}
+
+	prompt := fmt.Sprintf("Remote state Azure storage container %s does not exist or you don't have permissions to access it. Would you like Terragrunt to create it?", containerName)
+
+	shouldCreateContainer, err := shell.PromptUserForYesNo(ctx, l, prompt, opts)
+	if err != nil {
+		return err
+	}
+
+	if shouldCreateContainer {
+		description := "Create Azure storage container " + containerName
+
+		return util.DoWithRetry(ctx, description, azureMaxRetries, azureSleepBetweenRetries, l, log.DebugLevel, func(ctx context.Context) error {
+			return client.CreateContainer(ctx, l, containerName)
+		})
+	}
+
+	return nil
+}
+
+// CreateContainer creates the given storage container with the configured metadata.
+func (client *Client) CreateContainer(ctx context.Context, l log.Logger, containerName string) error {
+	l.Debugf("Creating Azure storage container %s in storage account %s", containerName, client.RemoteStateConfigAzurerm.StorageAccountName)
+
+	var metadata map[string]*string
+
+	if len(client.ContainerMetadata) > 0 {
+		metadata = make(map[string]*string, len(client.ContainerMetadata))
+
+		for key, value := range client.ContainerMetadata {
+			metadata[key] = to.Ptr(value)
+		}
+	}
+
+	_, err := client.Client.CreateContainer(ctx, containerName, &azblob.CreateContainerOptions{
+		Metadata: metadata,
+	})
+	if err != nil && !bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+		return errors.Errorf("error creating Azure storage container %s: %w", containerName, err)
+	}
+
+	return nil
+}
+
+// DoesContainerExist returns true if the given storage container exists and the current user has the ability to access it.
+func (client *Client) DoesContainerExist(ctx context.Context, containerName string) (bool, error) {
+	containerClient := client.ServiceClient().NewContainerClient(containerName)
+
+	if _, err := containerClient.GetProperties(ctx, nil); err != nil {
+		if bloberror.HasCode(err, bloberror.ContainerNotFound, bloberror.ContainerBeingDeleted) {
+			return false, nil
+		}
+
+		return false, errors.New(err)
+	}
+
+	return true, nil
+}
+
+// CheckIfVersioningEnabled checks if blob versioning is enabled for the storage account and warns the user if it is not.
+// Returns `backend.VersionControlUnknownError` if the state blob does not exist.
+//
+// Blob versioning is a storage account setting that is only exposed by the management API, so instead of requiring
+// permissions on the storage account itself, we look for versions of the state blob. A blob written before versioning
+// was enabled has no version until it is written again, so in that case we look for versions of the other blobs of
+// the container.
+func (client *Client) CheckIfVersioningEnabled(ctx context.Context, l log.Logger, containerName, key string) (bool, error) {
+	if exists, err := client.DoesContainerExist(ctx, containerName); err != nil {
+		return false, err
+	} else if !exists {
+		return false, backend.NewBucketDoesNotExistError(containerName)
+	}
+
+	exists, versioned, err := client.hasBlobVersions(ctx, containerName, key)
+	if err != nil {
+		return false, err
+	}
+
+	if !exists {
+		l.Debugf("Azure storage container %s blob %s does not exist, cannot determine whether blob versioning is enabled", containerName, key)
+
+		return false, errors.New(backend.VersionControlUnknownError{BackendName: BackendName, Path: path.Join(containerName, key)})
+	}
+
+	if !versioned {
+		if _, versioned, err = client.hasBlobVersions(ctx, containerName, ""); err != nil {
+			return false, err
+		}
+	}
+
+	if versioned {
+		return true, nil
+	}
+
+	l.Warnf("Versioning is not enabled for the remote state Azure storage account %s. We recommend enabling blob versioning so that you can roll back to previous versions of your OpenTofu/Terraform state in case of error.", client.RemoteStateConfigAzurerm.StorageAccountName)
+
+	return false, nil
+}
+
+// hasBlobVersions returns whether the blob with the given name exists, and whether it, or any blob of the container if
+// the name is empty, has a version.
+func (client *Client) hasBlobVersions(ctx context.Context, containerName, key string) (bool, bool, error) {
+	listOpts := &azblob.ListBlobsFlatOptions{
+		Include: azblob.ListBlobsInclude{Versions: true},
+	}
+
+	if key != "" {
+		listOpts.Prefix = to.Ptr(key)
+	}
+
+	var (
+		pager  = client.NewListBlobsFlatPager(containerName, listOpts)
+		exists bool
+	)
+
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return false, false, errors.New(err)
+		}
+
+		if page.Segment == nil {
+			continue
+		}
+
+		for _, item := range page.Segment.BlobItems {
+			// The prefix also matches the blobs whose name starts with the key, such as backups of the state.
+			if key != "" && (item.Name == nil || *item.Name != key) {
+				continue
+			}
+
+			exists = true
+
+			if item.VersionID != nil {
+				return true, true, nil
+			}
+		}
+	}
+
+	return exists, false, nil
+}
+
+// isVersionControlUnknown returns true if the error is `backend.VersionControlUnknownError`.
+func isVersionControlUnknown(err error) bool {
+	return errors.As(err, new(backend.VersionControlUnknownError))
+}
+
+// DoesBlobExist returns true if the specified blob exists otherwise false.
+func (client *Client) DoesBlobExist(ctx context.Context, containerName, key string) (bool, error) {
+	blobClient := client.ServiceClient().NewContainerClient(containerName).NewBlobClient(key)
+
+	if _, err := blobClient.GetProperties(ctx, nil); err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound) {
+			return false, nil
+		}
+
+		return false, errors.New(err)
+	}
+
+	return true, nil
+}
+
+func (client *Client) DoesBlobExistWithLogging(ctx context.Context, l log.Logger, containerName, key string) (bool, error) {
+	if exists, err := client.DoesBlobExist(ctx, containerName, key); err != nil || exists {
+		return exists, err
+	}
+
+	l.Debugf("Remote state Azure storage container %s blob %s does not exist or you don't have permissions to access it.", containerName, key)
+
+	return false, nil
+}
+
+// GetBlob returns the content of the specified blob.
+func (client *Client) GetBlob(ctx context.Context, containerName, key string) ([]byte, error) {
+	resp, err := client.DownloadStream(ctx, containerName, key, nil)
+	if err != nil {
+		return nil, errors.Errorf("failed to download blob %s: %w", path.Join(containerName, key), err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return data, nil
+}
+
+// PutBlob uploads the given content to the specified blob, overwriting it if it already exists. The leaseID must be
+// the ID of the lease held on the blob, if any.
+func (client *Client) PutBlob(ctx context.Context, containerName, key string, data []byte, leaseID string) error {
+	uploadOpts := &azblob.UploadBufferOptions{
+		AccessConditions: leaseAccessConditions(leaseID),
+	}
+
+	if _, err := client.UploadBuffer(ctx, containerName, key, data, uploadOpts); err != nil {
+		return errors.Errorf("failed to upload blob %s: %w", path.Join(containerName, key), err)
+	}
+
+	return nil
+}
+
+// MoveBlobIfNecessary moves the blob at the specified srcContainerName and srcKey to dstContainerName and dstKey
+// of the storage account the given dstClient is connected to.
+func (client *Client) MoveBlobIfNecessary(ctx context.Context, l log.Logger, srcContainerName, srcKey string, dstClient *Client, dstContainerName, dstKey string) error {
+	if exists, err := client.DoesBlobExistWithLogging(ctx, l, srcContainerName, srcKey); err != nil || !exists {
+		return err
+	}
+
+	if exists, err := dstClient.DoesBlobExist(ctx, dstContainerName, dstKey); err != nil {
+		return err
+	} else if exists {
+		return errors.Errorf("destination Azure storage container %s blob %s already exists", dstContainerName, dstKey)
+	}
+
+	description := fmt.Sprintf("Move Azure storage blob from %s to %s", path.Join(srcContainerName, srcKey), path.Join(dstContainerName, dstKey))
+
+	return util.DoWithRetry(ctx, description, azureMaxRetries, azureSleepBetweenRetries, l, log.DebugLevel, func(ctx context.Context) error {
+		return client.MoveBlob(ctx, l, srcContainerName, srcKey, dstClient, dstContainerName, dstKey)
+	})
+}
+
+// MoveBlob copies the blob at the specified srcKey to dstKey and then removes srcKey.
+func (client *Client) MoveBlob(ctx context.Context, l log.Logger, srcContainerName, srcKey string, dstClient *Client, dstContainerName, dstKey string) error {
+	l.Debugf("Copying Azure storage blob from %s to %s", path.Join(srcContainerName, srcKey), path.Join(dstContainerName, dstKey))
+
+	// The blob is copied through the client rather than server-side, since the storage accounts may not be
+	// able to access each other.
+	data, err := client.GetBlob(ctx, srcContainerName, srcKey)
+	if err != nil {
+		return err
+	}
+
+	if err := dstClient.PutBlob(ctx, dstContainerName, dstKey, data, ""); err != nil {
+		return err
+	}
+
+	return client.DeleteBlob(ctx, l, srcContainerName, srcKey, "")
+}
+
+// DeleteBlobIfNecessary deletes the specified blob if it exists. The leaseID must be the ID of the lease held on the
+// blob, if any.
+func (client *Client) DeleteBlobIfNecessary(ctx context.Context, l log.Logger, containerName, key, leaseID string) error {
+	if exists, err := client.DoesBlobExistWithLogging(ctx, l, containerName, key); err != nil || !exists {
+		return err
+	}
+
+	description := fmt.Sprintf("Delete Azure storage blob %s with retry", path.Join(containerName, key))
+
+	return util.DoWithRetry(ctx, description, azureMaxRetries, azureSleepBetweenRetries, l, log.DebugLevel, func(ctx context.Context) error {
+		return client.DeleteBlob(ctx, l, containerName, key, leaseID)
+	})
+}
+
+// DeleteBlob deletes the specified blob along with its snapshots. The leaseID must be the ID of the lease held on the
+// blob, if any.
+func (client *Client) DeleteBlob(ctx context.Context, l log.Logger, containerName, key, leaseID string) error {
+	l.Debugf("Deleting Azure storage blob %s in container %s", key, containerName)
+
+	_, err := client.Client.DeleteBlob(ctx, containerName, key, &azblob.DeleteBlobOptions{
+		DeleteSnapshots:  to.Ptr(azblob.DeleteSnapshotsOptionTypeInclude),
+		AccessConditions: leaseAccessConditions(leaseID),
+	})
+	if err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return errors.Errorf("failed to delete blob %s in container %s: %w", key, containerName, err)
+	}
+
+	return nil
+}
+
+// DeleteContainerIfNecessary deletes the given storage container with all its blobs if it exists.
+func (client *Client) DeleteContainerIfNecessary(ctx context.Context, l log.Logger, containerName string) error {
+	if exists, err := client.DoesContainerExist(ctx, containerName); err != nil || !exists {
+		return err
+	}
+
+	description := fmt.Sprintf("Delete Azure storage container %s with retry", containerName)
+
+	return util.DoWithRetry(ctx, description, azureMaxRetries, azureSleepBetweenRetries, l, log.DebugLevel, func(ctx context.Context) error {
+		l.Debugf("Deleting Azure storage container %s", containerName)
+
+		if _, err := client.Client.DeleteContainer(ctx, containerName, nil); err != nil && !bloberror.HasCode(err, bloberror.ContainerNotFound) {
+			return errors.Errorf("error deleting Azure storage container %s: %w", containerName, err)
+		}
+
+		return nil
+	})
+}
+
+func valueOrEnv(value, envName string) string {
+	if value != "" {
+		return value
+	}
+
+	return os.Getenv(envName)
+}
+
+// LockState acquires an infinite lease on the specified blob, the same way OpenTofu/Terraform does it, and returns
+// the ID of the lease, which the writes and deletes of the blob must pass, and the function that releases the lease.
+// Returns `backend.StateLockedError` if the blob is already leased. A blob that does not exist yet cannot be leased,
+// so it is left unlocked, with an empty lease ID.
+func (client *Client) LockState(ctx context.Context, l log.Logger, containerName, key string) (string, backend.UnlockFunc, error) {
+	if exists, err := client.DoesBlobExist(ctx, containerName, key); err != nil {
+		return "", nil, err
+	} else if !exists {
+		l.Debugf("Azure storage container %s blob %s does not exist, skipping locking", containerName, key)
+
+		return "", backend.NoopUnlock, nil
+	}
+
+	var (
+		blobClient = client.ServiceClient().NewContainerClient(containerName).NewBlobClient(key)
+		leaseID    = backend.NewLockInfo(path.Join(containerName, key)).ID
+	)
+
+	leaseClient, err := lease.NewBlobClient(blobClient, &lease.BlobClientOptions{
+		LeaseID: to.Ptr(leaseID),
+	})
+	if err != nil {
+		return "", nil, errors.New(err)
+	}
+
+	l.Debugf("Acquiring lease on Azure storage container %s blob %s", containerName, key)
+
+	if _, err := leaseClient.AcquireLease(ctx, infiniteLeaseDuration, nil); err != nil {
+		if bloberror.HasCode(err, bloberror.LeaseAlreadyPresent) {
+			return "", nil, errors.New(backend.StateLockedError{Path: path.Join(containerName, key)})
+		}
+
+		return "", nil, errors.Errorf("failed to acquire lease on blob %s: %w", path.Join(containerName, key), err)
+	}
+
+	return leaseID, func(ctx context.Context) error {
+		l.Debugf("Releasing lease on Azure storage container %s blob %s", containerName, key)
+
+		if _, err := leaseClient.ReleaseLease(ctx, nil); err != nil {
+			return errors.Errorf("failed to release lease on blob %s: %w", path.Join(containerName, key), err)
+		}
+
+		return nil
+	}, nil
+}
+
+// BlobURL returns the URL of the specified blob.
+func (client *Client) BlobURL(containerName, key string) string {
+	return client.ServiceClient().NewContainerClient(containerName).NewBlobClient(key).URL()
+}
+
+// leaseAccessConditions returns the access conditions of a blob leased with the given lease ID, or nil if the lease
+// ID is empty.
+func leaseAccessConditions(leaseID string) *azblob.AccessConditions {
+	if leaseID == "" {
+		return nil
+	}
+
+	return &azblob.AccessConditions{
+		LeaseAccessConditions: &blob.LeaseAccessConditions{LeaseID: to.Ptr(leaseID)},
+	}
+}
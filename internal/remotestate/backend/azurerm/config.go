@@ -0,0 +1,78 @@
+package azurerm
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/mitchellh/mapstructure"
+)
+
+type Config map[string]any
+
+func (cfg Config) FilterOutTerragruntKeys() Config {
+	var filtered = make(Config)
+
+	for key, val := range cfg {
+		if slices.Contains(terragruntOnlyConfigs, key) {
+			continue
+		}
+
+		filtered[key] = val
+	}
+
+	return filtered
+}
+
+func (cfg Config) IsEqual(targetCfg Config, logger log.Logger) bool {
+	// If other keys in config are bools, DeepEqual also will consider the maps to be different.
+	for key, value := range targetCfg {
+		if util.KindOf(targetCfg[key]) == reflect.String && util.KindOf(cfg[key]) == reflect.Bool {
+			if convertedValue, err := strconv.ParseBool(value.(string)); err == nil {
+				targetCfg[key] = convertedValue
+			}
+		}
+	}
+
+	// Construct a new map excluding the settings that are only used in Terragrunt config and not in Terraform's backend
+	newConfig := backend.Config{}
+
+	maps.Copy(newConfig, cfg.FilterOutTerragruntKeys())
+
+	return newConfig.IsEqual(backend.Config(targetCfg), BackendName, logger)
+}
+
+// ParseExtendedAzurermConfig parses the given map into an azurerm config.
+func (cfg Config) ParseExtendedAzurermConfig() (*ExtendedRemoteStateConfigAzurerm, error) {
+	var (
+		azurermConfig  RemoteStateConfigAzurerm
+		extendedConfig ExtendedRemoteStateConfigAzurerm
+	)
+
+	if err := mapstructure.Decode(cfg, &azurermConfig); err != nil {
+		return nil, errors.New(err)
+	}
+
+	if err := mapstructure.Decode(cfg, &extendedConfig); err != nil {
+		return nil, errors.New(err)
+	}
+
+	extendedConfig.RemoteStateConfigAzurerm = azurermConfig
+
+	return &extendedConfig, nil
+}
+
+// ExtendedAzurermConfig parses the given map into an extended azurerm config and validates this config.
+func (cfg Config) ExtendedAzurermConfig() (*ExtendedRemoteStateConfigAzurerm, error) {
+	extCfg, err := cfg.ParseExtendedAzurermConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return extCfg, extCfg.Validate()
+}
@@ -0,0 +1,120 @@
+package azurerm_test
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/azurerm"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_IsEqual(t *testing.T) {
+	t.Parallel()
+
+	logger := logger.CreateLogger()
+
+	testCases := []struct { //nolint: govet
+		name          string
+		cfg           azurerm.Config
+		comparableCfg azurerm.Config
+		shouldBeEqual bool
+	}{
+		{
+			"equal-both-empty",
+			azurerm.Config{},
+			azurerm.Config{},
+			true,
+		},
+		{
+			"equal-one-key",
+			azurerm.Config{"container_name": "tfstate"},
+			azurerm.Config{"container_name": "tfstate"},
+			true,
+		},
+		{
+			"equal-general-bool-handling",
+			azurerm.Config{"use_azuread_auth": true},
+			azurerm.Config{"use_azuread_auth": "true"},
+			true,
+		},
+		{
+			"equal-ignore-terragrunt-only-keys",
+			azurerm.Config{"container_name": "tfstate", "skip_container_creation": true, "container_metadata": map[string]string{"foo": "bar"}},
+			azurerm.Config{"container_name": "tfstate"},
+			true,
+		},
+		{
+			"unequal-values",
+			azurerm.Config{"container_name": "tfstate"},
+			azurerm.Config{"container_name": "different"},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := tc.cfg.IsEqual(tc.comparableCfg, logger)
+			assert.Equal(t, tc.shouldBeEqual, actual)
+		})
+	}
+}
+
+func TestConfig_ExtendedAzurermConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct { //nolint: govet
+		name               string
+		cfg                azurerm.Config
+		expectedServiceURL string
+		expectedErr        string
+	}{
+		{
+			"public-cloud",
+			azurerm.Config{"storage_account_name": "tfstate", "container_name": "state", "key": "prod.tfstate"},
+			"https://tfstate.blob.core.windows.net/",
+			"",
+		},
+		{
+			"china-cloud",
+			azurerm.Config{"storage_account_name": "tfstate", "container_name": "state", "key": "prod.tfstate", "environment": "china"},
+			"https://tfstate.blob.core.chinacloudapi.cn/",
+			"",
+		},
+		{
+			"custom-endpoint",
+			azurerm.Config{"storage_account_name": "devstoreaccount1", "container_name": "state", "key": "prod.tfstate", "blob_endpoint": "http://127.0.0.1:10000/devstoreaccount1"},
+			"http://127.0.0.1:10000/devstoreaccount1/",
+			"",
+		},
+		{
+			"missing-container-name",
+			azurerm.Config{"storage_account_name": "tfstate", "key": "prod.tfstate"},
+			"",
+			"Missing required azurerm remote state configuration container_name",
+		},
+		{
+			"unknown-environment",
+			azurerm.Config{"storage_account_name": "tfstate", "container_name": "state", "key": "prod.tfstate", "environment": "mars"},
+			"",
+			`unsupported azurerm environment "mars"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			extCfg, err := tc.cfg.ExtendedAzurermConfig()
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedServiceURL, extCfg.BlobServiceURL())
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package azurerm
+
+type MissingRequiredAzurermRemoteStateConfig string
+
+func (configName MissingRequiredAzurermRemoteStateConfig) Error() string {
+	return "Missing required azurerm remote state configuration " + string(configName)
+}
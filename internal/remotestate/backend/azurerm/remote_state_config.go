@@ -0,0 +1,107 @@
+package azurerm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const (
+	defaultEnvironment = "public"
+
+	blobServiceURLFormat = "https://%s.blob.%s/"
+)
+
+// These are settings that can appear in the remote_state config that are ONLY used by Terragrunt and NOT forwarded
+// to the underlying Terraform backend configuration.
+var terragruntOnlyConfigs = []string{
+	"skip_container_creation",
+	"skip_blob_versioning",
+	"container_metadata",
+	"blob_endpoint",
+}
+
+// storageSuffixes maps the `environment` values supported by the azurerm backend to the storage endpoint suffixes.
+var storageSuffixes = map[string]string{
+	"public":       "core.windows.net",
+	"china":        "core.chinacloudapi.cn",
+	"usgovernment": "core.usgovcloudapi.net",
+	"german":       "core.cloudapi.de",
+}
+
+/* ExtendedRemoteStateConfigAzurerm is a struct that contains the azurerm specific configuration options.
+ *
+ * We use this construct to separate the config keys that are only used by terragrunt, e.g. to set metadata
+ * on the storage container in case it has to create it, from the keys understood by the azurerm backend.
+ */
+type ExtendedRemoteStateConfigAzurerm struct {
+	ContainerMetadata        map[string]string        `mapstructure:"container_metadata"`
+	BlobEndpoint             string                   `mapstructure:"blob_endpoint"`
+	RemoteStateConfigAzurerm RemoteStateConfigAzurerm `mapstructure:",squash"`
+	SkipContainerCreation    bool                     `mapstructure:"skip_container_creation"`
+	SkipBlobVersioning       bool                     `mapstructure:"skip_blob_versioning"`
+}
+
+// Validate validates the configuration for azurerm remote state.
+func (cfg *ExtendedRemoteStateConfigAzurerm) Validate() error {
+	if cfg.RemoteStateConfigAzurerm.StorageAccountName == "" {
+		return errors.New(MissingRequiredAzurermRemoteStateConfig("storage_account_name"))
+	}
+
+	if cfg.RemoteStateConfigAzurerm.ContainerName == "" {
+		return errors.New(MissingRequiredAzurermRemoteStateConfig("container_name"))
+	}
+
+	if cfg.RemoteStateConfigAzurerm.Key == "" {
+		return errors.New(MissingRequiredAzurermRemoteStateConfig("key"))
+	}
+
+	if _, ok := storageSuffixes[cfg.environment()]; !ok && cfg.BlobEndpoint == "" {
+		return errors.Errorf("unsupported azurerm environment %q", cfg.RemoteStateConfigAzurerm.Environment)
+	}
+
+	return nil
+}
+
+// BlobServiceURL returns the URL of the blob service of the configured storage account. The `blob_endpoint` setting
+// takes precedence, which allows pointing Terragrunt at a local storage emulator such as Azurite.
+func (cfg *ExtendedRemoteStateConfigAzurerm) BlobServiceURL() string {
+	if cfg.BlobEndpoint != "" {
+		return strings.TrimSuffix(cfg.BlobEndpoint, "/") + "/"
+	}
+
+	return fmt.Sprintf(blobServiceURLFormat, cfg.RemoteStateConfigAzurerm.StorageAccountName, storageSuffixes[cfg.environment()])
+}
+
+func (cfg *ExtendedRemoteStateConfigAzurerm) environment() string {
+	if cfg.RemoteStateConfigAzurerm.Environment == "" {
+		return defaultEnvironment
+	}
+
+	return strings.ToLower(cfg.RemoteStateConfigAzurerm.Environment)
+}
+
+// RemoteStateConfigAzurerm is a representation of the configuration
+// options available for azurerm remote state.
+type RemoteStateConfigAzurerm struct {
+	StorageAccountName string `mapstructure:"storage_account_name"`
+	ContainerName      string `mapstructure:"container_name"`
+	Key                string `mapstructure:"key"`
+	ResourceGroupName  string `mapstructure:"resource_group_name"`
+	Environment        string `mapstructure:"environment"`
+	AccessKey          string `mapstructure:"access_key"`
+	SASToken           string `mapstructure:"sas_token"`
+	SubscriptionID     string `mapstructure:"subscription_id"`
+	TenantID           string `mapstructure:"tenant_id"`
+	ClientID           string `mapstructure:"client_id"`
+	ClientSecret       string `mapstructure:"client_secret"`
+	UseAzureADAuth     bool   `mapstructure:"use_azuread_auth"`
+	UseMSI             bool   `mapstructure:"use_msi"`
+	UseOIDC            bool   `mapstructure:"use_oidc"`
+}
+
+// CacheKey returns a unique key for the given azurerm config that can be used to cache the initialization.
+func (cfg *RemoteStateConfigAzurerm) CacheKey() string {
+	return cfg.StorageAccountName + "/" + cfg.ContainerName
+}
@@ -3,6 +3,7 @@ package backend
 
 import (
 	"context"
+	"slices"
 
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
@@ -21,6 +22,33 @@ func (backends Backends) Get(name string) Backend {
 	return nil
 }
 
+// Register adds the given backends to the list. A backend with the same name as an already registered one replaces it.
+func (backends *Backends) Register(newBackends ...Backend) {
+	for _, newBackend := range newBackends {
+		idx := slices.IndexFunc(*backends, func(backend Backend) bool {
+			return backend.Name() == newBackend.Name()
+		})
+
+		if idx >= 0 {
+			(*backends)[idx] = newBackend
+			continue
+		}
+
+		*backends = append(*backends, newBackend)
+	}
+}
+
+// Names returns the names of all backends.
+func (backends Backends) Names() []string {
+	names := make([]string, 0, len(backends))
+
+	for _, backend := range backends {
+		names = append(names, backend.Name())
+	}
+
+	return names
+}
+
 type Backend interface {
 	// Names returns the backend name.
 	Name() string
@@ -43,6 +71,31 @@ type Backend interface {
 	// DeleteBucket deletes the entire bucket.
 	DeleteBucket(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) error
 
+	// ReadState returns the raw state stored at the location specified in the given config, or nil if there is no state.
+	// Backends which cannot access the state themselves return `StateAccessNotImplementedError`.
+	ReadState(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) ([]byte, error)
+
+	// WriteState stores the given raw state at the location specified in the given config.
+	// Backends which cannot access the state themselves return `StateAccessNotImplementedError`.
+	WriteState(ctx context.Context, l log.Logger, config Config, state []byte, opts *options.TerragruntOptions) error
+
+	// StateETag returns the entity tag of the state stored at the location specified in the given config, which changes
+	// whenever the state is written, or an empty string if there is no state.
+	// Backends which cannot access the state themselves return `StateAccessNotImplementedError`.
+	StateETag(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) (string, error)
+
+	// LockState acquires the state lock the same way OpenTofu/Terraform does and returns the function that releases it.
+	// Returns `StateLockedError` if the state is already locked.
+	LockState(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) (UnlockFunc, error)
+
+	// ListStateVersions returns the versions of the state object kept by the versioning of the backend storage,
+	// from the newest to the oldest. Backends without versioning support return `StateVersioningNotImplementedError`.
+	ListStateVersions(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) (StateVersions, error)
+
+	// ReadStateVersion returns the raw state of the given version.
+	// Backends without versioning support return `StateVersioningNotImplementedError`.
+	ReadStateVersion(ctx context.Context, l log.Logger, config Config, versionID string, opts *options.TerragruntOptions) ([]byte, error)
+
 	// GetTFInitArgs returns the config that should be passed on to `tofu -backend-config` cmd line param
 	// Allows the Backends to filter and/or modify the configuration given from the user.
 	GetTFInitArgs(config Config) map[string]any
@@ -0,0 +1,73 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackends_Register(t *testing.T) {
+	t.Parallel()
+
+	var (
+		s3Backend     = backend.NewCommonBackend("s3")
+		gcsBackend    = backend.NewCommonBackend("gcs")
+		customBackend = backend.NewCommonBackend("s3")
+		backends      = backend.Backends{s3Backend}
+	)
+
+	backends.Register(gcsBackend)
+	assert.Equal(t, []string{"s3", "gcs"}, backends.Names())
+	assert.Same(t, gcsBackend, backends.Get("gcs"))
+
+	backends.Register(customBackend)
+	assert.Equal(t, []string{"s3", "gcs"}, backends.Names())
+	assert.Same(t, customBackend, backends.Get("s3"))
+
+	assert.Nil(t, backends.Get("azurerm"))
+}
+
+func TestStateVersions_Find(t *testing.T) {
+	t.Parallel()
+
+	versions := backend.StateVersions{
+		{ID: "v1", LastModified: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)},
+		{ID: "v3", LastModified: time.Date(2025, 1, 3, 10, 0, 0, 0, time.UTC), IsLatest: true},
+		{ID: "v2", LastModified: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)},
+	}
+
+	versions.Sort()
+	assert.Equal(t, "v3", versions[0].ID)
+	assert.Equal(t, "v3", versions.Latest().ID)
+
+	testCases := []struct {
+		query       string
+		expectedID  string
+		expectedErr bool
+	}{
+		{query: "v2", expectedID: "v2"},
+		{query: "2025-01-02T12:00:00Z", expectedID: "v2"},
+		{query: "2025-01-02T10:00:00Z", expectedID: "v2"},
+		{query: "2025-01-02", expectedID: "v1"},
+		{query: "2025-02-01 00:00:00", expectedID: "v3"},
+		{query: "2024-12-31", expectedErr: true},
+		{query: "unknown", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			t.Parallel()
+
+			version, err := versions.Find(tc.query)
+			if tc.expectedErr {
+				assert.ErrorAs(t, err, new(backend.StateVersionNotFoundError))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedID, version.ID)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/puzpuzpuz/xsync/v3"
@@ -69,6 +70,38 @@ func (backend *CommonBackend) DeleteBucket(ctx context.Context, l log.Logger, co
 	return nil
 }
 
+// ReadState implements `backends.ReadState` interface.
+func (backend *CommonBackend) ReadState(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) ([]byte, error) {
+	return nil, errors.New(StateAccessNotImplementedError{BackendName: backend.Name()})
+}
+
+// WriteState implements `backends.WriteState` interface.
+func (backend *CommonBackend) WriteState(ctx context.Context, l log.Logger, config Config, state []byte, opts *options.TerragruntOptions) error {
+	return errors.New(StateAccessNotImplementedError{BackendName: backend.Name()})
+}
+
+// StateETag implements `backends.StateETag` interface.
+func (backend *CommonBackend) StateETag(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) (string, error) {
+	return "", errors.New(StateAccessNotImplementedError{BackendName: backend.Name()})
+}
+
+// LockState implements `backends.LockState` interface.
+func (backend *CommonBackend) LockState(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) (UnlockFunc, error) {
+	l.Debugf("Locking state for %s backend not implemented.", backend.Name())
+
+	return NoopUnlock, nil
+}
+
+// ListStateVersions implements `backends.ListStateVersions` interface.
+func (backend *CommonBackend) ListStateVersions(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) (StateVersions, error) {
+	return nil, errors.New(StateVersioningNotImplementedError{BackendName: backend.Name()})
+}
+
+// ReadStateVersion implements `backends.ReadStateVersion` interface.
+func (backend *CommonBackend) ReadStateVersion(ctx context.Context, l log.Logger, config Config, versionID string, opts *options.TerragruntOptions) ([]byte, error) {
+	return nil, errors.New(StateVersioningNotImplementedError{BackendName: backend.Name()})
+}
+
 // GetTFInitArgs implements `backends.GetTFInitArgs` interface.
 func (backend *CommonBackend) GetTFInitArgs(config Config) map[string]any {
 	return config
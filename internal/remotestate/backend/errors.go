@@ -24,3 +24,72 @@ func NewBucketDoesNotExistError(bucketName string) *BucketDoesNotExistError {
 func (err BucketDoesNotExistError) Error() string {
 	return fmt.Sprintf("S3 bucket %s does not exist", err.bucketName)
 }
+
+// StateAccessNotImplementedError is the error that is returned when the backend cannot read or write the state itself
+// and the caller has to fall back to the OpenTofu/Terraform CLI.
+type StateAccessNotImplementedError struct {
+	BackendName string
+}
+
+// Error implements `error` interface.
+func (err StateAccessNotImplementedError) Error() string {
+	return fmt.Sprintf("Reading and writing state is not implemented for the %s backend", err.BackendName)
+}
+
+// VersionControlNotSupportedError is the error that is returned when the state storage of the backend, such as a local
+// file or an HTTP server, cannot be versioned, so there is no versioning to check.
+type VersionControlNotSupportedError struct {
+	BackendName string
+}
+
+// Error implements `error` interface.
+func (err VersionControlNotSupportedError) Error() string {
+	return fmt.Sprintf("Version control is not supported by the %s backend", err.BackendName)
+}
+
+// VersionControlUnknownError is the error that is returned when whether the state storage of the backend is versioned
+// cannot be determined, such as before the state is first written.
+type VersionControlUnknownError struct {
+	BackendName string
+	Path        string
+}
+
+// Error implements `error` interface.
+func (err VersionControlUnknownError) Error() string {
+	return fmt.Sprintf("Cannot determine whether version control is enabled for the %s backend state %s, which does not exist", err.BackendName, err.Path)
+}
+
+// StateLockedError is the error that is returned when the state is already locked.
+type StateLockedError struct {
+	Path string
+	Info *LockInfo
+}
+
+// Error implements `error` interface.
+func (err StateLockedError) Error() string {
+	if err.Info == nil {
+		return fmt.Sprintf("State %s is already locked", err.Path)
+	}
+
+	return fmt.Sprintf("State %s is already locked (%s)", err.Path, err.Info)
+}
+
+// StateVersioningNotImplementedError is the error that is returned when the backend cannot access prior state versions.
+type StateVersioningNotImplementedError struct {
+	BackendName string
+}
+
+// Error implements `error` interface.
+func (err StateVersioningNotImplementedError) Error() string {
+	return fmt.Sprintf("Accessing state versions is not implemented for the %s backend", err.BackendName)
+}
+
+// StateVersionNotFoundError is the error that is returned when no state version matches the given ID or timestamp.
+type StateVersionNotFoundError struct {
+	Query string
+}
+
+// Error implements `error` interface.
+func (err StateVersionNotFoundError) Error() string {
+	return fmt.Sprintf("No state version found by ID or timestamp %q", err.Query)
+}
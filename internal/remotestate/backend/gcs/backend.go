@@ -220,3 +220,123 @@ func (backend *Backend) DeleteBucket(ctx context.Context, l log.Logger, backendC
 func (backend *Backend) GetTFInitArgs(config backend.Config) map[string]any {
 	return Config(config).FilterOutTerragruntKeys()
 }
+
+// ReadState returns the content of the state object specified in the given config, or nil if the object does not exist.
+func (backend *Backend) ReadState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) ([]byte, error) {
+	extGCSCfg, err := Config(backendConfig).ExtendedGCSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(ctx, extGCSCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		bucketName = extGCSCfg.RemoteStateConfigGCS.Bucket
+		bucketKey  = path.Join(extGCSCfg.RemoteStateConfigGCS.Prefix, defaultTfState)
+	)
+
+	return client.GetGCSObject(ctx, bucketName, bucketKey)
+}
+
+// StateETag returns the ETag of the state object specified in the given config.
+func (backend *Backend) StateETag(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (string, error) {
+	extGCSCfg, err := Config(backendConfig).ExtendedGCSConfig()
+	if err != nil {
+		return "", err
+	}
+
+	client, err := NewClient(ctx, extGCSCfg)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		bucketName = extGCSCfg.RemoteStateConfigGCS.Bucket
+		bucketKey  = path.Join(extGCSCfg.RemoteStateConfigGCS.Prefix, defaultTfState)
+	)
+
+	return client.GetGCSObjectETag(ctx, bucketName, bucketKey)
+}
+
+// WriteState stores the given state in the object specified in the given config.
+func (backend *Backend) WriteState(ctx context.Context, l log.Logger, backendConfig backend.Config, state []byte, opts *options.TerragruntOptions) error {
+	extGCSCfg, err := Config(backendConfig).ExtendedGCSConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx, extGCSCfg)
+	if err != nil {
+		return err
+	}
+
+	var (
+		bucketName = extGCSCfg.RemoteStateConfigGCS.Bucket
+		bucketKey  = path.Join(extGCSCfg.RemoteStateConfigGCS.Prefix, defaultTfState)
+	)
+
+	return client.PutGCSObject(ctx, l, bucketName, bucketKey, state)
+}
+
+// LockState locks the state specified in the given config by creating the lock object next to the state object.
+func (backend *Backend) LockState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (backend.UnlockFunc, error) {
+	extGCSCfg, err := Config(backendConfig).ExtendedGCSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(ctx, extGCSCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		bucketName = extGCSCfg.RemoteStateConfigGCS.Bucket
+		bucketKey  = path.Join(extGCSCfg.RemoteStateConfigGCS.Prefix, defaultTfState)
+	)
+
+	return client.LockState(ctx, l, bucketName, bucketKey)
+}
+
+// ListStateVersions returns the generations of the state object specified in the given config, from the newest to the oldest.
+func (backend *Backend) ListStateVersions(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (backend.StateVersions, error) {
+	extGCSCfg, err := Config(backendConfig).ExtendedGCSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(ctx, extGCSCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		bucketName = extGCSCfg.RemoteStateConfigGCS.Bucket
+		bucketKey  = path.Join(extGCSCfg.RemoteStateConfigGCS.Prefix, defaultTfState)
+	)
+
+	return client.ListGCSObjectVersions(ctx, bucketName, bucketKey)
+}
+
+// ReadStateVersion returns the content of the given generation of the state object specified in the given config.
+func (backend *Backend) ReadStateVersion(ctx context.Context, l log.Logger, backendConfig backend.Config, versionID string, opts *options.TerragruntOptions) ([]byte, error) {
+	extGCSCfg, err := Config(backendConfig).ExtendedGCSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(ctx, extGCSCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		bucketName = extGCSCfg.RemoteStateConfigGCS.Bucket
+		bucketKey  = path.Join(extGCSCfg.RemoteStateConfigGCS.Prefix, defaultTfState)
+	)
+
+	return client.GetGCSObjectVersion(ctx, bucketName, bucketKey, versionID)
+}
@@ -2,10 +2,15 @@ package gcs
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path"
+	"strconv"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -17,6 +22,7 @@ import (
 	"github.com/gruntwork-io/terragrunt/util"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/jwt"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/impersonate"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
@@ -30,6 +36,9 @@ const (
 	gcpSleepBetweenRetries = 10 * time.Second
 
 	tokenURL = "https://oauth2.googleapis.com/token"
+
+	stateFileExt = ".tfstate"
+	lockFileExt  = ".tflock"
 )
 
 type Client struct {
@@ -459,3 +468,200 @@ func (client *Client) CopyGCSBucketObject(ctx context.Context, l log.Logger, src
 
 	return nil
 }
+
+// GetGCSObject returns the content of the GCS object at the specified key, or nil if the object does not exist.
+func (client *Client) GetGCSObject(ctx context.Context, bucketName, key string) ([]byte, error) {
+	obj, err := client.stateObject(bucketName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := obj.NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, nil
+		}
+
+		return nil, errors.Errorf("failed to read GCS bucket %s object %s: %w", bucketName, key, err)
+	}
+
+	defer reader.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return data, nil
+}
+
+// GetGCSObjectETag returns the ETag of the GCS object at the specified key, or an empty string if the object does not exist.
+func (client *Client) GetGCSObjectETag(ctx context.Context, bucketName, key string) (string, error) {
+	obj, err := client.stateObject(bucketName, key)
+	if err != nil {
+		return "", err
+	}
+
+	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return "", nil
+		}
+
+		return "", errors.Errorf("failed to get GCS bucket %s object %s attributes: %w", bucketName, key, err)
+	}
+
+	return attrs.Etag, nil
+}
+
+// PutGCSObject stores the given data in the GCS object at the specified key.
+func (client *Client) PutGCSObject(ctx context.Context, l log.Logger, bucketName, key string, data []byte) error {
+	l.Debugf("Putting GCS bucket %s object %s", bucketName, key)
+
+	obj, err := client.stateObject(bucketName, key)
+	if err != nil {
+		return err
+	}
+
+	writer := obj.NewWriter(ctx)
+	writer.ContentType = "application/json"
+
+	if _, err := writer.Write(data); err != nil {
+		writer.Close() //nolint:errcheck
+
+		return errors.Errorf("failed to write GCS bucket %s object %s: %w", bucketName, key, err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return errors.Errorf("failed to write GCS bucket %s object %s: %w", bucketName, key, err)
+	}
+
+	return nil
+}
+
+// LockState creates the lock object next to the state object at the specified key, the same way OpenTofu/Terraform
+// does it, and returns the function that releases the lock. Returns `backend.StateLockedError` if the lock object
+// already exists.
+func (client *Client) LockState(ctx context.Context, l log.Logger, bucketName, key string) (backend.UnlockFunc, error) {
+	var (
+		lockKey = strings.TrimSuffix(key, stateFileExt) + lockFileExt
+		lockObj = client.Bucket(bucketName).Object(lockKey)
+		info    = backend.NewLockInfo(path.Join(bucketName, key))
+	)
+
+	l.Debugf("Locking GCS bucket %s object %s", bucketName, key)
+
+	writer := lockObj.If(storage.Conditions{DoesNotExist: true}).NewWriter(ctx)
+	writer.ContentType = "application/json"
+
+	_, err := writer.Write(info.Marshal())
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		var apiErr *googleapi.Error
+		if !errors.As(err, &apiErr) || apiErr.Code != http.StatusPreconditionFailed {
+			return nil, errors.Errorf("failed to lock GCS bucket %s object %s: %w", bucketName, key, err)
+		}
+
+		lockErr := backend.StateLockedError{Path: info.Path}
+
+		if reader, err := lockObj.NewReader(ctx); err == nil {
+			if data, err := io.ReadAll(reader); err == nil {
+				lockErr.Info, _ = backend.ParseLockInfo(data)
+			}
+
+			reader.Close() //nolint:errcheck
+		}
+
+		return nil, errors.New(lockErr)
+	}
+
+	return func(ctx context.Context) error {
+		l.Debugf("Unlocking GCS bucket %s object %s", bucketName, key)
+
+		if err := lockObj.Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return errors.Errorf("failed to unlock GCS bucket %s object %s: %w", bucketName, key, err)
+		}
+
+		return nil
+	}, nil
+}
+
+// stateObject returns the handle of the state object, which is encrypted with the customer-supplied
+// `encryption_key`, if it is set.
+func (client *Client) stateObject(bucketName, key string) (*storage.ObjectHandle, error) {
+	obj := client.Bucket(bucketName).Object(key)
+
+	if encryptionKey := client.RemoteStateConfigGCS.EncryptionKey; encryptionKey != "" {
+		decodedKey, err := base64.StdEncoding.DecodeString(encryptionKey)
+		if err != nil {
+			return nil, errors.Errorf("failed to decode GCS encryption_key: %w", err)
+		}
+
+		obj = obj.Key(decodedKey)
+	}
+
+	return obj, nil
+}
+
+// ListGCSObjectVersions returns the generations of the GCS object at the specified key, from the newest to the oldest.
+func (client *Client) ListGCSObjectVersions(ctx context.Context, bucketName, key string) (backend.StateVersions, error) {
+	var versions backend.StateVersions
+
+	it := client.Bucket(bucketName).Objects(ctx, &storage.Query{Prefix: key, Versions: true})
+
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.Errorf("failed to list versions of GCS bucket %s object %s: %w", bucketName, key, err)
+		}
+
+		if attrs.Name != key {
+			continue
+		}
+
+		versions = append(versions, &backend.StateVersion{
+			ID:           strconv.FormatInt(attrs.Generation, 10),
+			LastModified: attrs.Created,
+			Size:         attrs.Size,
+			IsLatest:     attrs.Deleted.IsZero(),
+		})
+	}
+
+	versions.Sort()
+
+	return versions, nil
+}
+
+// GetGCSObjectVersion returns the content of the given generation of the GCS object at the specified key.
+func (client *Client) GetGCSObjectVersion(ctx context.Context, bucketName, key, versionID string) ([]byte, error) {
+	generation, err := strconv.ParseInt(versionID, 10, 64)
+	if err != nil {
+		return nil, errors.Errorf("invalid GCS object generation %q: %w", versionID, err)
+	}
+
+	obj, err := client.stateObject(bucketName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := obj.Generation(generation).NewReader(ctx)
+	if err != nil {
+		return nil, errors.Errorf("failed to read GCS bucket %s object %s generation %s: %w", bucketName, key, versionID, err)
+	}
+
+	defer reader.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return data, nil
+}
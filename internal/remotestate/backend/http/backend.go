@@ -0,0 +1,120 @@
+// Package http represents the HTTP backend for interacting with state stored by a REST state server.
+package http
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
+)
+
+const BackendName = "http"
+
+var _ backend.Backend = new(Backend)
+
+var versionControlNotSupportedError = backend.VersionControlNotSupportedError{BackendName: BackendName}
+
+type Backend struct {
+	*backend.CommonBackend
+}
+
+func NewBackend() *Backend {
+	return &Backend{
+		CommonBackend: backend.NewCommonBackend(BackendName),
+	}
+}
+
+// Bootstrap validates the config. The state server is managed outside of Terragrunt, so there is nothing to create.
+func (backend *Backend) Bootstrap(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) error {
+	if _, err := Config(backendConfig).HTTPConfig(); err != nil {
+		return err
+	}
+
+	l.Debugf("Nothing to bootstrap for the %s backend", backend.Name())
+
+	return nil
+}
+
+// IsVersionControlEnabled returns `backend.VersionControlNotSupportedError`, since the versioning of the state server
+// cannot be determined.
+func (backend *Backend) IsVersionControlEnabled(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (bool, error) {
+	return false, errors.New(versionControlNotSupportedError)
+}
+
+// Migrate moves the state stored at the address of src config to the address of dst config.
+func (backend *Backend) Migrate(ctx context.Context, l log.Logger, srcBackendConfig, dstBackendConfig backend.Config, opts *options.TerragruntOptions) error {
+	srcHTTPCfg, err := Config(srcBackendConfig).HTTPConfig()
+	if err != nil {
+		return err
+	}
+
+	dstHTTPCfg, err := Config(dstBackendConfig).HTTPConfig()
+	if err != nil {
+		return err
+	}
+
+	var (
+		srcClient = NewClient(srcHTTPCfg)
+		dstClient = NewClient(dstHTTPCfg)
+	)
+
+	return srcClient.MoveStateIfNecessary(ctx, l, srcHTTPCfg.Address, dstClient, dstHTTPCfg.Address)
+}
+
+// Delete deletes the state stored at the address of the given config.
+func (backend *Backend) Delete(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) error {
+	httpCfg, err := Config(backendConfig).HTTPConfig()
+	if err != nil {
+		return err
+	}
+
+	prompt := fmt.Sprintf("State at %s will be deleted. Do you want to continue?", httpCfg.Address)
+	if yes, err := shell.PromptUserForYesNo(ctx, l, prompt, opts); err != nil {
+		return err
+	} else if yes {
+		l.Debugf("Deleting state at %s", httpCfg.Address)
+
+		return NewClient(httpCfg).DeleteState(ctx, httpCfg.Address)
+	}
+
+	return nil
+}
+
+// ReadState returns the state stored at the address of the given config, or nil if there is no state.
+func (backend *Backend) ReadState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) ([]byte, error) {
+	httpCfg, err := Config(backendConfig).HTTPConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	l.Debugf("Reading state from %s", httpCfg.Address)
+
+	return NewClient(httpCfg).GetState(ctx, httpCfg.Address)
+}
+
+// WriteState stores the given state at the address of the given config.
+func (backend *Backend) WriteState(ctx context.Context, l log.Logger, backendConfig backend.Config, state []byte, opts *options.TerragruntOptions) error {
+	httpCfg, err := Config(backendConfig).HTTPConfig()
+	if err != nil {
+		return err
+	}
+
+	l.Debugf("Writing state to %s", httpCfg.Address)
+
+	return NewClient(httpCfg).UpdateState(ctx, httpCfg.Address, state)
+}
+
+// LockState locks the state through the lock address of the given config. If no lock address is configured,
+// the state server does not support locking and the state is left unlocked.
+func (backend *Backend) LockState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (backend.UnlockFunc, error) {
+	httpCfg, err := Config(backendConfig).HTTPConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(httpCfg).LockState(ctx, l)
+}
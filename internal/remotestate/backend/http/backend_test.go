@@ -0,0 +1,173 @@
+package http_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	httpbackend "github.com/gruntwork-io/terragrunt/internal/remotestate/backend/http"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackend_Migrate(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeStateServer(t)
+	server.states["/src"] = []byte(`{"serial": 1}`)
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	var (
+		l         = logger.CreateLogger()
+		srcConfig = backend.Config{"address": server.URL + "/src", "username": "user", "password": "pass"}
+		dstConfig = backend.Config{"address": server.URL + "/dst", "username": "user", "password": "pass"}
+	)
+
+	require.NoError(t, httpbackend.NewBackend().Migrate(t.Context(), l, srcConfig, dstConfig, opts))
+
+	assert.NotContains(t, server.states, "/src")
+	assert.JSONEq(t, `{"serial": 1}`, string(server.states["/dst"]))
+
+	// Migrating again is a no-op since the source state no longer exists.
+	require.NoError(t, httpbackend.NewBackend().Migrate(t.Context(), l, srcConfig, dstConfig, opts))
+
+	server.states["/src"] = []byte(`{"serial": 2}`)
+
+	err = httpbackend.NewBackend().Migrate(t.Context(), l, srcConfig, dstConfig, opts)
+	require.ErrorContains(t, err, "already exists")
+}
+
+func TestBackend_Delete(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeStateServer(t)
+	server.states["/app"] = []byte(`{}`)
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	err = httpbackend.NewBackend().Delete(t.Context(), logger.CreateLogger(), backend.Config{"address": server.URL + "/app", "username": "user", "password": "pass"}, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, server.states, "/app")
+}
+
+func TestBackend_DeleteUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeStateServer(t)
+	server.states["/app"] = []byte(`{}`)
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	err = httpbackend.NewBackend().Delete(t.Context(), logger.CreateLogger(), backend.Config{"address": server.URL + "/app"}, opts)
+	require.ErrorAs(t, err, new(httpbackend.UnexpectedResponseError))
+	assert.Contains(t, server.states, "/app")
+}
+
+func TestBackend_MissingAddress(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	err = httpbackend.NewBackend().Bootstrap(t.Context(), logger.CreateLogger(), backend.Config{}, opts)
+	require.EqualError(t, err, "Missing required HTTP remote state configuration address")
+}
+
+func TestBackend_LockState(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeStateServer(t)
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	var (
+		l   = logger.CreateLogger()
+		cfg = backend.Config{"address": server.URL + "/app", "lock_address": server.URL + "/app/lock", "username": "user", "password": "pass"}
+	)
+
+	unlock, err := httpbackend.NewBackend().LockState(t.Context(), l, cfg, opts)
+	require.NoError(t, err)
+	assert.Contains(t, server.locks, "/app/lock")
+
+	_, err = httpbackend.NewBackend().LockState(t.Context(), l, cfg, opts)
+
+	var lockErr backend.StateLockedError
+	require.ErrorAs(t, err, &lockErr)
+	require.NotNil(t, lockErr.Info)
+	assert.Equal(t, backend.LockOperation, lockErr.Info.Operation)
+
+	require.NoError(t, unlock(t.Context()))
+	assert.NotContains(t, server.locks, "/app/lock")
+
+	// Without a lock address the state server does not support locking.
+	unlock, err = httpbackend.NewBackend().LockState(t.Context(), l, backend.Config{"address": server.URL + "/app"}, opts)
+	require.NoError(t, err)
+	require.NoError(t, unlock(t.Context()))
+}
+
+// fakeStateServer is a minimal REST state server which requires basic auth.
+type fakeStateServer struct {
+	*httptest.Server
+	states map[string][]byte
+	locks  map[string][]byte
+	mu     sync.Mutex
+}
+
+func newFakeStateServer(t *testing.T) *fakeStateServer {
+	t.Helper()
+
+	server := &fakeStateServer{states: make(map[string][]byte), locks: make(map[string][]byte)}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := server.states[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.Write(data) //nolint:errcheck
+		case http.MethodPost:
+			data, _ := io.ReadAll(r.Body)
+			server.states[r.URL.Path] = data
+		case http.MethodDelete:
+			delete(server.states, r.URL.Path)
+		case "LOCK":
+			if info, ok := server.locks[r.URL.Path]; ok {
+				w.WriteHeader(http.StatusLocked)
+				w.Write(info) //nolint:errcheck
+
+				return
+			}
+
+			server.locks[r.URL.Path], _ = io.ReadAll(r.Body)
+		case "UNLOCK":
+			delete(server.locks, r.URL.Path)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
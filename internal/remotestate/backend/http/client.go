@@ -0,0 +1,232 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	httpMaxRetries          = 3
+	httpSleepBetweenRetries = 5 * time.Second
+
+	httpClientTimeout = 30 * time.Second
+)
+
+type Client struct {
+	*RemoteStateConfigHTTP
+	*http.Client
+}
+
+// NewClient inits HTTP client for the state server.
+func NewClient(config *RemoteStateConfigHTTP) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.SkipCertVerification {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+	}
+
+	return &Client{
+		RemoteStateConfigHTTP: config,
+		Client: &http.Client{
+			Transport: transport,
+			Timeout:   httpClientTimeout,
+		},
+	}
+}
+
+// GetState returns the state stored at the given address, or nil if there is no state.
+func (client *Client) GetState(ctx context.Context, address string) ([]byte, error) {
+	resp, err := client.do(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent, http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, errors.New(UnexpectedResponseError{Method: http.MethodGet, Address: address, StatusCode: resp.StatusCode})
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	return data, nil
+}
+
+// UpdateState stores the given state at the given address using the configured update method.
+func (client *Client) UpdateState(ctx context.Context, address string, data []byte) error {
+	resp, err := client.do(ctx, client.UpdateMethod, address, data)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	default:
+		return errors.New(UnexpectedResponseError{Method: client.UpdateMethod, Address: address, StatusCode: resp.StatusCode})
+	}
+}
+
+// DeleteState deletes the state stored at the given address.
+func (client *Client) DeleteState(ctx context.Context, address string) error {
+	resp, err := client.do(ctx, http.MethodDelete, address, nil)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return errors.New(UnexpectedResponseError{Method: http.MethodDelete, Address: address, StatusCode: resp.StatusCode})
+	}
+}
+
+// MoveStateIfNecessary moves the state stored at srcAddress to dstAddress, which is reached through the given dstClient.
+func (client *Client) MoveStateIfNecessary(ctx context.Context, l log.Logger, srcAddress string, dstClient *Client, dstAddress string) error {
+	data, err := client.GetState(ctx, srcAddress)
+	if err != nil {
+		return err
+	}
+
+	if data == nil {
+		l.Debugf("Remote state at %s does not exist or you don't have permissions to access it.", srcAddress)
+
+		return nil
+	}
+
+	if dstData, err := dstClient.GetState(ctx, dstAddress); err != nil {
+		return err
+	} else if dstData != nil {
+		return errors.Errorf("destination state at %s already exists", dstAddress)
+	}
+
+	description := fmt.Sprintf("Move state from %s to %s", srcAddress, dstAddress)
+
+	return util.DoWithRetry(ctx, description, httpMaxRetries, httpSleepBetweenRetries, l, log.DebugLevel, func(ctx context.Context) error {
+		if err := dstClient.UpdateState(ctx, dstAddress, data); err != nil {
+			return err
+		}
+
+		return client.DeleteState(ctx, srcAddress)
+	})
+}
+
+// LockState locks the state if a lock address is configured and returns the function that releases the lock.
+func (client *Client) LockState(ctx context.Context, l log.Logger) (backend.UnlockFunc, error) {
+	if client.LockAddress == "" {
+		l.Debugf("No lock address configured for state at %s, skipping locking", client.Address)
+
+		return backend.NoopUnlock, nil
+	}
+
+	info := backend.NewLockInfo(client.Address)
+
+	l.Debugf("Locking state at %s", client.Address)
+
+	if err := client.Lock(ctx, info); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		l.Debugf("Unlocking state at %s", client.Address)
+
+		return client.Unlock(ctx, info)
+	}, nil
+}
+
+// Lock locks the state by sending the given lock info to the lock address. Returns `backend.StateLockedError`
+// if the state server reports that the state is already locked.
+func (client *Client) Lock(ctx context.Context, info *backend.LockInfo) error {
+	resp, err := client.do(ctx, client.LockMethod, client.LockAddress, info.Marshal())
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusConflict, http.StatusLocked:
+		lockErr := backend.StateLockedError{Path: client.Address}
+
+		if data, err := io.ReadAll(resp.Body); err == nil && len(data) > 0 {
+			lockErr.Info, _ = backend.ParseLockInfo(data)
+		}
+
+		return errors.New(lockErr)
+	default:
+		return errors.New(UnexpectedResponseError{Method: client.LockMethod, Address: client.LockAddress, StatusCode: resp.StatusCode})
+	}
+}
+
+// Unlock releases the lock with the given lock info by sending it to the unlock address,
+// which defaults to the lock address.
+func (client *Client) Unlock(ctx context.Context, info *backend.LockInfo) error {
+	address := client.UnlockAddress
+	if address == "" {
+		address = client.LockAddress
+	}
+
+	resp, err := client.do(ctx, client.UnlockMethod, address, info.Marshal())
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	default:
+		return errors.New(UnexpectedResponseError{Method: client.UnlockMethod, Address: address, StatusCode: resp.StatusCode})
+	}
+}
+
+func (client *Client) do(ctx context.Context, method, address string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, address, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if client.Username != "" {
+		req.SetBasicAuth(client.Username, client.Password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return resp, nil
+}
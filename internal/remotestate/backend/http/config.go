@@ -0,0 +1,91 @@
+package http
+
+import (
+	"os"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	defaultUpdateMethod = "POST"
+	defaultLockMethod   = "LOCK"
+	defaultUnlockMethod = "UNLOCK"
+)
+
+type Config map[string]any
+
+// ParseHTTPConfig parses the given map into an HTTP config. Unset settings fall back to the `TF_HTTP_*` env vars
+// which are also read by the http backend of OpenTofu/Terraform.
+func (cfg Config) ParseHTTPConfig() (*RemoteStateConfigHTTP, error) {
+	var httpConfig RemoteStateConfigHTTP
+
+	if err := mapstructure.Decode(cfg, &httpConfig); err != nil {
+		return nil, errors.New(err)
+	}
+
+	for _, setting := range []struct {
+		value  *string
+		envVar string
+	}{
+		{&httpConfig.Address, "TF_HTTP_ADDRESS"},
+		{&httpConfig.UpdateMethod, "TF_HTTP_UPDATE_METHOD"},
+		{&httpConfig.LockAddress, "TF_HTTP_LOCK_ADDRESS"},
+		{&httpConfig.LockMethod, "TF_HTTP_LOCK_METHOD"},
+		{&httpConfig.UnlockAddress, "TF_HTTP_UNLOCK_ADDRESS"},
+		{&httpConfig.UnlockMethod, "TF_HTTP_UNLOCK_METHOD"},
+		{&httpConfig.Username, "TF_HTTP_USERNAME"},
+		{&httpConfig.Password, "TF_HTTP_PASSWORD"},
+	} {
+		if *setting.value == "" {
+			*setting.value = os.Getenv(setting.envVar)
+		}
+	}
+
+	if httpConfig.UpdateMethod == "" {
+		httpConfig.UpdateMethod = defaultUpdateMethod
+	}
+
+	if httpConfig.LockMethod == "" {
+		httpConfig.LockMethod = defaultLockMethod
+	}
+
+	if httpConfig.UnlockMethod == "" {
+		httpConfig.UnlockMethod = defaultUnlockMethod
+	}
+
+	return &httpConfig, nil
+}
+
+// HTTPConfig parses the given map into an HTTP config and validates this config.
+func (cfg Config) HTTPConfig() (*RemoteStateConfigHTTP, error) {
+	httpCfg, err := cfg.ParseHTTPConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return httpCfg, httpCfg.Validate()
+}
+
+// RemoteStateConfigHTTP is a representation of the configuration
+// options available for HTTP remote state.
+type RemoteStateConfigHTTP struct {
+	Address              string `mapstructure:"address"`
+	UpdateMethod         string `mapstructure:"update_method"`
+	LockAddress          string `mapstructure:"lock_address"`
+	LockMethod           string `mapstructure:"lock_method"`
+	UnlockAddress        string `mapstructure:"unlock_address"`
+	UnlockMethod         string `mapstructure:"unlock_method"`
+	Username             string `mapstructure:"username"`
+	Password             string `mapstructure:"password"`
+	SkipCertVerification bool   `mapstructure:"skip_cert_verification"`
+}
+
+// Validate validates the configuration for HTTP remote state.
+func (cfg *RemoteStateConfigHTTP) Validate() error {
+	if cfg.Address == "" {
+		return errors.New(MissingRequiredHTTPRemoteStateConfig("address"))
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package http
+
+import "fmt"
+
+type MissingRequiredHTTPRemoteStateConfig string
+
+func (configName MissingRequiredHTTPRemoteStateConfig) Error() string {
+	return "Missing required HTTP remote state configuration " + string(configName)
+}
+
+// UnexpectedResponseError is returned when the HTTP state server responds with an unexpected status code.
+type UnexpectedResponseError struct {
+	Method     string
+	Address    string
+	StatusCode int
+}
+
+func (err UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("unexpected HTTP response code %d for %s %s", err.StatusCode, err.Method, err.Address)
+}
@@ -0,0 +1,234 @@
+// Package local represents the local backend for interacting with state stored on the local filesystem.
+package local
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	BackendName = "local"
+
+	stateFilePerm = 0644
+)
+
+var _ backend.Backend = new(Backend)
+
+var versionControlNotSupportedError = backend.VersionControlNotSupportedError{BackendName: BackendName}
+
+type Backend struct {
+	*backend.CommonBackend
+}
+
+func NewBackend() *Backend {
+	return &Backend{
+		CommonBackend: backend.NewCommonBackend(BackendName),
+	}
+}
+
+// Bootstrap creates the directory of the state file if it doesn't already exist.
+func (backend *Backend) Bootstrap(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) error {
+	localCfg, err := Config(backendConfig).ParseLocalConfig()
+	if err != nil {
+		return err
+	}
+
+	stateDir := filepath.Dir(localCfg.StatePath(opts.WorkingDir))
+
+	l.Debugf("Ensuring local state directory %s exists", stateDir)
+
+	return util.EnsureDirectory(stateDir)
+}
+
+// IsVersionControlEnabled returns `backend.VersionControlNotSupportedError`, since local state files are not versioned.
+func (backend *Backend) IsVersionControlEnabled(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (bool, error) {
+	return false, errors.New(versionControlNotSupportedError)
+}
+
+// Migrate moves the state file located at src config to dst config. Relative paths are resolved against the working dir.
+func (backend *Backend) Migrate(ctx context.Context, l log.Logger, srcBackendConfig, dstBackendConfig backend.Config, opts *options.TerragruntOptions) error {
+	srcLocalCfg, err := Config(srcBackendConfig).ParseLocalConfig()
+	if err != nil {
+		return err
+	}
+
+	dstLocalCfg, err := Config(dstBackendConfig).ParseLocalConfig()
+	if err != nil {
+		return err
+	}
+
+	var (
+		srcPath = srcLocalCfg.StatePath(opts.WorkingDir)
+		dstPath = dstLocalCfg.StatePath(opts.WorkingDir)
+	)
+
+	if !util.FileExists(srcPath) {
+		l.Warnf("Local state file %s does not exist, nothing to migrate.", srcPath)
+
+		return nil
+	}
+
+	if util.FileExists(dstPath) {
+		return errors.Errorf("destination local state file %s already exists", dstPath)
+	}
+
+	l.Debugf("Moving local state file from %s to %s", srcPath, dstPath)
+
+	if err := util.EnsureDirectory(filepath.Dir(dstPath)); err != nil {
+		return err
+	}
+
+	// Copy and remove instead of renaming, since both files may be on different devices.
+	if err := util.CopyFile(srcPath, dstPath); err != nil {
+		return err
+	}
+
+	if err := os.Remove(srcPath); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// Delete deletes the state file specified in the given config.
+func (backend *Backend) Delete(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) error {
+	localCfg, err := Config(backendConfig).ParseLocalConfig()
+	if err != nil {
+		return err
+	}
+
+	statePath := localCfg.StatePath(opts.WorkingDir)
+
+	if !util.FileExists(statePath) {
+		l.Debugf("Local state file %s does not exist, nothing to delete.", statePath)
+
+		return nil
+	}
+
+	prompt := fmt.Sprintf("Local state file %s will be deleted. Do you want to continue?", statePath)
+	if yes, err := shell.PromptUserForYesNo(ctx, l, prompt, opts); err != nil {
+		return err
+	} else if yes {
+		l.Debugf("Deleting local state file %s", statePath)
+
+		if err := os.Remove(statePath); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
+// ReadState returns the content of the state file specified in the given config, or nil if the file does not exist.
+func (backend *Backend) ReadState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) ([]byte, error) {
+	localCfg, err := Config(backendConfig).ParseLocalConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	statePath := localCfg.StatePath(opts.WorkingDir)
+
+	if !util.FileExists(statePath) {
+		l.Debugf("Local state file %s does not exist.", statePath)
+
+		return nil, nil
+	}
+
+	state, err := os.ReadFile(statePath)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return state, nil
+}
+
+// WriteState writes the given state to the state file specified in the given config.
+func (backend *Backend) WriteState(ctx context.Context, l log.Logger, backendConfig backend.Config, state []byte, opts *options.TerragruntOptions) error {
+	localCfg, err := Config(backendConfig).ParseLocalConfig()
+	if err != nil {
+		return err
+	}
+
+	statePath := localCfg.StatePath(opts.WorkingDir)
+
+	l.Debugf("Writing local state file %s", statePath)
+
+	if err := util.EnsureDirectory(filepath.Dir(statePath)); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(statePath, state, stateFilePerm); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// LockState creates the lock info file next to the state file specified in the given config. The lock info file has the
+// same name as the one created by OpenTofu/Terraform, so concurrent migrations of the same state exclude each other.
+func (backend *Backend) LockState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (backend.UnlockFunc, error) {
+	localCfg, err := Config(backendConfig).ParseLocalConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return lockStateFile(l, localCfg.StatePath(opts.WorkingDir))
+}
+
+// lockInfoPath returns the path of the lock info file OpenTofu/Terraform creates for the given state file.
+func lockInfoPath(statePath string) string {
+	return filepath.Join(filepath.Dir(statePath), "."+filepath.Base(statePath)+".lock.info")
+}
+
+// lockStateFile exclusively creates the lock info file for the given state file.
+func lockStateFile(l log.Logger, statePath string) (backend.UnlockFunc, error) {
+	lockPath := lockInfoPath(statePath)
+
+	if err := util.EnsureDirectory(filepath.Dir(lockPath)); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, stateFilePerm)
+	if err != nil {
+		if !os.IsExist(err) {
+			return nil, errors.New(err)
+		}
+
+		lockErr := backend.StateLockedError{Path: statePath}
+
+		if data, err := os.ReadFile(lockPath); err == nil {
+			lockErr.Info, _ = backend.ParseLockInfo(data)
+		}
+
+		return nil, errors.New(lockErr)
+	}
+
+	defer file.Close() //nolint:errcheck
+
+	l.Debugf("Locking local state file %s", statePath)
+
+	if _, err := file.Write(backend.NewLockInfo(statePath).Marshal()); err != nil {
+		os.Remove(lockPath) //nolint:errcheck
+
+		return nil, errors.New(err)
+	}
+
+	return func(ctx context.Context) error {
+		l.Debugf("Unlocking local state file %s", statePath)
+
+		if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+			return errors.New(err)
+		}
+
+		return nil
+	}, nil
+}
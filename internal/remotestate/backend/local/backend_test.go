@@ -0,0 +1,185 @@
+package local_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/local"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_WithAbsPath(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		cfg      local.Config
+		expected string
+	}{
+		{
+			"default-path",
+			local.Config{},
+			filepath.Join("/units/app", "terraform.tfstate"),
+		},
+		{
+			"relative-path",
+			local.Config{"path": "state/app.tfstate"},
+			filepath.Join("/units/app", "state", "app.tfstate"),
+		},
+		{
+			"absolute-path",
+			local.Config{"path": "/var/state/app.tfstate"},
+			filepath.Join("/var/state", "app.tfstate"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := tc.cfg.WithAbsPath("/units/app")
+			assert.Equal(t, tc.expected, actual["path"])
+		})
+	}
+}
+
+func TestBackend_Bootstrap(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+
+	err = local.NewBackend().Bootstrap(t.Context(), logger.CreateLogger(), backend.Config{"path": "state/terraform.tfstate"}, opts)
+	require.NoError(t, err)
+	assert.DirExists(t, filepath.Join(workingDir, "state"))
+}
+
+func TestBackend_IsVersionControlEnabled(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	enabled, err := local.NewBackend().IsVersionControlEnabled(t.Context(), logger.CreateLogger(), backend.Config{}, opts)
+	require.ErrorAs(t, err, new(backend.VersionControlNotSupportedError))
+	assert.False(t, enabled)
+}
+
+func TestBackend_Migrate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		l          = logger.CreateLogger()
+		workingDir = t.TempDir()
+		srcPath    = filepath.Join(workingDir, "terraform.tfstate")
+		dstPath    = filepath.Join(workingDir, "new", "terraform.tfstate")
+	)
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+
+	require.NoError(t, os.WriteFile(srcPath, []byte(`{"serial": 1}`), 0644))
+
+	err = local.NewBackend().Migrate(t.Context(), l, backend.Config{}, backend.Config{"path": dstPath}, opts)
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, srcPath)
+
+	data, err := os.ReadFile(dstPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"serial": 1}`, string(data))
+
+	require.NoError(t, os.WriteFile(srcPath, []byte(`{"serial": 2}`), 0644))
+
+	err = local.NewBackend().Migrate(t.Context(), l, backend.Config{}, backend.Config{"path": dstPath}, opts)
+	require.ErrorContains(t, err, "already exists")
+	assert.FileExists(t, srcPath)
+}
+
+func TestBackend_Delete(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	statePath := filepath.Join(workingDir, "terraform.tfstate")
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+
+	require.NoError(t, os.WriteFile(statePath, []byte("{}"), 0644))
+
+	require.NoError(t, local.NewBackend().Delete(t.Context(), logger.CreateLogger(), backend.Config{}, opts))
+	assert.NoFileExists(t, statePath)
+
+	// Deleting a missing state file is a no-op.
+	require.NoError(t, local.NewBackend().Delete(t.Context(), logger.CreateLogger(), backend.Config{}, opts))
+}
+
+func TestBackend_LockState(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+
+	var (
+		l        = logger.CreateLogger()
+		cfg      = backend.Config{"path": "state/terraform.tfstate"}
+		lockPath = filepath.Join(workingDir, "state", ".terraform.tfstate.lock.info")
+	)
+
+	unlock, err := local.NewBackend().LockState(t.Context(), l, cfg, opts)
+	require.NoError(t, err)
+	assert.FileExists(t, lockPath)
+
+	_, err = local.NewBackend().LockState(t.Context(), l, cfg, opts)
+
+	var lockErr backend.StateLockedError
+	require.ErrorAs(t, err, &lockErr)
+	require.NotNil(t, lockErr.Info)
+	assert.Equal(t, backend.LockOperation, lockErr.Info.Operation)
+
+	require.NoError(t, unlock(t.Context()))
+	assert.NoFileExists(t, lockPath)
+}
+
+func TestBackend_ReadWriteState(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+
+	var (
+		l   = logger.CreateLogger()
+		cfg = backend.Config{"path": "state/terraform.tfstate"}
+	)
+
+	state, err := local.NewBackend().ReadState(t.Context(), l, cfg, opts)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	require.NoError(t, local.NewBackend().WriteState(t.Context(), l, cfg, []byte(`{"serial": 1}`), opts))
+
+	state, err = local.NewBackend().ReadState(t.Context(), l, cfg, opts)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"serial": 1}`, string(state))
+}
@@ -0,0 +1,67 @@
+package local
+
+import (
+	"path/filepath"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	configPathKey = "path"
+
+	defaultStatePath = "terraform.tfstate"
+)
+
+type Config map[string]any
+
+// ParseLocalConfig parses the given map into a local config.
+func (cfg Config) ParseLocalConfig() (*RemoteStateConfigLocal, error) {
+	var localConfig RemoteStateConfigLocal
+
+	if err := mapstructure.Decode(cfg, &localConfig); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return &localConfig, nil
+}
+
+// WithAbsPath returns a copy of the config with the state file path resolved against the given working dir.
+func (cfg Config) WithAbsPath(workingDir string) Config {
+	localConfig, err := cfg.ParseLocalConfig()
+	if err != nil {
+		return cfg
+	}
+
+	absConfig := make(Config, len(cfg)+1)
+
+	for key, val := range cfg {
+		absConfig[key] = val
+	}
+
+	absConfig[configPathKey] = localConfig.StatePath(workingDir)
+
+	return absConfig
+}
+
+// RemoteStateConfigLocal is a representation of the configuration
+// options available for local state.
+type RemoteStateConfigLocal struct {
+	Path         string `mapstructure:"path"`
+	WorkspaceDir string `mapstructure:"workspace_dir"`
+}
+
+// StatePath returns the path to the state file of the default workspace. Relative paths are resolved
+// against the given working dir, the same way OpenTofu/Terraform resolves them against its working dir.
+func (cfg *RemoteStateConfigLocal) StatePath(workingDir string) string {
+	path := cfg.Path
+	if path == "" {
+		path = defaultStatePath
+	}
+
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	return filepath.Join(workingDir, path)
+}
@@ -0,0 +1,84 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gruntwork-io/go-commons/version"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// LockOperation is the operation recorded in the lock info of locks acquired by Terragrunt.
+const LockOperation = "terragrunt backend migrate"
+
+// UnlockFunc releases a lock acquired by `Backend.LockState`.
+type UnlockFunc func(ctx context.Context) error
+
+// NoopUnlock is returned by backends that do not lock the state.
+func NoopUnlock(ctx context.Context) error {
+	return nil
+}
+
+// LockInfo is the metadata stored alongside a state lock. It has the same format as the OpenTofu/Terraform lock info,
+// so the lock holder can be identified by `tofu force-unlock` and the other OpenTofu/Terraform tooling.
+type LockInfo struct {
+	Created   time.Time `json:"Created"`
+	ID        string    `json:"ID"`
+	Operation string    `json:"Operation"`
+	Info      string    `json:"Info"`
+	Who       string    `json:"Who"`
+	Version   string    `json:"Version"`
+	Path      string    `json:"Path"`
+}
+
+// NewLockInfo returns a lock info for the state at the given path.
+func NewLockInfo(path string) *LockInfo {
+	return &LockInfo{
+		ID:        uuid.NewString(),
+		Operation: LockOperation,
+		Who:       lockOwner(),
+		Version:   version.GetVersion(),
+		Created:   time.Now().UTC(),
+		Path:      path,
+	}
+}
+
+// Marshal returns the JSON representation of the lock info.
+func (info *LockInfo) Marshal() []byte {
+	data, _ := json.Marshal(info) //nolint:errchkjson
+
+	return data
+}
+
+// String implements `fmt.Stringer` interface.
+func (info *LockInfo) String() string {
+	return fmt.Sprintf("ID %s, operation %q, created by %s at %s", info.ID, info.Operation, info.Who, info.Created.Format(time.RFC3339))
+}
+
+// ParseLockInfo parses the lock info written by OpenTofu/Terraform or Terragrunt.
+func ParseLockInfo(data []byte) (*LockInfo, error) {
+	info := new(LockInfo)
+
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return info, nil
+}
+
+func lockOwner() string {
+	var name string
+
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+
+	host, _ := os.Hostname()
+
+	return fmt.Sprintf("%s@%s", name, host)
+}
@@ -271,3 +271,108 @@ func (backend *Backend) DeleteBucket(ctx context.Context, l log.Logger, backendC
 func (backend *Backend) GetTFInitArgs(config backend.Config) map[string]any {
 	return Config(config).GetTFInitArgs()
 }
+
+// ReadState returns the content of the state object specified in the given config, or nil if the object does not exist.
+func (backend *Backend) ReadState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) ([]byte, error) {
+	extS3Cfg, err := Config(backendConfig).ExtendedS3Config(l)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(l, extS3Cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetS3Object(ctx, extS3Cfg.RemoteStateConfigS3.Bucket, extS3Cfg.RemoteStateConfigS3.Key)
+}
+
+// StateETag returns the ETag of the state object specified in the given config.
+func (backend *Backend) StateETag(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (string, error) {
+	extS3Cfg, err := Config(backendConfig).ExtendedS3Config(l)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := NewClient(l, extS3Cfg, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return client.GetS3ObjectETag(ctx, extS3Cfg.RemoteStateConfigS3.Bucket, extS3Cfg.RemoteStateConfigS3.Key)
+}
+
+// WriteState stores the given state in the object specified in the given config. If a DynamoDB lock table is
+// configured, the state digest is updated as well, so OpenTofu/Terraform accepts the new state.
+func (backend *Backend) WriteState(ctx context.Context, l log.Logger, backendConfig backend.Config, state []byte, opts *options.TerragruntOptions) error {
+	extS3Cfg, err := Config(backendConfig).ExtendedS3Config(l)
+	if err != nil {
+		return err
+	}
+
+	var (
+		bucketName = extS3Cfg.RemoteStateConfigS3.Bucket
+		bucketKey  = extS3Cfg.RemoteStateConfigS3.Key
+		tableName  = extS3Cfg.RemoteStateConfigS3.GetLockTableName()
+	)
+
+	client, err := NewClient(l, extS3Cfg, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := client.PutS3Object(ctx, l, bucketName, bucketKey, state); err != nil {
+		return err
+	}
+
+	if tableName != "" {
+		return client.PutTableItemDigest(ctx, l, tableName, path.Join(bucketName, bucketKey+stateIDSuffix), state)
+	}
+
+	return nil
+}
+
+// LockState locks the state specified in the given config using the DynamoDB lock table, if one is configured.
+func (backend *Backend) LockState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (backend.UnlockFunc, error) {
+	extS3Cfg, err := Config(backendConfig).ExtendedS3Config(l)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(l, extS3Cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.LockState(ctx, l, extS3Cfg.RemoteStateConfigS3.Bucket, extS3Cfg.RemoteStateConfigS3.Key)
+}
+
+// ListStateVersions returns the versions of the state object specified in the given config, from the newest to the oldest.
+func (backend *Backend) ListStateVersions(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (backend.StateVersions, error) {
+	extS3Cfg, err := Config(backendConfig).ExtendedS3Config(l)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(l, extS3Cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.ListS3ObjectVersions(ctx, extS3Cfg.RemoteStateConfigS3.Bucket, extS3Cfg.RemoteStateConfigS3.Key)
+}
+
+// ReadStateVersion returns the content of the given version of the state object specified in the given config.
+func (backend *Backend) ReadStateVersion(ctx context.Context, l log.Logger, backendConfig backend.Config, versionID string, opts *options.TerragruntOptions) ([]byte, error) {
+	extS3Cfg, err := Config(backendConfig).ExtendedS3Config(l)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(l, extS3Cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetS3ObjectVersion(ctx, extS3Cfg.RemoteStateConfigS3.Bucket, extS3Cfg.RemoteStateConfigS3.Key, versionID)
+}
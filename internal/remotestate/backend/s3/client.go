@@ -1,8 +1,12 @@
 package s3
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
 	"fmt"
+	"io"
 	"path"
 	"reflect"
 	"slices"
@@ -47,6 +51,11 @@ const (
 	// OpenTofu/Terraform requires the DynamoDB table to have a primary key with this name
 	AttrLockID = "LockID"
 
+	// attrInfo and attrDigest are the names of the lock info and state digest attributes of the lock table items,
+	// as written by OpenTofu/Terraform.
+	attrInfo   = "Info"
+	attrDigest = "Digest"
+
 	// stateIDSuffix is last saved serial in tablestore with this suffix for consistency checks.
 	stateIDSuffix = "-md5"
 
@@ -1903,3 +1912,224 @@ func (client *Client) CreateTableItem(ctx context.Context, l log.Logger, tableNa
 
 	return nil
 }
+
+// GetS3Object returns the content of the S3 object at the specified key, or nil if the object does not exist.
+func (client *Client) GetS3Object(ctx context.Context, bucketName, key string) ([]byte, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	}
+
+	res, err := client.GetObjectWithContext(ctx, input)
+	if err != nil {
+		var awsErr awserr.Error
+		if ok := errors.As(err, &awsErr); ok && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, nil
+		}
+
+		return nil, errors.Errorf("failed to get S3 bucket %s object %s: %w", bucketName, key, err)
+	}
+
+	defer res.Body.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return data, nil
+}
+
+// GetS3ObjectETag returns the ETag of the S3 object at the specified key, or an empty string if the object does not exist.
+func (client *Client) GetS3ObjectETag(ctx context.Context, bucketName, key string) (string, error) {
+	input := &s3.HeadObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	}
+
+	res, err := client.HeadObjectWithContext(ctx, input)
+	if err != nil {
+		var awsErr awserr.Error
+		if ok := errors.As(err, &awsErr); ok && awsErr.Code() == "NotFound" {
+			return "", nil
+		}
+
+		return "", errors.Errorf("failed to head S3 bucket %s object %s: %w", bucketName, key, err)
+	}
+
+	return aws.StringValue(res.ETag), nil
+}
+
+// PutS3Object stores the given data in the S3 object at the specified key. The object is encrypted on the server
+// side if the `encrypt` setting is enabled, the same way OpenTofu/Terraform does it.
+func (client *Client) PutS3Object(ctx context.Context, l log.Logger, bucketName, key string, data []byte) error {
+	l.Debugf("Putting S3 bucket %s object %s", bucketName, key)
+
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(bucketName),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	}
+
+	if client.RemoteStateConfigS3.Encrypt {
+		input.ServerSideEncryption = aws.String(s3.ServerSideEncryptionAes256)
+	}
+
+	if _, err := client.PutObjectWithContext(ctx, input); err != nil {
+		return errors.Errorf("failed to put S3 bucket %s object %s: %w", bucketName, key, err)
+	}
+
+	return nil
+}
+
+// PutTableItemDigest stores the MD5 digest of the state in the DynamoDB table item with the specified key.
+// OpenTofu/Terraform compares the digest with the state it reads from S3 to detect stale reads.
+func (client *Client) PutTableItemDigest(ctx context.Context, l log.Logger, tableName, key string, state []byte) error {
+	l.Debugf("Putting DynamoDB table %s item %s digest", tableName, key)
+
+	digest := md5.Sum(state) //nolint:gosec
+
+	input := &dynamodb.PutItemInput{
+		TableName: aws.String(tableName),
+		Item: map[string]*dynamodb.AttributeValue{
+			AttrLockID: {
+				S: aws.String(key),
+			},
+			attrDigest: {
+				S: aws.String(hex.EncodeToString(digest[:])),
+			},
+		},
+	}
+
+	if _, err := client.PutItemWithContext(ctx, input); err != nil {
+		return errors.Errorf("failed to put item %s digest of table %s: %w", key, tableName, err)
+	}
+
+	return nil
+}
+
+// LockTableItem creates the DynamoDB table lock item with the specified key, unless it already exists.
+// Returns `backend.StateLockedError` if the item already exists.
+func (client *Client) LockTableItem(ctx context.Context, l log.Logger, tableName, key string, info *backend.LockInfo) error {
+	l.Debugf("Locking DynamoDB table %s item %s", tableName, key)
+
+	input := &dynamodb.PutItemInput{
+		TableName: aws.String(tableName),
+		Item: map[string]*dynamodb.AttributeValue{
+			AttrLockID: {
+				S: aws.String(key),
+			},
+			attrInfo: {
+				S: aws.String(string(info.Marshal())),
+			},
+		},
+		ConditionExpression: aws.String("attribute_not_exists(" + AttrLockID + ")"),
+	}
+
+	if _, err := client.PutItemWithContext(ctx, input); err != nil {
+		var awsErr awserr.Error
+		if ok := errors.As(err, &awsErr); !ok || awsErr.Code() != dynamodb.ErrCodeConditionalCheckFailedException {
+			return errors.Errorf("failed to lock item %s of table %s: %w", key, tableName, err)
+		}
+
+		lockErr := backend.StateLockedError{Path: key}
+
+		if res, err := client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+			TableName: aws.String(tableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				AttrLockID: {
+					S: aws.String(key),
+				},
+			},
+		}); err == nil {
+			if attr, ok := res.Item[attrInfo]; ok && attr.S != nil {
+				lockErr.Info, _ = backend.ParseLockInfo([]byte(*attr.S))
+			}
+		}
+
+		return errors.New(lockErr)
+	}
+
+	return nil
+}
+
+// LockState locks the state stored at the specified key in the DynamoDB lock table, if one is configured,
+// and returns the function that releases the lock.
+func (client *Client) LockState(ctx context.Context, l log.Logger, bucketName, key string) (backend.UnlockFunc, error) {
+	tableName := client.RemoteStateConfigS3.GetLockTableName()
+
+	if tableName == "" {
+		l.Debugf("No DynamoDB lock table configured for S3 bucket %s object %s, skipping locking", bucketName, key)
+
+		return backend.NoopUnlock, nil
+	}
+
+	lockKey := path.Join(bucketName, key)
+
+	if err := client.LockTableItem(ctx, l, tableName, lockKey, backend.NewLockInfo(lockKey)); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		return client.DeleteTableItem(ctx, l, tableName, lockKey)
+	}, nil
+}
+
+// ListS3ObjectVersions returns the versions of the S3 object at the specified key, from the newest to the oldest.
+// Delete markers are skipped.
+func (client *Client) ListS3ObjectVersions(ctx context.Context, bucketName, key string) (backend.StateVersions, error) {
+	var versions backend.StateVersions
+
+	input := &s3.ListObjectVersionsInput{
+		Bucket: aws.String(bucketName),
+		Prefix: aws.String(key),
+	}
+
+	err := client.ListObjectVersionsPagesWithContext(ctx, input, func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
+		for _, version := range page.Versions {
+			if aws.StringValue(version.Key) != key {
+				continue
+			}
+
+			versions = append(versions, &backend.StateVersion{
+				ID:           aws.StringValue(version.VersionId),
+				LastModified: aws.TimeValue(version.LastModified),
+				Size:         aws.Int64Value(version.Size),
+				IsLatest:     aws.BoolValue(version.IsLatest),
+			})
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to list versions of S3 bucket %s object %s: %w", bucketName, key, err)
+	}
+
+	versions.Sort()
+
+	return versions, nil
+}
+
+// GetS3ObjectVersion returns the content of the given version of the S3 object at the specified key.
+func (client *Client) GetS3ObjectVersion(ctx context.Context, bucketName, key, versionID string) ([]byte, error) {
+	input := &s3.GetObjectInput{
+		Bucket:    aws.String(bucketName),
+		Key:       aws.String(key),
+		VersionId: aws.String(versionID),
+	}
+
+	res, err := client.GetObjectWithContext(ctx, input)
+	if err != nil {
+		return nil, errors.Errorf("failed to get S3 bucket %s object %s version %s: %w", bucketName, key, versionID, err)
+	}
+
+	defer res.Body.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return data, nil
+}
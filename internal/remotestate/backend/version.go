@@ -0,0 +1,82 @@
+package backend
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// versionTimestampLayouts are the layouts of the timestamps accepted by `StateVersions.Find`, from the most to the least precise.
+var versionTimestampLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// StateVersion is a prior version of the state object kept by the versioning of the backend storage.
+type StateVersion struct {
+	LastModified time.Time
+	ID           string
+	Size         int64
+	IsLatest     bool
+}
+
+type StateVersions []*StateVersion
+
+// Sort sorts the versions from the newest to the oldest.
+func (versions StateVersions) Sort() {
+	slices.SortStableFunc(versions, func(a, b *StateVersion) int {
+		return b.LastModified.Compare(a.LastModified)
+	})
+}
+
+// Latest returns the current version of the state, or nil if there are no versions.
+func (versions StateVersions) Latest() *StateVersion {
+	for _, version := range versions {
+		if version.IsLatest {
+			return version
+		}
+	}
+
+	return nil
+}
+
+// Find returns the version with the given ID. If there is no such version and the query is a timestamp,
+// it returns the newest version that was current at that point in time.
+func (versions StateVersions) Find(query string) (*StateVersion, error) {
+	for _, version := range versions {
+		if version.ID == query {
+			return version, nil
+		}
+	}
+
+	for _, layout := range versionTimestampLayouts {
+		timestamp, err := time.Parse(layout, query)
+		if err != nil {
+			continue
+		}
+
+		var found *StateVersion
+
+		for _, version := range versions {
+			if version.LastModified.After(timestamp) {
+				continue
+			}
+
+			if found == nil || version.LastModified.After(found.LastModified) {
+				found = version
+			}
+		}
+
+		if found == nil {
+			return nil, errors.New(StateVersionNotFoundError{Query: query})
+		}
+
+		return found, nil
+	}
+
+	return nil, errors.New(StateVersionNotFoundError{Query: query})
+}
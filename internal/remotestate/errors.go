@@ -0,0 +1,24 @@
+package remotestate
+
+import "fmt"
+
+// DestinationStateExistsError is returned when the state is migrated to a location that already contains state.
+type DestinationStateExistsError struct {
+	BackendName string
+	Path        string
+}
+
+func (err DestinationStateExistsError) Error() string {
+	return fmt.Sprintf("destination %s state of %s already exists", err.BackendName, err.Path)
+}
+
+// StateChecksumMismatchError is returned when the migrated state differs from the source state.
+type StateChecksumMismatchError struct {
+	BackendName string
+	Expected    string
+	Actual      string
+}
+
+func (err StateChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum of the migrated %s state %s does not match the checksum of the source state %s", err.BackendName, err.Actual, err.Expected)
+}
@@ -3,21 +3,45 @@ package remotestate
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/azurerm"
 	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/gcs"
+	httpbackend "github.com/gruntwork-io/terragrunt/internal/remotestate/backend/http"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/local"
 	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/s3"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/tf"
 )
 
+const (
+	// backupTimestampLayout is the layout of the timestamps in the names of state backup files.
+	backupTimestampLayout = "20060102T150405Z"
+
+	backupFilePerm = 0600
+)
+
 var backends = backend.Backends{
 	s3.NewBackend(),
 	gcs.NewBackend(),
+	azurerm.NewBackend(),
+	local.NewBackend(),
+	httpbackend.NewBackend(),
+}
+
+// RegisterBackend makes the given backend available to `remote_state` blocks with the same `backend` name,
+// replacing the built-in implementation if there is one. It must be called before any remote state is created.
+func RegisterBackend(backend backend.Backend) {
+	backends.Register(backend)
 }
 
 // RemoteState is the configuration for Terraform remote state.
@@ -74,23 +98,134 @@ func (remote *RemoteState) Bootstrap(ctx context.Context, l log.Logger, opts *op
 }
 
 // Migrate determines where the remote state resources exist for source backend config and migrate them to dest backend config.
+// If both units use the same backend, the state is moved by the backend itself. Otherwise, the state is read through the
+// source backend and written through the destination backend, while the states of both units are locked.
 func (remote *RemoteState) Migrate(ctx context.Context, l log.Logger, opts, dstOpts *options.TerragruntOptions, dstRemote *RemoteState) error {
 	l.Debugf("Migrate remote state for the %s backend", remote.BackendName)
 
+	if opts.BackendMigrateDryRun {
+		return remote.printMigration(ctx, l, opts, dstOpts, dstRemote)
+	}
+
 	if remote.BackendName == dstRemote.BackendName {
-		return remote.backend.Migrate(ctx, l, remote.BackendConfig, dstRemote.BackendConfig, opts)
+		srcConfig, dstConfig := remote.BackendConfig, dstRemote.BackendConfig
+
+		// Relative local state paths must be resolved against the directory of their own unit.
+		if remote.BackendName == local.BackendName {
+			srcConfig = backend.Config(local.Config(srcConfig).WithAbsPath(opts.WorkingDir))
+			dstConfig = backend.Config(local.Config(dstConfig).WithAbsPath(dstOpts.WorkingDir))
+		}
+
+		return remote.backend.Migrate(ctx, l, srcConfig, dstConfig, opts)
+	}
+
+	return remote.migrateState(ctx, l, opts, dstOpts, dstRemote)
+}
+
+// ReadOutputs returns the outputs of the state in the same JSON format as `tofu output -json`, which is the format
+// outputs are stored in the state, reading the state directly through the backend without calling OpenTofu/Terraform.
+// A missing state has no outputs. Returns `backend.StateAccessNotImplementedError` if the backend cannot read the
+// state itself.
+func (remote *RemoteState) ReadOutputs(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) ([]byte, error) {
+	l.Debugf("Reading outputs from the %s backend state", remote.BackendName)
+
+	state, err := remote.backend.ReadState(ctx, l, remote.BackendConfig, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(state) == 0 {
+		return []byte("{}"), nil
+	}
+
+	var parsed struct {
+		Outputs json.RawMessage `json:"outputs"`
+	}
+
+	if err := json.Unmarshal(state, &parsed); err != nil {
+		return nil, errors.Errorf("failed to parse %s state: %w", remote.BackendName, err)
+	}
+
+	if len(parsed.Outputs) == 0 || string(parsed.Outputs) == "null" {
+		return []byte("{}"), nil
+	}
+
+	return parsed.Outputs, nil
+}
+
+// StateETag returns the entity tag of the state, which changes whenever the state is written, or an empty string if
+// there is no state. Returns `backend.StateAccessNotImplementedError` if the backend cannot access the state itself.
+func (remote *RemoteState) StateETag(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) (string, error) {
+	return remote.backend.StateETag(ctx, l, remote.BackendConfig, opts)
+}
+
+// ListStateVersions returns the versions of the state kept by the versioning of the backend storage, from the newest to the oldest.
+func (remote *RemoteState) ListStateVersions(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) (backend.StateVersions, error) {
+	l.Debugf("Listing state versions for the %s backend", remote.BackendName)
+
+	return remote.backend.ListStateVersions(ctx, l, remote.BackendConfig, opts)
+}
+
+// Backup writes the given state version, or the current state if the version is nil, to a file in the given directory
+// and returns the path of the file. Returns an empty path if there is no state.
+func (remote *RemoteState) Backup(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, version *backend.StateVersion, dir string) (string, error) {
+	var (
+		state    []byte
+		filename string
+		err      error
+	)
+
+	if version != nil {
+		l.Debugf("Backing up state version %s for the %s backend", version.ID, remote.BackendName)
+
+		state, err = remote.backend.ReadStateVersion(ctx, l, remote.BackendConfig, version.ID, opts)
+		filename = fmt.Sprintf("%s_%s.tfstate", version.LastModified.UTC().Format(backupTimestampLayout), version.ID)
+	} else {
+		l.Debugf("Backing up current state for the %s backend", remote.BackendName)
+
+		state, err = remote.readState(ctx, l, opts)
+		filename = time.Now().UTC().Format(backupTimestampLayout) + ".tfstate"
 	}
 
-	stateFile, err := remote.pullState(ctx, l, opts)
+	if err != nil || len(state) == 0 {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", errors.New(err)
+	}
+
+	path := filepath.Join(dir, filename)
+
+	if err := os.WriteFile(path, state, backupFilePerm); err != nil {
+		return "", errors.New(err)
+	}
+
+	return path, nil
+}
+
+// Restore makes the given state version the current state. The restored state is written as a new version,
+// so the overwritten state can be restored again later.
+func (remote *RemoteState) Restore(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, version *backend.StateVersion) error {
+	l.Debugf("Restoring state version %s for the %s backend", version.ID, remote.BackendName)
+
+	state, err := remote.backend.ReadStateVersion(ctx, l, remote.BackendConfig, version.ID, opts)
 	if err != nil {
 		return err
 	}
 
-	defer func() {
-		os.Remove(stateFile) // nolint: errcheck
-	}()
+	unlock, err := remote.backend.LockState(ctx, l, remote.BackendConfig, opts)
+	if err != nil {
+		return err
+	}
+
+	defer remote.unlockState(ctx, l, unlock)
+
+	if err := remote.writeState(ctx, l, opts, state); err != nil {
+		return err
+	}
 
-	return dstRemote.pushState(ctx, l, dstOpts, stateFile)
+	return remote.verifyState(ctx, l, opts, stateChecksum(state))
 }
 
 // NeedsBootstrap returns true if remote state needs to be configured. This will be the case when:
@@ -145,38 +280,169 @@ func (remote *RemoteState) GenerateOpenTofuCode(l log.Logger, opts *options.Terr
 	return remote.Config.GenerateOpenTofuCode(l, opts, backendConfig)
 }
 
-func (remote *RemoteState) pullState(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) (string, error) {
+// migrateState copies the state from the source to the destination backend and verifies the checksum of the copied state.
+// The source state is kept, so it can be removed with `backend delete` once the migration is confirmed.
+func (remote *RemoteState) migrateState(ctx context.Context, l log.Logger, opts, dstOpts *options.TerragruntOptions, dstRemote *RemoteState) error {
+	unlockSrc, err := remote.backend.LockState(ctx, l, remote.BackendConfig, opts)
+	if err != nil {
+		return err
+	}
+
+	defer remote.unlockState(ctx, l, unlockSrc)
+
+	unlockDst, err := dstRemote.backend.LockState(ctx, l, dstRemote.BackendConfig, dstOpts)
+	if err != nil {
+		return err
+	}
+
+	defer dstRemote.unlockState(ctx, l, unlockDst)
+
+	state, err := remote.readState(ctx, l, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(state) == 0 {
+		l.Warnf("No %s state found for %s, nothing to migrate", remote.BackendName, opts.WorkingDir)
+
+		return nil
+	}
+
+	if dstState, err := dstRemote.backend.ReadState(ctx, l, dstRemote.BackendConfig, dstOpts); err != nil {
+		if !errors.As(err, new(backend.StateAccessNotImplementedError)) {
+			return err
+		}
+	} else if len(dstState) != 0 {
+		return errors.New(DestinationStateExistsError{BackendName: dstRemote.BackendName, Path: dstOpts.WorkingDir})
+	}
+
+	checksum := stateChecksum(state)
+
+	l.Infof("Migrating state from %s backend to %s backend (%d bytes, SHA256 %s)", remote.BackendName, dstRemote.BackendName, len(state), checksum)
+
+	if err := dstRemote.writeState(ctx, l, dstOpts, state); err != nil {
+		return err
+	}
+
+	return dstRemote.verifyState(ctx, l, dstOpts, checksum)
+}
+
+// printMigration prints what would be migrated by `migrateState` without locking or modifying any state.
+func (remote *RemoteState) printMigration(ctx context.Context, l log.Logger, opts, dstOpts *options.TerragruntOptions, dstRemote *RemoteState) error {
+	state, err := remote.readState(ctx, l, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(state) == 0 {
+		if _, err := fmt.Fprintf(opts.Writer, "No %s state found for %s, nothing to migrate\n", remote.BackendName, opts.WorkingDir); err != nil {
+			return errors.New(err)
+		}
+
+		return nil
+	}
+
+	msg := fmt.Sprintf("Would migrate %s state of %s to %s state of %s (%d bytes, SHA256 %s)",
+		remote.BackendName, opts.WorkingDir, dstRemote.BackendName, dstOpts.WorkingDir, len(state), stateChecksum(state))
+
+	if dstState, err := dstRemote.backend.ReadState(ctx, l, dstRemote.BackendConfig, dstOpts); err == nil && len(dstState) != 0 {
+		msg += ", but the destination state already exists"
+	}
+
+	if _, err := fmt.Fprintln(opts.Writer, msg); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// readState reads the state through the backend, falling back to `tofu state pull` if the backend cannot read it itself.
+func (remote *RemoteState) readState(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) ([]byte, error) {
+	state, err := remote.backend.ReadState(ctx, l, remote.BackendConfig, opts)
+	if err == nil || !errors.As(err, new(backend.StateAccessNotImplementedError)) {
+		return state, err
+	}
+
+	return remote.pullState(ctx, l, opts)
+}
+
+// writeState writes the state through the backend, falling back to `tofu state push` if the backend cannot write it itself.
+func (remote *RemoteState) writeState(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, state []byte) error {
+	err := remote.backend.WriteState(ctx, l, remote.BackendConfig, state, opts)
+	if err == nil || !errors.As(err, new(backend.StateAccessNotImplementedError)) {
+		return err
+	}
+
+	return remote.pushState(ctx, l, opts, state)
+}
+
+// verifyState reads the state back through the backend and compares its checksum with the given one.
+func (remote *RemoteState) verifyState(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, checksum string) error {
+	state, err := remote.backend.ReadState(ctx, l, remote.BackendConfig, opts)
+	if err != nil {
+		if errors.As(err, new(backend.StateAccessNotImplementedError)) {
+			l.Debugf("Skipping checksum verification of migrated state, reading state is not supported by the %s backend", remote.BackendName)
+
+			return nil
+		}
+
+		return err
+	}
+
+	if actual := stateChecksum(state); actual != checksum {
+		return errors.New(StateChecksumMismatchError{BackendName: remote.BackendName, Expected: checksum, Actual: actual})
+	}
+
+	l.Debugf("Verified checksum %s of migrated state", checksum)
+
+	return nil
+}
+
+func (remote *RemoteState) unlockState(ctx context.Context, l log.Logger, unlock backend.UnlockFunc) {
+	if err := unlock(ctx); err != nil {
+		l.Warnf("Failed to unlock %s state: %v", remote.BackendName, err)
+	}
+}
+
+func (remote *RemoteState) pullState(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) ([]byte, error) {
 	l.Debugf("Pulling state from %s backend", remote.BackendName)
 
 	args := []string{tf.CommandNameState, tf.CommandNamePull}
 
 	output, err := tf.RunCommandWithOutput(ctx, l, opts, args...)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
+	return output.Stdout.Bytes(), nil
+}
+
+func (remote *RemoteState) pushState(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, state []byte) error {
+	l.Debugf("Pushing state to %s backend", remote.BackendName)
+
 	l.Debugf("Creating temporary state file for migration")
 
 	file, err := os.CreateTemp("", "*.tfstate")
 	if err != nil {
-		return "", errors.New(err)
+		return errors.New(err)
 	}
 
 	defer func() {
-		file.Close() // nolint: errcheck
+		file.Close()           // nolint: errcheck
+		os.Remove(file.Name()) // nolint: errcheck
 	}()
 
-	if _, err := file.Write(output.Stdout.Bytes()); err != nil {
-		return file.Name(), errors.New(err)
+	if _, err := file.Write(state); err != nil {
+		return errors.New(err)
 	}
 
-	return file.Name(), nil
-}
+	args := []string{tf.CommandNameState, tf.CommandNamePush, file.Name()}
 
-func (remote *RemoteState) pushState(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, stateFile string) error {
-	l.Debugf("Pushing state to %s backend", remote.BackendName)
+	return tf.RunCommand(ctx, l, opts, args...)
+}
 
-	args := []string{tf.CommandNameState, tf.CommandNamePush, stateFile}
+func stateChecksum(state []byte) string {
+	sum := sha256.Sum256(state)
 
-	return tf.RunCommand(ctx, l, opts, args...)
+	return hex.EncodeToString(sum[:])
 }
@@ -1,11 +1,26 @@
 package remotestate_test
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/internal/remotestate"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/local"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 /**
@@ -135,3 +150,235 @@ func assertTerraformInitArgsEqual(t *testing.T, actualArgs []string, expectedArg
 		assert.Contains(t, actualArgs, expectedArg)
 	}
 }
+
+func TestMigrateAcrossBackends(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		states = make(map[string][]byte)
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := states[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.Write(data) //nolint:errcheck
+		case http.MethodPost:
+			states[r.URL.Path], _ = io.ReadAll(r.Body)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	srcDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "terraform.tfstate"), []byte(`{"serial": 1}`), 0644))
+
+	srcOpts, err := options.NewTerragruntOptionsForTest(filepath.Join(srcDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	srcOpts.WorkingDir = srcDir
+
+	dstOpts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	var (
+		l         = logger.CreateLogger()
+		srcRemote = remotestate.New(&remotestate.Config{BackendName: "local", BackendConfig: map[string]any{}})
+		dstRemote = remotestate.New(&remotestate.Config{BackendName: "http", BackendConfig: map[string]any{"address": server.URL + "/app"}})
+	)
+
+	var out bytes.Buffer
+
+	srcOpts.Writer = &out
+	srcOpts.BackendMigrateDryRun = true
+
+	require.NoError(t, srcRemote.Migrate(t.Context(), l, srcOpts, dstOpts, dstRemote))
+	assert.Contains(t, out.String(), "Would migrate local state")
+	assert.NotContains(t, states, "/app")
+
+	srcOpts.BackendMigrateDryRun = false
+
+	require.NoError(t, srcRemote.Migrate(t.Context(), l, srcOpts, dstOpts, dstRemote))
+	assert.JSONEq(t, `{"serial": 1}`, string(states["/app"]))
+	assert.FileExists(t, filepath.Join(srcDir, "terraform.tfstate"))
+	assert.NoFileExists(t, filepath.Join(srcDir, ".terraform.tfstate.lock.info"))
+
+	err = srcRemote.Migrate(t.Context(), l, srcOpts, dstOpts, dstRemote)
+	require.ErrorAs(t, err, new(remotestate.DestinationStateExistsError))
+}
+
+// versionedLocalBackend is a local backend which keeps the given versions of the state.
+type versionedLocalBackend struct {
+	*local.Backend
+	versions map[string][]byte
+}
+
+func (backend *versionedLocalBackend) Name() string {
+	return "versioned-local"
+}
+
+func (backend *versionedLocalBackend) ReadStateVersion(ctx context.Context, l log.Logger, config backend.Config, versionID string, opts *options.TerragruntOptions) ([]byte, error) {
+	return backend.versions[versionID], nil
+}
+
+func TestBackup(t *testing.T) {
+	remotestate.RegisterBackend(&versionedLocalBackend{
+		Backend:  local.NewBackend(),
+		versions: map[string][]byte{"v1": []byte(`{"serial": 1}`)},
+	})
+
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+
+	var (
+		l         = logger.CreateLogger()
+		backupDir = filepath.Join(t.TempDir(), "backups")
+		remote    = remotestate.New(&remotestate.Config{BackendName: "versioned-local", BackendConfig: map[string]any{}})
+	)
+
+	path, err := remote.Backup(t.Context(), l, opts, nil, backupDir)
+	require.NoError(t, err)
+	assert.Empty(t, path)
+	assert.NoDirExists(t, backupDir)
+
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "terraform.tfstate"), []byte(`{"serial": 2}`), 0644))
+
+	path, err = remote.Backup(t.Context(), l, opts, nil, backupDir)
+	require.NoError(t, err)
+	assert.Equal(t, backupDir, filepath.Dir(path))
+	assert.FileExists(t, path)
+
+	state, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"serial": 2}`, string(state))
+
+	version := &backend.StateVersion{ID: "v1", LastModified: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	path, err = remote.Backup(t.Context(), l, opts, version, backupDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(backupDir, "20250102T030405Z_v1.tfstate"), path)
+
+	state, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"serial": 1}`, string(state))
+}
+
+func TestRestore(t *testing.T) {
+	remotestate.RegisterBackend(&versionedLocalBackend{
+		Backend:  local.NewBackend(),
+		versions: map[string][]byte{"v1": []byte(`{"serial": 1}`)},
+	})
+
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	statePath := filepath.Join(workingDir, "terraform.tfstate")
+
+	require.NoError(t, os.WriteFile(statePath, []byte(`{"serial": 2}`), 0644))
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+
+	var (
+		l      = logger.CreateLogger()
+		remote = remotestate.New(&remotestate.Config{BackendName: "versioned-local", BackendConfig: map[string]any{}})
+	)
+
+	require.NoError(t, remote.Restore(t.Context(), l, opts, &backend.StateVersion{ID: "v1"}))
+
+	state, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"serial": 1}`, string(state))
+	assert.NoFileExists(t, filepath.Join(workingDir, ".terraform.tfstate.lock.info"))
+
+	lockPath := filepath.Join(workingDir, ".terraform.tfstate.lock.info")
+	require.NoError(t, os.WriteFile(lockPath, []byte(`{}`), 0644))
+	require.NoError(t, os.WriteFile(statePath, []byte(`{"serial": 2}`), 0644))
+
+	err = remote.Restore(t.Context(), l, opts, &backend.StateVersion{ID: "v1"})
+	require.ErrorAs(t, err, new(backend.StateLockedError))
+
+	state, err = os.ReadFile(statePath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"serial": 2}`, string(state))
+}
+
+func TestReadOutputs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		state         string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:     "outputs",
+			state:    `{"version": 4, "outputs": {"vpc_id": {"value": "vpc-123", "type": "string"}}, "resources": []}`,
+			expected: `{"vpc_id": {"value": "vpc-123", "type": "string"}}`,
+		},
+		{
+			name:     "no outputs",
+			state:    `{"version": 4, "resources": []}`,
+			expected: `{}`,
+		},
+		{
+			name:     "no state",
+			expected: `{}`,
+		},
+		{
+			name:          "invalid state",
+			state:         "not a state",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			workingDir := t.TempDir()
+
+			if tc.state != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(workingDir, "terraform.tfstate"), []byte(tc.state), 0644))
+			}
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+			require.NoError(t, err)
+
+			opts.WorkingDir = workingDir
+
+			remote := remotestate.New(&remotestate.Config{BackendName: "local", BackendConfig: map[string]any{}})
+
+			outputs, err := remote.ReadOutputs(t.Context(), logger.CreateLogger(), opts)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(outputs))
+		})
+	}
+
+	remote := remotestate.New(&remotestate.Config{BackendName: "unknown", BackendConfig: map[string]any{}})
+
+	_, err := remote.ReadOutputs(t.Context(), logger.CreateLogger(), nil)
+	require.ErrorAs(t, err, new(backend.StateAccessNotImplementedError))
+}
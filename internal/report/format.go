@@ -0,0 +1,397 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Format is the format a report is written in.
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSON  Format = "json"
+	FormatJUnit Format = "junit"
+)
+
+// Formats returns all the supported report formats.
+func Formats() []Format {
+	return []Format{FormatCSV, FormatJSON, FormatJUnit}
+}
+
+// ErrUnsupportedFormat is returned when a report format is not supported.
+var ErrUnsupportedFormat = errors.New("unsupported report format")
+
+// ParseFormat parses the given string as a report format.
+func ParseFormat(str string) (Format, error) {
+	for _, format := range Formats() {
+		if strings.EqualFold(str, string(format)) {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, str)
+}
+
+// FormatFromPath returns the report format matching the extension of the given path.
+// Files ending with `.json` are JSON reports, files ending with `.xml` are JUnit XML reports,
+// and every other file is a CSV report.
+func FormatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	case ".xml":
+		return FormatJUnit
+	}
+
+	return FormatCSV
+}
+
+// Ext returns the file extension used for reports of the format.
+func (format Format) Ext() string {
+	if format == FormatJUnit {
+		return "xml"
+	}
+
+	return string(format)
+}
+
+// jsonRun is the JSON representation of a run.
+type jsonRun struct {
+	Name      string   `json:"Name"`
+	Started   string   `json:"Started"`
+	Ended     string   `json:"Ended"`
+	Result    string   `json:"Result"`
+	Reason    string   `json:"Reason,omitempty"`
+	Cause     string   `json:"Cause,omitempty"`
+	Ancestors []string `json:"Ancestors,omitempty"`
+	Error     string   `json:"Error,omitempty"`
+}
+
+// WriteJSON writes the report to a writer in JSON format.
+func (r *Report) WriteJSON(w io.Writer) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	runs := make([]jsonRun, 0, len(r.Runs))
+
+	for _, run := range r.Runs {
+		run.mu.RLock()
+
+		jsonRun := jsonRun{
+			Name:      run.Name,
+			Started:   run.Started.Format(time.RFC3339),
+			Ended:     run.Ended.Format(time.RFC3339),
+			Result:    string(run.Result),
+			Ancestors: run.Ancestors,
+			Error:     run.Error,
+		}
+
+		if run.Reason != nil {
+			jsonRun.Reason = string(*run.Reason)
+		}
+
+		if run.Cause != nil {
+			jsonRun.Cause = string(*run.Cause)
+		}
+
+		run.mu.RUnlock()
+
+		runs = append(runs, jsonRun)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(runs)
+}
+
+// junitTestSuites is the root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite groups the runs of a report.
+type junitTestSuite struct {
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Name      string          `xml:"name,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      float64         `xml:"time,attr"`
+}
+
+// junitTestCase is a single run of a report.
+type junitTestCase struct {
+	Failure   *junitResult `xml:"failure,omitempty"`
+	Skipped   *junitResult `xml:"skipped,omitempty"`
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	Time      float64      `xml:"time,attr"`
+}
+
+// junitResult describes why a test case failed or was skipped.
+type junitResult struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnitXML writes the report to a writer in JUnit XML format.
+//
+// Every run is a test case. Failed runs are reported as failures, while early exits
+// and excluded runs are reported as skipped.
+func (r *Report) WriteJUnitXML(w io.Writer) error {
+	summary := r.Summarize()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	suite := junitTestSuite{
+		Name:      "terragrunt",
+		Tests:     summary.TotalUnits,
+		Failures:  summary.UnitsFailed,
+		Skipped:   summary.EarlyExits + summary.Excluded,
+		Time:      summary.TotalDuration().Seconds(),
+		TestCases: make([]junitTestCase, 0, len(r.Runs)),
+	}
+
+	if summary.firstRunStart != nil {
+		suite.Timestamp = summary.firstRunStart.Format(time.RFC3339)
+	}
+
+	for _, run := range r.Runs {
+		run.mu.RLock()
+
+		testCase := junitTestCase{
+			Name:      run.Name,
+			ClassName: filepath.Base(run.Name),
+		}
+
+		if !run.Ended.IsZero() {
+			testCase.Time = run.Ended.Sub(run.Started).Seconds()
+		}
+
+		result := &junitResult{
+			Message: run.Error,
+			Text:    strings.Join(run.Ancestors, "\n"),
+		}
+
+		if run.Reason != nil {
+			result.Type = string(*run.Reason)
+		}
+
+		if run.Cause != nil && result.Message == "" {
+			result.Message = string(*run.Cause)
+		}
+
+		switch run.Result {
+		case ResultFailed:
+			testCase.Failure = result
+		case ResultEarlyExit, ResultExcluded:
+			testCase.Skipped = result
+		}
+
+		run.mu.RUnlock()
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(junitTestSuites{TestSuites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+// ReadFromFile reads a report previously written with WriteToFile.
+// The format of the report is determined by the extension of the file.
+func ReadFromFile(path string) (*Report, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() //nolint:errcheck
+
+	r := NewReport()
+
+	switch FormatFromPath(path) {
+	case FormatJSON:
+		err = r.ReadJSON(file)
+	case FormatJUnit:
+		err = r.ReadJUnitXML(file)
+	default:
+		err = r.ReadCSV(file)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report %s: %w", path, err)
+	}
+
+	return r, nil
+}
+
+// ReadCSV reads the runs of a report in CSV format into the report.
+func (r *Report) ReadCSV(reader io.Reader) error {
+	records, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return err
+	}
+
+	// Skip the header.
+	for i, record := range records {
+		if i == 0 {
+			continue
+		}
+
+		if len(record) < 6 { //nolint:mnd
+			return fmt.Errorf("line %d: expected 6 columns, got %d", i+1, len(record))
+		}
+
+		run := &Run{
+			Name:   record[0],
+			Result: Result(record[3]),
+		}
+
+		if err := run.parseTimes(record[1], record[2]); err != nil {
+			return fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		run.setReasonCause(record[4], record[5])
+
+		r.addReadRun(run)
+	}
+
+	return nil
+}
+
+// ReadJSON reads the runs of a report in JSON format into the report.
+func (r *Report) ReadJSON(reader io.Reader) error {
+	var jsonRuns []jsonRun
+
+	if err := json.NewDecoder(reader).Decode(&jsonRuns); err != nil {
+		return err
+	}
+
+	for _, jsonRun := range jsonRuns {
+		run := &Run{
+			Name:      jsonRun.Name,
+			Result:    Result(jsonRun.Result),
+			Ancestors: jsonRun.Ancestors,
+			Error:     jsonRun.Error,
+		}
+
+		if err := run.parseTimes(jsonRun.Started, jsonRun.Ended); err != nil {
+			return fmt.Errorf("run %s: %w", jsonRun.Name, err)
+		}
+
+		run.setReasonCause(jsonRun.Reason, jsonRun.Cause)
+
+		r.addReadRun(run)
+	}
+
+	return nil
+}
+
+// ReadJUnitXML reads the runs of a report in JUnit XML format into the report.
+//
+// JUnit XML reports don't keep the time a run started and ended, nor the cause of a run.
+// Skipped test cases are read as early exits if they were skipped due to a run error, and as excluded runs otherwise.
+func (r *Report) ReadJUnitXML(reader io.Reader) error {
+	var suites junitTestSuites
+
+	if err := xml.NewDecoder(reader).Decode(&suites); err != nil {
+		return err
+	}
+
+	for _, suite := range suites.TestSuites {
+		for _, testCase := range suite.TestCases {
+			run := &Run{
+				Name:   testCase.Name,
+				Result: ResultSucceeded,
+			}
+
+			result := testCase.Failure
+
+			switch {
+			case testCase.Failure != nil:
+				run.Result = ResultFailed
+			case testCase.Skipped != nil && testCase.Skipped.Type == string(ReasonRunError):
+				run.Result = ResultEarlyExit
+				result = testCase.Skipped
+			case testCase.Skipped != nil:
+				run.Result = ResultExcluded
+				result = testCase.Skipped
+			}
+
+			if result != nil {
+				run.setReasonCause(result.Type, "")
+				run.Error = result.Message
+
+				if result.Text != "" {
+					run.Ancestors = strings.Split(result.Text, "\n")
+				}
+			}
+
+			r.addReadRun(run)
+		}
+	}
+
+	return nil
+}
+
+func (r *Report) addReadRun(run *Run) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Runs = append(r.Runs, run)
+}
+
+func (run *Run) parseTimes(started, ended string) error {
+	var err error
+
+	if started != "" {
+		if run.Started, err = time.Parse(time.RFC3339, started); err != nil {
+			return err
+		}
+	}
+
+	if ended != "" {
+		if run.Ended, err = time.Parse(time.RFC3339, ended); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (run *Run) setReasonCause(reason, cause string) {
+	if reason != "" {
+		reason := Reason(reason)
+		run.Reason = &reason
+	}
+
+	if cause != "" {
+		cause := Cause(cause)
+		run.Cause = &cause
+	}
+}
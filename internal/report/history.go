@@ -0,0 +1,336 @@
+package report
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// HistoryFileName is the name of the file run history is stored in.
+const HistoryFileName = "run-history.jsonl"
+
+const (
+	// slowdownRatio is how much longer than its average a unit has to take to be considered slower.
+	slowdownRatio = 1.25
+	// slowdownMinDuration is how much longer than its average a unit has to take at least to be considered slower,
+	// so that units that take a few milliseconds aren't reported for every bit of noise.
+	slowdownMinDuration = time.Second
+)
+
+// History is a local store of the reports of past runs.
+//
+// Every report is appended as a single JSON line, so that the store can be appended to
+// without having to read it first.
+type History struct {
+	path string
+}
+
+// HistoryEntry is a single report stored in the history.
+type HistoryEntry struct {
+	Started time.Time
+	Runs    []*Run
+}
+
+// NewHistory creates a new history stored in the given file.
+func NewHistory(path string) *History {
+	return &History{path: path}
+}
+
+// Path returns the path of the file the history is stored in.
+func (history *History) Path() string {
+	return history.path
+}
+
+// Append appends the given report to the history.
+func (history *History) Append(r *Report) error {
+	r.mu.Lock()
+	r.SortRuns()
+	r.mu.Unlock()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry := HistoryEntry{
+		Started: time.Now(),
+		Runs:    r.Runs,
+	}
+
+	for _, run := range r.Runs {
+		run.mu.RLock()
+		defer run.mu.RUnlock()
+
+		if run.Started.Before(entry.Started) {
+			entry.Started = run.Started
+		}
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(history.path), os.ModePerm); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(history.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close() //nolint:errcheck
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to append to run history: %w", err)
+	}
+
+	return nil
+}
+
+// Read returns the last `limit` entries of the history, oldest first.
+// If limit is zero or less, all the entries are returned.
+// A history that does not exist yet has no entries.
+func (history *History) Read(limit int) ([]*HistoryEntry, error) {
+	file, err := os.Open(history.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+	defer file.Close() //nolint:errcheck
+
+	var entries []*HistoryEntry
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<26) //nolint:mnd
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		entry := new(HistoryEntry)
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, fmt.Errorf("failed to parse run history %s line %d: %w", history.path, lineNum, err)
+		}
+
+		entries = append(entries, entry)
+
+		if limit > 0 && len(entries) > limit {
+			entries = entries[1:]
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Trends describes how units changed across the entries of a history.
+type Trends struct {
+	Regressions []*Regression
+	Slowdowns   []*Slowdown
+	Flaky       []*Flaky
+	Entries     int
+}
+
+// Regression is a unit that failed in the latest run, but succeeded in the run before it.
+type Regression struct {
+	Name     string
+	Previous Result
+	Latest   Result
+	Error    string
+}
+
+// Slowdown is a unit that took significantly longer in the latest run than it did on average before.
+type Slowdown struct {
+	Name    string
+	Average time.Duration
+	Latest  time.Duration
+}
+
+// Flaky is a unit whose result flipped between succeeding and failing more than once.
+type Flaky struct {
+	Name    string
+	Results []Result
+	Flips   int
+}
+
+// CompareHistory compares the latest entry of the given history entries, sorted oldest first, to the ones before it.
+func CompareHistory(entries []*HistoryEntry) *Trends {
+	trends := &Trends{Entries: len(entries)}
+
+	if len(entries) < 2 { //nolint:mnd
+		return trends
+	}
+
+	latest := entries[len(entries)-1]
+	previous := entries[:len(entries)-1]
+
+	for _, run := range latest.Runs {
+		var (
+			prevRun   *Run
+			durations []time.Duration
+		)
+
+		for _, entry := range previous {
+			if pastRun := entry.run(run.Name); pastRun != nil {
+				prevRun = pastRun
+
+				if pastRun.Result == ResultSucceeded {
+					durations = append(durations, pastRun.Duration())
+				}
+			}
+		}
+
+		if prevRun != nil && prevRun.Result == ResultSucceeded && run.Result == ResultFailed {
+			trends.Regressions = append(trends.Regressions, &Regression{
+				Name:     run.Name,
+				Previous: prevRun.Result,
+				Latest:   run.Result,
+				Error:    run.Error,
+			})
+		}
+
+		if run.Result == ResultSucceeded && len(durations) > 0 {
+			var total time.Duration
+			for _, duration := range durations {
+				total += duration
+			}
+
+			average := total / time.Duration(len(durations))
+			latestDuration := run.Duration()
+
+			if float64(latestDuration) > float64(average)*slowdownRatio && latestDuration-average >= slowdownMinDuration {
+				trends.Slowdowns = append(trends.Slowdowns, &Slowdown{
+					Name:    run.Name,
+					Average: average,
+					Latest:  latestDuration,
+				})
+			}
+		}
+	}
+
+	for _, name := range historyRunNames(entries) {
+		var (
+			results []Result
+			flips   int
+		)
+
+		for _, entry := range entries {
+			run := entry.run(name)
+			// Only units that actually ran tell whether they are flaky.
+			if run == nil || (run.Result != ResultSucceeded && run.Result != ResultFailed) {
+				continue
+			}
+
+			if len(results) > 0 && results[len(results)-1] != run.Result {
+				flips++
+			}
+
+			results = append(results, run.Result)
+		}
+
+		if flips > 1 {
+			trends.Flaky = append(trends.Flaky, &Flaky{
+				Name:    name,
+				Results: results,
+				Flips:   flips,
+			})
+		}
+	}
+
+	slices.SortFunc(trends.Slowdowns, func(a, b *Slowdown) int {
+		return cmp.Compare(b.Latest-b.Average, a.Latest-a.Average)
+	})
+
+	return trends
+}
+
+// Duration returns how long the run took.
+func (run *Run) Duration() time.Duration {
+	if run.Ended.IsZero() {
+		return 0
+	}
+
+	return run.Ended.Sub(run.Started)
+}
+
+func (entry *HistoryEntry) run(name string) *Run {
+	for _, run := range entry.Runs {
+		if run.Name == name {
+			return run
+		}
+	}
+
+	return nil
+}
+
+// historyRunNames returns the sorted names of all the runs in the given entries.
+func historyRunNames(entries []*HistoryEntry) []string {
+	var names []string
+
+	for _, entry := range entries {
+		for _, run := range entry.Runs {
+			if !slices.Contains(names, run.Name) {
+				names = append(names, run.Name)
+			}
+		}
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Durations returns the duration of every run in the report that succeeded, by the name of the run.
+func (r *Report) Durations() map[string]time.Duration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	durations := make(map[string]time.Duration, len(r.Runs))
+
+	for _, run := range r.Runs {
+		run.mu.RLock()
+
+		if run.Result == ResultSucceeded && !run.Ended.IsZero() {
+			durations[run.Name] = run.Duration()
+		}
+
+		run.mu.RUnlock()
+	}
+
+	return durations
+}
+
+// AverageDurations returns the average duration of the runs that succeeded in the given history entries,
+// by the name of the run.
+func AverageDurations(entries []*HistoryEntry) map[string]time.Duration {
+	var (
+		totals = make(map[string]time.Duration)
+		counts = make(map[string]int)
+	)
+
+	for _, entry := range entries {
+		for _, run := range entry.Runs {
+			if run.Result == ResultSucceeded && !run.Ended.IsZero() {
+				totals[run.Name] += run.Duration()
+				counts[run.Name]++
+			}
+		}
+	}
+
+	for name, total := range totals {
+		totals[name] = total / time.Duration(counts[name])
+	}
+
+	return totals
+}
@@ -0,0 +1,97 @@
+package report_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryAppendRead(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+
+	history := report.NewHistory(filepath.Join(tmp, ".terragrunt-cache", report.HistoryFileName))
+
+	entries, err := history.Read(0)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	for _, result := range []report.Result{report.ResultSucceeded, report.ResultFailed, report.ResultSucceeded} {
+		r := report.NewReport()
+
+		run := newRun(t, filepath.Join(tmp, "unit"))
+		require.NoError(t, r.AddRun(run))
+		require.NoError(t, r.EndRun(run.Name, report.WithResult(result)))
+
+		require.NoError(t, history.Append(r))
+	}
+
+	entries, err = history.Read(0)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, report.ResultSucceeded, entries[0].Runs[0].Result)
+	assert.Equal(t, report.ResultFailed, entries[1].Runs[0].Result)
+
+	entries, err = history.Read(2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, report.ResultFailed, entries[0].Runs[0].Result)
+	assert.Equal(t, report.ResultSucceeded, entries[1].Runs[0].Result)
+}
+
+func TestCompareHistory(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newEntry := func(runs ...*report.Run) *report.HistoryEntry {
+		return &report.HistoryEntry{Started: start, Runs: runs}
+	}
+
+	newHistoryRun := func(name string, result report.Result, duration time.Duration) *report.Run {
+		return &report.Run{Name: name, Result: result, Started: start, Ended: start.Add(duration)}
+	}
+
+	entries := []*report.HistoryEntry{
+		newEntry(
+			newHistoryRun("/regressed", report.ResultSucceeded, time.Second),
+			newHistoryRun("/slower", report.ResultSucceeded, 2*time.Second),
+			newHistoryRun("/flaky", report.ResultSucceeded, time.Second),
+		),
+		newEntry(
+			newHistoryRun("/regressed", report.ResultSucceeded, time.Second),
+			newHistoryRun("/slower", report.ResultSucceeded, 2*time.Second),
+			newHistoryRun("/flaky", report.ResultFailed, time.Second),
+		),
+		newEntry(
+			newHistoryRun("/regressed", report.ResultFailed, time.Second),
+			newHistoryRun("/slower", report.ResultSucceeded, 5*time.Second),
+			newHistoryRun("/flaky", report.ResultSucceeded, time.Second),
+		),
+	}
+
+	trends := report.CompareHistory(entries)
+
+	assert.Equal(t, 3, trends.Entries)
+
+	require.Len(t, trends.Regressions, 1)
+	assert.Equal(t, "/regressed", trends.Regressions[0].Name)
+	assert.Equal(t, report.ResultSucceeded, trends.Regressions[0].Previous)
+	assert.Equal(t, report.ResultFailed, trends.Regressions[0].Latest)
+
+	require.Len(t, trends.Slowdowns, 1)
+	assert.Equal(t, "/slower", trends.Slowdowns[0].Name)
+	assert.Equal(t, 2*time.Second, trends.Slowdowns[0].Average)
+	assert.Equal(t, 5*time.Second, trends.Slowdowns[0].Latest)
+
+	require.Len(t, trends.Flaky, 1)
+	assert.Equal(t, "/flaky", trends.Flaky[0].Name)
+	assert.Equal(t, 2, trends.Flaky[0].Flips)
+
+	assert.Empty(t, report.CompareHistory(entries[:1]).Regressions)
+}
@@ -20,19 +20,22 @@ import (
 // Report captures data for a report/summary.
 type Report struct {
 	Runs        []*Run
+	format      Format
 	shouldColor bool
 	mu          sync.RWMutex
 }
 
 // Run captures data for a run.
 type Run struct {
-	Started time.Time
-	Ended   time.Time
-	Reason  *Reason
-	Cause   *Cause
-	Name    string
-	Result  Result
-	mu      sync.RWMutex
+	Started   time.Time
+	Ended     time.Time
+	Reason    *Reason
+	Cause     *Cause
+	Name      string
+	Result    Result
+	Error     string
+	Ancestors []string
+	mu        sync.RWMutex
 }
 
 // Result captures the result of a run.
@@ -122,6 +125,14 @@ func (r *Report) WithDisableColor() *Report {
 	return r
 }
 
+// WithFormat sets the format the report is written in by WriteToFile.
+// When no format is set, the format is determined by the extension of the report file.
+func (r *Report) WithFormat(format Format) *Report {
+	r.format = format
+
+	return r
+}
+
 // ErrPathMustBeAbsolute is returned when a report run path is not absolute.
 var ErrPathMustBeAbsolute = errors.New("report run path must be absolute")
 
@@ -247,6 +258,7 @@ const (
 	ReasonExcludeDir     Reason = "--exclude-dir"
 	ReasonExcludeBlock   Reason = "exclude block"
 	ReasonEarlyExit      Reason = "early exit"
+	ReasonResumeFrom     Reason = "--resume-from"
 )
 
 // WithReason sets the reason of a run.
@@ -288,6 +300,25 @@ func WithCauseAncestorExit(name string) EndOption {
 	return withCause(name)
 }
 
+// WithError sets the error message of a run.
+func WithError(err error) EndOption {
+	return func(run *Run) {
+		if err != nil {
+			run.Error = err.Error()
+		}
+	}
+}
+
+// WithAncestors sets the dependency ancestry of a run, nearest dependency first.
+//
+// For early exits, this is the chain of dependencies that led to the run exiting early,
+// with the dependency that actually failed last.
+func WithAncestors(paths ...string) EndOption {
+	return func(run *Run) {
+		run.Ancestors = paths
+	}
+}
+
 // withCause sets the cause of a run to the name of a particular cause.
 func withCause(name string) EndOption {
 	return func(run *Run) {
@@ -379,9 +410,15 @@ func (s *Summary) TotalDurationString(colorizer *Colorizer) string {
 }
 
 // WriteToFile writes the report to a file.
+// The format of the report is the one set with WithFormat, or is determined by the extension of the file otherwise.
 func (r *Report) WriteToFile(path string) error {
+	format := r.format
+	if format == "" {
+		format = FormatFromPath(path)
+	}
+
 	// Create a temporary file to write to
-	tmpFile, err := os.CreateTemp("", "terragrunt-report-*.csv")
+	tmpFile, err := os.CreateTemp("", "terragrunt-report-*."+format.Ext())
 	if err != nil {
 		return err
 	}
@@ -392,7 +429,7 @@ func (r *Report) WriteToFile(path string) error {
 	r.mu.Unlock()
 
 	// Write the report to the temporary file
-	err = r.WriteCSV(tmpFile)
+	err = r.Write(tmpFile, format)
 	if err != nil {
 		return fmt.Errorf("failed to write report: %w", err)
 	}
@@ -406,6 +443,20 @@ func (r *Report) WriteToFile(path string) error {
 	return os.Rename(tmpFile.Name(), path)
 }
 
+// Write writes the report to a writer in the given format.
+func (r *Report) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatCSV:
+		return r.WriteCSV(w)
+	case FormatJSON:
+		return r.WriteJSON(w)
+	case FormatJUnit:
+		return r.WriteJUnitXML(w)
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+}
+
 // WriteCSV writes the report to a writer in CSV format.
 func (r *Report) WriteCSV(w io.Writer) error {
 	r.mu.RLock()
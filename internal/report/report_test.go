@@ -2,6 +2,10 @@ package report_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -350,6 +354,230 @@ func TestWriteCSV(t *testing.T) {
 	}
 }
 
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+
+	r := report.NewReport()
+
+	failedRun := newRun(t, filepath.Join(tmp, "failed-run"))
+	r.AddRun(failedRun)
+	r.EndRun(failedRun.Name,
+		report.WithResult(report.ResultFailed),
+		report.WithReason(report.ReasonRunError),
+		report.WithError(errors.New("apply failed")),
+	)
+
+	earlyExitRun := newRun(t, filepath.Join(tmp, "early-exit-run"))
+	r.AddRun(earlyExitRun)
+	r.EndRun(earlyExitRun.Name,
+		report.WithResult(report.ResultEarlyExit),
+		report.WithReason(report.ReasonRunError),
+		report.WithAncestors(failedRun.Name),
+		report.WithCauseAncestorExit(failedRun.Name),
+	)
+
+	var buf bytes.Buffer
+	err := r.WriteJSON(&buf)
+	require.NoError(t, err)
+
+	var runs []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &runs))
+	require.Len(t, runs, 2)
+
+	assert.Equal(t, failedRun.Name, runs[0]["Name"])
+	assert.Equal(t, "failed", runs[0]["Result"])
+	assert.Equal(t, "run error", runs[0]["Reason"])
+	assert.Equal(t, "apply failed", runs[0]["Error"])
+	assert.NotEmpty(t, runs[0]["Started"])
+	assert.NotEmpty(t, runs[0]["Ended"])
+	assert.NotContains(t, runs[0], "Cause")
+	assert.NotContains(t, runs[0], "Ancestors")
+
+	assert.Equal(t, "early exit", runs[1]["Result"])
+	assert.Equal(t, failedRun.Name, runs[1]["Cause"])
+	assert.Equal(t, []any{failedRun.Name}, runs[1]["Ancestors"])
+}
+
+func TestWriteJUnitXML(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+
+	r := report.NewReport()
+
+	successRun := newRun(t, filepath.Join(tmp, "success-run"))
+	r.AddRun(successRun)
+	r.EndRun(successRun.Name)
+
+	failedRun := newRun(t, filepath.Join(tmp, "failed-run"))
+	r.AddRun(failedRun)
+	r.EndRun(failedRun.Name,
+		report.WithResult(report.ResultFailed),
+		report.WithReason(report.ReasonRunError),
+		report.WithError(errors.New("apply failed")),
+	)
+
+	excludedRun := newRun(t, filepath.Join(tmp, "excluded-run"))
+	r.AddRun(excludedRun)
+	r.EndRun(excludedRun.Name, report.WithResult(report.ResultExcluded), report.WithReason(report.ReasonExcludeBlock))
+
+	var buf bytes.Buffer
+	err := r.WriteJUnitXML(&buf)
+	require.NoError(t, err)
+
+	var suites struct {
+		TestSuites []struct {
+			Name      string `xml:"name,attr"`
+			TestCases []struct {
+				Failure *struct {
+					Message string `xml:"message,attr"`
+					Type    string `xml:"type,attr"`
+				} `xml:"failure"`
+				Skipped *struct {
+					Type string `xml:"type,attr"`
+				} `xml:"skipped"`
+				Name string `xml:"name,attr"`
+			} `xml:"testcase"`
+			Tests    int `xml:"tests,attr"`
+			Failures int `xml:"failures,attr"`
+			Skipped  int `xml:"skipped,attr"`
+		} `xml:"testsuite"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &suites))
+	require.Len(t, suites.TestSuites, 1)
+
+	suite := suites.TestSuites[0]
+	assert.Equal(t, 3, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, 1, suite.Skipped)
+	require.Len(t, suite.TestCases, 3)
+
+	assert.Nil(t, suite.TestCases[0].Failure)
+	assert.Nil(t, suite.TestCases[0].Skipped)
+
+	require.NotNil(t, suite.TestCases[1].Failure)
+	assert.Equal(t, "apply failed", suite.TestCases[1].Failure.Message)
+	assert.Equal(t, "run error", suite.TestCases[1].Failure.Type)
+
+	require.NotNil(t, suite.TestCases[2].Skipped)
+	assert.Equal(t, "exclude block", suite.TestCases[2].Skipped.Type)
+}
+
+func TestWriteToFile(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+
+	tests := []struct {
+		name     string
+		file     string
+		format   report.Format
+		expected string
+	}{
+		{
+			name:     "csv extension",
+			file:     "report.csv",
+			expected: "Name,Started,Ended,Result,Reason,Cause",
+		},
+		{
+			name:     "json extension",
+			file:     "report.json",
+			expected: `"Result": "succeeded"`,
+		},
+		{
+			name:     "xml extension",
+			file:     "report.xml",
+			expected: "<testsuites>",
+		},
+		{
+			name:     "format overrides extension",
+			file:     "report.txt",
+			format:   report.FormatJSON,
+			expected: `"Result": "succeeded"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := report.NewReport().WithFormat(tt.format)
+
+			run := newRun(t, filepath.Join(tmp, "successful-run"))
+			r.AddRun(run)
+			r.EndRun(run.Name)
+
+			path := filepath.Join(t.TempDir(), tt.file)
+			require.NoError(t, r.WriteToFile(path))
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Contains(t, string(content), tt.expected)
+		})
+	}
+}
+
+func TestReadFromFile(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+
+	for _, file := range []string{"report.csv", "report.json", "report.xml"} {
+		t.Run(file, func(t *testing.T) {
+			t.Parallel()
+
+			r := report.NewReport()
+
+			successRun := newRun(t, filepath.Join(tmp, "success-run"))
+			r.AddRun(successRun)
+			r.EndRun(successRun.Name)
+
+			failedRun := newRun(t, filepath.Join(tmp, "failed-run"))
+			r.AddRun(failedRun)
+			r.EndRun(failedRun.Name, report.WithResult(report.ResultFailed), report.WithReason(report.ReasonRunError))
+
+			earlyExitRun := newRun(t, filepath.Join(tmp, "early-exit-run"))
+			r.AddRun(earlyExitRun)
+			r.EndRun(earlyExitRun.Name, report.WithResult(report.ResultEarlyExit), report.WithReason(report.ReasonRunError))
+
+			excludedRun := newRun(t, filepath.Join(tmp, "excluded-run"))
+			r.AddRun(excludedRun)
+			r.EndRun(excludedRun.Name, report.WithResult(report.ResultExcluded), report.WithReason(report.ReasonResumeFrom))
+
+			path := filepath.Join(t.TempDir(), file)
+			require.NoError(t, r.WriteToFile(path))
+
+			readReport, err := report.ReadFromFile(path)
+			require.NoError(t, err)
+			require.Len(t, readReport.Runs, 4)
+
+			for _, run := range r.Runs {
+				readRun, err := readReport.GetRun(run.Name)
+				require.NoError(t, err)
+				assert.Equal(t, run.Result, readRun.Result, run.Name)
+
+				if run.Reason != nil {
+					require.NotNil(t, readRun.Reason, run.Name)
+					assert.Equal(t, *run.Reason, *readRun.Reason, run.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := report.ParseFormat("JSON")
+	require.NoError(t, err)
+	assert.Equal(t, report.FormatJSON, format)
+
+	_, err = report.ParseFormat("yaml")
+	require.ErrorIs(t, err, report.ErrUnsupportedFormat)
+}
+
 func TestWriteSummary(t *testing.T) {
 	t.Parallel()
 
@@ -15,6 +15,7 @@ import (
 
 	"github.com/gruntwork-io/terragrunt/cli/commands/scaffold"
 	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/cas"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/experiment"
 	"github.com/gruntwork-io/terragrunt/internal/services/catalog/module"
@@ -25,7 +26,7 @@ import (
 
 // NewRepoFunc defines the signature for a function that creates a new repository.
 // This allows for mocking in tests.
-type NewRepoFunc func(ctx context.Context, l log.Logger, cloneURL, path string, walkWithSymlinks, allowCAS bool) (*module.Repo, error)
+type NewRepoFunc func(ctx context.Context, l log.Logger, cloneURL, path string, walkWithSymlinks bool, casOpts *cas.Options) (*module.Repo, error)
 
 const (
 	// tempDirFormat is used to create unique temporary directory names for catalog repositories.
@@ -119,7 +120,12 @@ func (s *catalogServiceImpl) Load(ctx context.Context, l log.Logger) error {
 
 	// Evaluate experimental features for symlinks and content-addressable storage.
 	walkWithSymlinks := s.opts.Experiments.Evaluate(experiment.Symlinks)
-	allowCAS := s.opts.Experiments.Evaluate(experiment.CAS)
+
+	var casOpts *cas.Options
+
+	if s.opts.Experiments.Evaluate(experiment.CAS) {
+		casOpts = &cas.Options{MaxSize: s.opts.CASMaxSize, Remote: s.opts.CASRemote}
+	}
 
 	var errs []error
 
@@ -138,7 +144,7 @@ func (s *catalogServiceImpl) Load(ctx context.Context, l log.Logger) error {
 
 		// Initialize the repository. This might involve cloning or updating.
 		// Use the newRepo function stored in the service instance.
-		repo, err := s.newRepo(ctx, l, currentRepoURL, tempPath, walkWithSymlinks, allowCAS)
+		repo, err := s.newRepo(ctx, l, currentRepoURL, tempPath, walkWithSymlinks, casOpts)
 		if err != nil {
 			l.Errorf("Failed to initialize repository %s: %v", currentRepoURL, err)
 
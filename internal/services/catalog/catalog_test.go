@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/cas"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/services/catalog"
 	"github.com/gruntwork-io/terragrunt/internal/services/catalog/module"
@@ -25,7 +26,7 @@ func TestListModules_HappyPath(t *testing.T) {
 	opts := options.NewTerragruntOptions()
 	opts.ScaffoldRootFileName = config.RecommendedParentConfigName
 
-	mockNewRepo := func(ctx context.Context, logger log.Logger, repoURL, path string, walkWithSymlinks, allowCAS bool) (*module.Repo, error) {
+	mockNewRepo := func(ctx context.Context, logger log.Logger, repoURL, path string, walkWithSymlinks bool, casOpts *cas.Options) (*module.Repo, error) {
 		// Use t.TempDir() for the dummyRepoDir to ensure cleanup and parallelism safety.
 		dummyRepoDir := filepath.Join(t.TempDir(), strings.ReplaceAll(repoURL, "github.com/gruntwork-io/", ""))
 		os.MkdirAll(filepath.Join(dummyRepoDir, ".git"), 0755)
@@ -36,13 +37,13 @@ func TestListModules_HappyPath(t *testing.T) {
 			readme1Path := filepath.Join(dummyRepoDir, "README.md")
 			os.WriteFile(readme1Path, []byte("# module1-title\nThis is module1."), 0644)
 			os.WriteFile(filepath.Join(dummyRepoDir, "module1.tf"), []byte{}, 0644)
-			return module.NewRepo(ctx, logger, dummyRepoDir, path, walkWithSymlinks, allowCAS)
+			return module.NewRepo(ctx, logger, dummyRepoDir, path, walkWithSymlinks, casOpts)
 		}
 		if repoURL == "github.com/gruntwork-io/repo2" {
 			readme2Path := filepath.Join(dummyRepoDir, "README.md")
 			os.WriteFile(readme2Path, []byte("# module2-title\nThis is module2."), 0644)
 			os.WriteFile(filepath.Join(dummyRepoDir, "module2.tf"), []byte{}, 0644)
-			return module.NewRepo(ctx, logger, dummyRepoDir, path, walkWithSymlinks, allowCAS)
+			return module.NewRepo(ctx, logger, dummyRepoDir, path, walkWithSymlinks, casOpts)
 		}
 		return nil, fmt.Errorf("unexpected repoURL in mock newRepoFunc: %s", repoURL)
 	}
@@ -100,7 +101,7 @@ func TestListModules_SingleRepoFromFlag(t *testing.T) {
 	opts := options.NewTerragruntOptions()
 	opts.ScaffoldRootFileName = config.RecommendedParentConfigName
 
-	mockNewRepo := func(ctx context.Context, logger log.Logger, repoURL, path string, walkWithSymlinks, allowCAS bool) (*module.Repo, error) {
+	mockNewRepo := func(ctx context.Context, logger log.Logger, repoURL, path string, walkWithSymlinks bool, casOpts *cas.Options) (*module.Repo, error) {
 		if repoURL == "github.com/gruntwork-io/only-repo" {
 			dummyRepoDir := filepath.Join(t.TempDir(), "only-repo")
 			os.MkdirAll(filepath.Join(dummyRepoDir, ".git"), 0755)
@@ -108,7 +109,7 @@ func TestListModules_SingleRepoFromFlag(t *testing.T) {
 			os.WriteFile(filepath.Join(dummyRepoDir, ".git", "HEAD"), []byte("ref: refs/heads/main"), 0644)
 			os.WriteFile(filepath.Join(dummyRepoDir, "README.md"), []byte("# moduleA-title"), 0644)
 			os.WriteFile(filepath.Join(dummyRepoDir, "moduleA.tf"), []byte{}, 0644)
-			return module.NewRepo(ctx, logger, dummyRepoDir, path, walkWithSymlinks, allowCAS)
+			return module.NewRepo(ctx, logger, dummyRepoDir, path, walkWithSymlinks, casOpts)
 		}
 		return nil, fmt.Errorf("unexpected repoURL: %s", repoURL)
 	}
@@ -132,7 +133,7 @@ func TestListModules_ErrorFromNewRepo(t *testing.T) {
 	opts.ScaffoldRootFileName = config.RecommendedParentConfigName
 
 	expectedErr := errors.Errorf("failed to clone repo")
-	mockNewRepo := func(ctx context.Context, logger log.Logger, repoURL, path string, walkWithSymlinks, allowCAS bool) (*module.Repo, error) {
+	mockNewRepo := func(ctx context.Context, logger log.Logger, repoURL, path string, walkWithSymlinks bool, casOpts *cas.Options) (*module.Repo, error) {
 		return nil, expectedErr
 	}
 
@@ -151,7 +152,7 @@ func TestListModules_ErrorFromFindModules(t *testing.T) {
 	opts := options.NewTerragruntOptions()
 	opts.ScaffoldRootFileName = config.RecommendedParentConfigName
 
-	mockNewRepo := func(ctx context.Context, logger log.Logger, repoURL, path string, walkWithSymlinks, allowCAS bool) (*module.Repo, error) {
+	mockNewRepo := func(ctx context.Context, logger log.Logger, repoURL, path string, walkWithSymlinks bool, casOpts *cas.Options) (*module.Repo, error) {
 		if repoURL == "github.com/gruntwork-io/find-error-repo" {
 			dummyRepoDir := filepath.Join(t.TempDir(), "find-error-repo-dir")
 			os.MkdirAll(filepath.Join(dummyRepoDir, ".git"), 0755)
@@ -163,7 +164,7 @@ func TestListModules_ErrorFromFindModules(t *testing.T) {
 			os.WriteFile(filepath.Join(moduleDirWithBadReadme, "main.tf"), []byte("{}"), 0644)
 			os.Mkdir(filepath.Join(moduleDirWithBadReadme, "README.md"), 0755)
 
-			return module.NewRepo(ctx, logger, dummyRepoDir, path, walkWithSymlinks, allowCAS)
+			return module.NewRepo(ctx, logger, dummyRepoDir, path, walkWithSymlinks, casOpts)
 		}
 		return nil, fmt.Errorf("unexpected repoURL: %s", repoURL)
 	}
@@ -182,12 +183,12 @@ func TestListModules_NoModulesFound(t *testing.T) {
 	opts := options.NewTerragruntOptions()
 	opts.ScaffoldRootFileName = config.RecommendedParentConfigName
 
-	mockNewRepo := func(ctx context.Context, logger log.Logger, repoURL, path string, walkWithSymlinks, allowCAS bool) (*module.Repo, error) {
+	mockNewRepo := func(ctx context.Context, logger log.Logger, repoURL, path string, walkWithSymlinks bool, casOpts *cas.Options) (*module.Repo, error) {
 		dummyRepoDir := filepath.Join(t.TempDir(), "empty-repo-dir")
 		os.MkdirAll(filepath.Join(dummyRepoDir, ".git"), 0755)
 		os.WriteFile(filepath.Join(dummyRepoDir, ".git", "config"), []byte("[remote \"origin\"]\nurl = "+repoURL), 0644)
 		os.WriteFile(filepath.Join(dummyRepoDir, ".git", "HEAD"), []byte("ref: refs/heads/main"), 0644)
-		return module.NewRepo(ctx, logger, dummyRepoDir, path, walkWithSymlinks, allowCAS)
+		return module.NewRepo(ctx, logger, dummyRepoDir, path, walkWithSymlinks, casOpts)
 	}
 
 	svc := catalog.NewCatalogService(opts).WithNewRepoFunc(mockNewRepo).WithRepoURL("github.com/gruntwork-io/empty-repo")
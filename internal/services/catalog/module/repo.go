@@ -49,17 +49,20 @@ type Repo struct {
 	RemoteURL  string
 	BranchName string
 
+	// casOpts are the options of the content-addressable storage used to clone the repository.
+	// If nil, the repository is cloned without CAS.
+	casOpts *cas.Options
+
 	walkWithSymlinks bool
-	allowCAS         bool
 }
 
-func NewRepo(ctx context.Context, l log.Logger, cloneURL, path string, walkWithSymlinks bool, allowCAS bool) (*Repo, error) {
+func NewRepo(ctx context.Context, l log.Logger, cloneURL, path string, walkWithSymlinks bool, casOpts *cas.Options) (*Repo, error) {
 	repo := &Repo{
 		logger:           l,
 		cloneURL:         cloneURL,
 		path:             path,
 		walkWithSymlinks: walkWithSymlinks,
-		allowCAS:         allowCAS,
+		casOpts:          casOpts,
 	}
 
 	if err := repo.clone(ctx, l); err != nil {
@@ -282,8 +285,8 @@ func (repo *Repo) cloneCompleted() bool {
 func (repo *Repo) performClone(ctx context.Context, l log.Logger, opts *CloneOptions) error {
 	client := getter.DefaultClient
 
-	if repo.allowCAS {
-		c, err := cas.New(cas.Options{})
+	if repo.casOpts != nil {
+		c, err := cas.New(*repo.casOpts)
 		if err != nil {
 			return err
 		}
@@ -151,6 +151,10 @@ type TerragruntOptions struct {
 	EngineLogLevel string
 	// Path to cache directory for engine files
 	EngineCachePath string
+	// Maximum size in bytes of the CAS store, beyond which the least recently used content is evicted
+	CASMaxSize int64
+	// URL of a shared tier of the CAS store, either a directory or an S3 bucket
+	CASRemote string
 	// The command and arguments that can be used to fetch authentication configurations.
 	AuthProviderCmd string
 	// Folder to store JSON representation of output files.
@@ -193,6 +197,8 @@ type TerragruntOptions struct {
 	ModulesThatInclude []string
 	// When used with `run --all`, restrict the units in the stack to only those that read at least one of the files in this list.
 	UnitsReading []string
+	// When used with `run --all`, restrict the units in the stack to only those affected by the changes since this git ref.
+	ChangedSince string
 	// Experiments is a map of experiments, and their status.
 	Experiments experiment.Experiments `clone:"shadowcopy"`
 	// Maximum number of times to retry errors matching RetryableErrors
@@ -237,6 +243,13 @@ type TerragruntOptions struct {
 	ExcludeByDefault bool
 	// This is an experimental feature, used to speed up dependency processing by getting the output from the state
 	FetchDependencyOutputFromState bool
+	// Caches dependency outputs on disk across runs, by the location of their state.
+	DependencyOutputCache bool
+	// DependencyOutputCacheTTL is how long cached dependency outputs are valid for if the backend cannot tell whether
+	// the state changed.
+	DependencyOutputCacheTTL time.Duration
+	// DependencyOutputCacheDir is the directory dependency outputs are cached in.
+	DependencyOutputCacheDir string
 	// True if is required to show dependent modules and confirm action
 	CheckDependentModules bool
 	// True if is required not to show dependent modules and confirm action
@@ -271,6 +284,8 @@ type TerragruntOptions struct {
 	HCLValidateStrict bool
 	// HCLValidateInputs checks if the terragrunt configured inputs align with the terraform defined variables.
 	HCLValidateInputs bool
+	// HCLValidateMockOutputs checks if the mock outputs of dependencies align with the outputs defined in their terraform source.
+	HCLValidateMockOutputs bool
 	// HCLValidateShowConfigPath shows the paths of the hcl invalid configs.
 	HCLValidateShowConfigPath bool
 	// HCLValidateJSONOutput outputs the hcl validate result as a JSON string.
@@ -299,8 +314,24 @@ type TerragruntOptions struct {
 	ForceBackendDelete bool
 	// ForceBackendMigrate forces the backend to be migrated, even if the bucket is not versioned.
 	ForceBackendMigrate bool
+	// BackendMigrateDryRun prints what would be migrated by `backend migrate` without migrating anything.
+	BackendMigrateDryRun bool
+	// BackendStateVersion is the ID or timestamp of the state version used by `backend backup` and `backend restore`.
+	BackendStateVersion string
+	// BackendBackupDir is the directory `backend backup` writes the state backups into.
+	BackendBackupDir string
 	// SummaryDisable disables the summary output at the end of a run.
 	SummaryDisable bool
+	// ReportFile is the path to the file the run report is written to.
+	ReportFile string
+	// ReportFormat is the format of the run report. If not set, it is determined by the extension of ReportFile.
+	ReportFormat string
+	// ReportHistory appends the run report to the run history stored in the download dir.
+	ReportHistory bool
+	// ReportHistoryLimit is the number of past runs `info history` compares.
+	ReportHistoryLimit int
+	// ResumeFrom is the path to the report of a prior run. Units that succeeded in that run are skipped.
+	ResumeFrom string
 }
 
 // TerragruntOptionsFunc is a functional option type used to pass options in certain integration tests
@@ -3,7 +3,9 @@ package shell
 import (
 	"bytes"
 	"context"
+	"io"
 	"net/url"
+	"path/filepath"
 	"strings"
 
 	"github.com/gruntwork-io/terragrunt/internal/cache"
@@ -53,6 +55,73 @@ func GitTopLevelDir(ctx context.Context, l log.Logger, terragruntOptions *option
 	return cmdOutput, nil
 }
 
+// GitChangedFiles returns the absolute paths of the files in the git repository of the passed directory that changed
+// since the passed ref. Committed, uncommitted and untracked changes are all included. To not include changes made on
+// the ref after the current branch diverged from it, the working tree is compared with the merge base of the ref and HEAD.
+func GitChangedFiles(ctx context.Context, l log.Logger, terragruntOptions *options.TerragruntOptions, path, ref string) ([]string, error) {
+	topLevelDir, err := GitTopLevelDir(ctx, l, terragruntOptions, path)
+	if err != nil {
+		return nil, err
+	}
+
+	runGit := func(args ...string) ([]string, error) {
+		opts, err := options.NewTerragruntOptionsWithConfigPath(topLevelDir)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.Env = terragruntOptions.Env
+		opts.Writer = io.Discard
+		opts.ErrWriter = io.Discard
+
+		// Disable quoting, so that paths with special characters are output verbatim.
+		cmd, err := RunCommandWithOutput(ctx, l, opts, topLevelDir, true, false, "git", append([]string{"-c", "core.quotePath=false"}, args...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		var lines []string
+
+		for _, line := range strings.Split(cmd.Stdout.String(), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+
+		return lines, nil
+	}
+
+	mergeBase, err := runGit("merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, errors.Errorf("failed to find the merge base of %s and HEAD: %w", ref, err)
+	}
+
+	if len(mergeBase) == 0 {
+		return nil, errors.Errorf("no merge base found for %s and HEAD", ref)
+	}
+
+	// With renames disabled, both the old and the new path of a renamed file are listed.
+	changed, err := runGit("diff", "--name-only", "--no-renames", mergeBase[0])
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := runGit("ls-files", "--others", "--exclude-standard", "--full-name")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(changed)+len(untracked))
+
+	for _, file := range append(changed, untracked...) {
+		files = append(files, filepath.Join(topLevelDir, filepath.FromSlash(file)))
+	}
+
+	l.Debugf("%d files changed since %s (merge base %s)", len(files), ref, mergeBase[0])
+
+	return files, nil
+}
+
 // GitRepoTags fetches git repository tags from passed url.
 func GitRepoTags(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, gitRepo *url.URL) ([]string, error) {
 	repoPath := gitRepo.String()
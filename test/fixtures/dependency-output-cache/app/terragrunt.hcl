@@ -0,0 +1,11 @@
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+dependency "dep" {
+  config_path = "../dep"
+}
+
+inputs = {
+  value = dependency.dep.outputs.value
+}
@@ -0,0 +1,12 @@
+dependency "vpc" {
+  config_path = "../vpc"
+
+  mock_outputs = tomap({
+    vpc_id     = "mock-vpc-id"
+    subnet_ids = "mock-subnet-id"
+  })
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.vpc_id
+}
@@ -0,0 +1,29 @@
+variable "cidr_block" {
+  type = string
+}
+
+variable "subnets" {
+  type = list(string)
+}
+
+locals {
+  tags = {
+    Name = "vpc"
+  }
+}
+
+output "vpc_id" {
+  value = "vpc-123"
+}
+
+output "cidr_block" {
+  value = var.cidr_block
+}
+
+output "subnet_ids" {
+  value = var.subnets
+}
+
+output "tags" {
+  value = local.tags
+}
@@ -0,0 +1,14 @@
+dependency "vpc" {
+  config_path = "../vpc"
+
+  mock_outputs = {
+    vpc_id     = "mock-vpc-id"
+    cidr_block = "10.0.0.0/16"
+    subnet_ids = ["mock-subnet-id"]
+    tags       = {}
+  }
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.vpc_id
+}
@@ -0,0 +1,8 @@
+terraform {
+  source = "../modules/vpc"
+}
+
+inputs = {
+  cidr_block = "10.0.0.0/16"
+  subnets    = ["subnet-a", "subnet-b"]
+}
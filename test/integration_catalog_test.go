@@ -22,10 +22,10 @@ func TestCatalogGitRepoUpdate(t *testing.T) {
 
 	tempDir := t.TempDir()
 
-	_, err := module.NewRepo(ctx, logger.CreateLogger(), "github.com/gruntwork-io/terraform-fake-modules.git", tempDir, false, false)
+	_, err := module.NewRepo(ctx, logger.CreateLogger(), "github.com/gruntwork-io/terraform-fake-modules.git", tempDir, false, nil)
 	require.NoError(t, err)
 
-	_, err = module.NewRepo(ctx, logger.CreateLogger(), "github.com/gruntwork-io/terraform-fake-modules.git", tempDir, false, false)
+	_, err = module.NewRepo(ctx, logger.CreateLogger(), "github.com/gruntwork-io/terraform-fake-modules.git", tempDir, false, nil)
 	require.NoError(t, err)
 }
 
@@ -36,7 +36,7 @@ func TestScaffoldGitRepo(t *testing.T) {
 
 	tempDir := t.TempDir()
 
-	repo, err := module.NewRepo(ctx, logger.CreateLogger(), "github.com/gruntwork-io/terraform-fake-modules.git", tempDir, false, false)
+	repo, err := module.NewRepo(ctx, logger.CreateLogger(), "github.com/gruntwork-io/terraform-fake-modules.git", tempDir, false, nil)
 	require.NoError(t, err)
 
 	modules, err := repo.FindModules(ctx)
@@ -51,7 +51,7 @@ func TestScaffoldGitModule(t *testing.T) {
 
 	tempDir := t.TempDir()
 
-	repo, err := module.NewRepo(ctx, logger.CreateLogger(), "https://github.com/gruntwork-io/terraform-fake-modules.git", tempDir, false, false)
+	repo, err := module.NewRepo(ctx, logger.CreateLogger(), "https://github.com/gruntwork-io/terraform-fake-modules.git", tempDir, false, nil)
 	require.NoError(t, err)
 
 	modules, err := repo.FindModules(ctx)
@@ -90,7 +90,7 @@ func TestScaffoldGitModuleHttps(t *testing.T) {
 
 	tempDir := t.TempDir()
 
-	repo, err := module.NewRepo(ctx, logger.CreateLogger(), "https://github.com/gruntwork-io/terraform-fake-modules", tempDir, false, false)
+	repo, err := module.NewRepo(ctx, logger.CreateLogger(), "https://github.com/gruntwork-io/terraform-fake-modules", tempDir, false, nil)
 	require.NoError(t, err)
 
 	modules, err := repo.FindModules(ctx)
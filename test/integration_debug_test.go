@@ -180,6 +180,50 @@ func TestTerragruntValidateInputsWithStrictModeDisabledAndUnusedInputs(t *testin
 	helpers.RunTerragruntValidateInputs(t, rootPath, args, true)
 }
 
+func TestTerragruntValidateMockOutputs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		expectedError string
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:          "fail-unknown-output",
+			expectedError: "dependency.vpc.mock_outputs.subnet_id is not an output of the dependency",
+		},
+		{
+			name:          "fail-type-mismatch",
+			expectedError: "dependency.vpc.mock_outputs.subnet_ids is not a valid list of string",
+		},
+		{
+			name:          "fail-map-type-mismatch",
+			expectedError: "dependency.vpc.mock_outputs.subnet_ids is not a valid list of string",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			moduleDir := filepath.Join("fixtures/validate-mock-outputs", tc.name)
+
+			_, stderr, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt hcl validate --mock-outputs --non-interactive --working-dir "+moduleDir)
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, stderr, tc.expectedError)
+		})
+	}
+}
+
 func TestRenderJSONConfig(t *testing.T) {
 	t.Parallel()
 
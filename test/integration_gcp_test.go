@@ -3,10 +3,12 @@
 package test_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -31,6 +33,7 @@ const (
 	testFixtureGcsNoPrefix          = "fixtures/gcs-no-prefix/"
 	testFixtureGcsParallelStateInit = "fixtures/gcs-parallel-state-init"
 	testFixtureGCSBackend           = "fixtures/gcs-backend"
+	testFixtureGcsOutputFromState   = "fixtures/gcs-output-from-remote-state"
 )
 
 func TestGcpBootstrapBackend(t *testing.T) {
@@ -340,6 +343,46 @@ func TestGcpParallelStateInit(t *testing.T) {
 	helpers.RunTerragrunt(t, "terragrunt run --all --non-interactive --working-dir "+tmpEnvPath+" -- apply -auto-approve")
 }
 
+func TestGcpOutputFromRemoteState(t *testing.T) { //nolint: paralleltest
+	// NOTE: We can't run this test in parallel because other tests also call `config.ClearOutputCache()`, which uses a global variable.
+	// t.Parallel()
+
+	project := os.Getenv("GOOGLE_CLOUD_PROJECT")
+	gcsBucketName := "terragrunt-test-bucket-" + strings.ToLower(helpers.UniqueID())
+
+	defer deleteGCSBucket(t, gcsBucketName)
+
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureGcsOutputFromState)
+
+	rootTerragruntConfigPath := util.JoinPath(tmpEnvPath, testFixtureGcsOutputFromState, "root.hcl")
+	copyTerragruntGCSConfigAndFillPlaceholders(t, rootTerragruntConfigPath, rootTerragruntConfigPath, project, terraformRemoteStateGcpRegion, gcsBucketName)
+
+	environmentPath := fmt.Sprintf("%s/%s/env1", tmpEnvPath, testFixtureGcsOutputFromState)
+
+	helpers.RunTerragrunt(t, fmt.Sprintf("terragrunt apply --dependency-fetch-output-from-state --auto-approve --non-interactive --working-dir %s/app1", environmentPath))
+	helpers.RunTerragrunt(t, fmt.Sprintf("terragrunt apply --dependency-fetch-output-from-state --auto-approve --non-interactive --working-dir %s/app3", environmentPath))
+	// Now delete dependencies cached state
+	config.ClearOutputCache()
+	require.NoError(t, os.RemoveAll(filepath.Join(environmentPath, "app1", ".terraform")))
+	require.NoError(t, os.RemoveAll(filepath.Join(environmentPath, "app3", ".terraform")))
+
+	helpers.RunTerragrunt(t, fmt.Sprintf("terragrunt apply --dependency-fetch-output-from-state --auto-approve --non-interactive --working-dir %s/app2", environmentPath))
+
+	var (
+		stdout bytes.Buffer
+		stderr bytes.Buffer
+	)
+
+	helpers.RunTerragruntRedirectOutput(t, "terragrunt run --all output --dependency-fetch-output-from-state --non-interactive --log-level trace --working-dir "+environmentPath, &stdout, &stderr)
+	output := stdout.String()
+
+	assert.Contains(t, output, "app1 output")
+	assert.Contains(t, output, "app2 output")
+	assert.Contains(t, output, "app3 output")
+	assert.Contains(t, stderr.String(), "using gcs backend")
+	assert.NotContains(t, stderr.String(), "falling back to normal method")
+}
+
 func createTmpTerragruntGCSConfig(t *testing.T, templatesPath string, project string, location string, gcsBucketName string, configFileName string) string {
 	t.Helper()
 
@@ -2,6 +2,9 @@ package test_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
@@ -72,3 +75,44 @@ func TestTerragruntReportExperimentDisableSummary(t *testing.T) {
 	stdoutStr := stdout.String()
 	assert.NotContains(t, stdoutStr, "Run Summary")
 }
+
+func TestTerragruntReportExperimentJSONReportFile(t *testing.T) {
+	t.Parallel()
+
+	// Set up test environment
+	helpers.CleanupTerraformFolder(t, testFixtureReportPath)
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureReportPath)
+	rootPath := util.JoinPath(tmpEnvPath, testFixtureReportPath)
+	reportFile := util.JoinPath(rootPath, "report.json")
+
+	// Run terragrunt with report experiment enabled and a JSON report file
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	err := helpers.RunTerragruntCommand(t, "terragrunt run --all apply --experiment report --non-interactive --working-dir "+rootPath+" --report-file "+reportFile, &stdout, &stderr)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(reportFile)
+	require.NoError(t, err)
+
+	var runs []struct {
+		Name      string
+		Result    string
+		Cause     string
+		Error     string
+		Ancestors []string
+	}
+	require.NoError(t, json.Unmarshal(content, &runs))
+	assert.Len(t, runs, 8)
+
+	for _, run := range runs {
+		switch filepath.Base(run.Name) {
+		case "first-failure":
+			assert.Equal(t, "failed", run.Result)
+			assert.NotEmpty(t, run.Error)
+		case "first-early-exit":
+			assert.Equal(t, "early exit", run.Result)
+			assert.Equal(t, []string{util.JoinPath(rootPath, "first-failure")}, run.Ancestors)
+			assert.Equal(t, util.JoinPath(rootPath, "first-failure"), run.Cause)
+		}
+	}
+}
@@ -46,6 +46,7 @@ const (
 	testFixtureConfigWithNonDefaultNames      = "fixtures/config-files/with-non-default-names"
 	testFixtureDependenciesOptimisation       = "fixtures/dependency-optimisation"
 	testFixtureDependencyOutput               = "fixtures/dependency-output"
+	testFixtureDependencyOutputCache          = "fixtures/dependency-output-cache"
 	testFixtureDetailedExitCode               = "fixtures/detailed-exitcode"
 	testFixtureDirsPath                       = "fixtures/dirs"
 	testFixtureDisabledModule                 = "fixtures/disabled/"
@@ -3080,6 +3081,45 @@ func TestDependenciesOptimisation(t *testing.T) {
 	assert.NotContains(t, stderr, "Retrieved output from ../module-a/terragrunt.hcl")
 }
 
+func TestDependencyOutputCache(t *testing.T) {
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureDependencyOutputCache)
+	rootPath := util.JoinPath(tmpEnvPath, testFixtureDependencyOutputCache)
+	depPath := util.JoinPath(rootPath, "dep")
+	appPath := util.JoinPath(rootPath, "app")
+	cacheDir := t.TempDir()
+
+	renderValue := func(t *testing.T, args string) string {
+		t.Helper()
+
+		config.ClearOutputCache()
+
+		stdout, _, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt render --json --non-interactive --dependency-output-cache --dependency-output-cache-dir "+cacheDir+" --working-dir "+appPath+" "+args)
+		require.NoError(t, err)
+
+		var rendered struct {
+			Inputs map[string]string `json:"inputs"`
+		}
+
+		require.NoError(t, json.Unmarshal([]byte(stdout), &rendered))
+
+		return rendered.Inputs["value"]
+	}
+
+	t.Setenv("DEP_VALUE", "first")
+	helpers.RunTerragrunt(t, "terragrunt apply -auto-approve --non-interactive --working-dir "+depPath)
+
+	assert.Equal(t, "first", renderValue(t, "--dependency-output-cache-ttl 1h"))
+
+	t.Setenv("DEP_VALUE", "second")
+	helpers.RunTerragrunt(t, "terragrunt apply -auto-approve --non-interactive --working-dir "+depPath)
+
+	// the outputs are cached for an hour, since the local backend cannot tell whether the state changed
+	assert.Equal(t, "first", renderValue(t, "--dependency-output-cache-ttl 1h"))
+
+	// without a TTL, the outputs of the local backend aren't cached
+	assert.Equal(t, "second", renderValue(t, ""))
+}
+
 func cleanupTerraformFolder(t *testing.T, templatesPath string) {
 	t.Helper()
 
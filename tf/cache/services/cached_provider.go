@@ -0,0 +1,226 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/hashicorp/go-version"
+)
+
+// cachedProviderDepth is the depth of the provider packages in the cache directory, laid out as
+// `<registry>/<namespace>/<name>/<version>/<os>_<arch>`.
+const cachedProviderDepth = 5
+
+// ownerWritePerm is the permission bit for the owner to write a file.
+const ownerWritePerm = 0200
+
+// CachedProvider is a provider package in the provider cache directory.
+type CachedProvider struct {
+	// LastUsed is the last time the package was used by the provider cache server.
+	LastUsed time.Time
+	// Address is the address of the provider, e.g. `registry.terraform.io/hashicorp/aws`.
+	Address  string
+	Version  string
+	Platform string
+	// Path is the path of the package directory.
+	Path string
+	Size int64
+	// Linked is true if the package is a symlink to the user plugins directory, in which case the size of the
+	// package isn't counted, and only the link is removed.
+	Linked bool
+}
+
+// CachedProviders returns all the provider packages in the given cache directory, sorted by address, version and
+// platform, with the latest versions first.
+func CachedProviders(cacheDir string) ([]*CachedProvider, error) {
+	var providers []*CachedProvider
+
+	err := filepath.WalkDir(cacheDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == cacheDir {
+				return filepath.SkipDir
+			}
+
+			return err
+		}
+
+		rel, err := filepath.Rel(cacheDir, path)
+		if err != nil {
+			return err
+		}
+
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if rel == "." || len(parts) < cachedProviderDepth {
+			return nil
+		}
+
+		if !entry.IsDir() && entry.Type()&os.ModeSymlink == 0 {
+			return nil
+		}
+
+		info, err := os.Lstat(path)
+		if err != nil {
+			return err
+		}
+
+		cached := &CachedProvider{
+			Address:  strings.Join(parts[:3], "/"),
+			Version:  parts[3],
+			Platform: parts[4],
+			Path:     path,
+			LastUsed: info.ModTime(),
+			Linked:   info.Mode()&os.ModeSymlink != 0,
+		}
+
+		if !cached.Linked {
+			if cached.Size, err = dirSize(path); err != nil {
+				return err
+			}
+		}
+
+		providers = append(providers, cached)
+
+		if entry.IsDir() {
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	sort.SliceStable(providers, func(i, j int) bool {
+		a, b := providers[i], providers[j]
+
+		if a.Address != b.Address {
+			return a.Address < b.Address
+		}
+
+		if a.Version != b.Version {
+			return compareVersions(a.Version, b.Version) > 0
+		}
+
+		return a.Platform < b.Platform
+	})
+
+	return providers, nil
+}
+
+// Remove removes the cached provider package, along with the directories left empty. It fails if the package is
+// being cached by the provider cache server.
+func (cached *CachedProvider) Remove(cacheDir string) error {
+	tempDir, err := providerTempDir()
+	if err != nil {
+		return err
+	}
+
+	packageName := strings.Join(append(strings.Split(cached.Address, "/"), cached.Version, cached.Platform), "-")
+	lockfilePath := filepath.Join(tempDir, packageName+".lock")
+
+	if err := os.MkdirAll(tempDir, os.ModePerm); err != nil {
+		return errors.New(err)
+	}
+
+	// The provider cache server holds the same lock file while caching the package
+	lockfile := util.NewLockfile(lockfilePath)
+
+	if err := lockfile.TryLock(); err != nil {
+		return errors.Errorf("provider %s %s (%s) is in use: %w", cached.Address, cached.Version, cached.Platform, err)
+	}
+
+	defer lockfile.Unlock() //nolint:errcheck
+
+	if !cached.Linked {
+		if err := makeWritable(cached.Path); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	if err := os.RemoveAll(cached.Path); err != nil {
+		return errors.New(err)
+	}
+
+	for dir := filepath.Dir(cached.Path); dir != cacheDir && util.HasPathPrefix(dir, cacheDir); dir = filepath.Dir(dir) {
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) > 0 {
+			break
+		}
+
+		if err := os.Remove(dir); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
+// providerTempDir returns the predictable temporary directory of the provider archives and lock files.
+func providerTempDir() (string, error) {
+	tempDir, err := util.GetTempDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(tempDir, "providers"), nil
+}
+
+// makeWritable makes the files of the given directory writable by the owner, so that they can be removed. Provider
+// packages are unpacked with the permissions of their archives, which can be read-only.
+func makeWritable(path string) error {
+	return filepath.WalkDir(path, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		if info.Mode().Perm()&ownerWritePerm == 0 {
+			return os.Chmod(path, info.Mode().Perm()|ownerWritePerm)
+		}
+
+		return nil
+	})
+}
+
+func dirSize(path string) (int64, error) {
+	var size int64
+
+	err := filepath.WalkDir(path, func(_ string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		size += info.Size()
+
+		return nil
+	})
+
+	return size, err
+}
+
+// compareVersions compares the given provider versions semantically, falling back to comparing them as strings if
+// either of them isn't a valid version.
+func compareVersions(a, b string) int {
+	va, errA := version.NewVersion(a)
+	vb, errB := version.NewVersion(b)
+
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+
+	return va.Compare(vb)
+}
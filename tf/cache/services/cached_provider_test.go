@@ -0,0 +1,88 @@
+package services_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/tf/cache/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCachedProvider writes a provider package to the cache directory, last used the given time ago.
+func writeCachedProvider(t *testing.T, cacheDir, address, version, platform string, age time.Duration) string {
+	t.Helper()
+
+	dir := filepath.Join(cacheDir, filepath.FromSlash(address), version, platform)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "terraform-provider"), []byte("provider binary"), 0555))
+
+	lastUsed := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(dir, lastUsed, lastUsed))
+
+	return dir
+}
+
+func TestCachedProviders(t *testing.T) {
+	t.Parallel()
+
+	cacheDir := t.TempDir()
+
+	writeCachedProvider(t, cacheDir, "registry.terraform.io/hashicorp/aws", "5.9.0", "linux_amd64", 0)
+	writeCachedProvider(t, cacheDir, "registry.terraform.io/hashicorp/aws", "5.10.0", "linux_amd64", 0)
+	writeCachedProvider(t, cacheDir, "registry.terraform.io/hashicorp/aws", "5.10.0", "darwin_arm64", 0)
+	nullDir := writeCachedProvider(t, cacheDir, "registry.opentofu.org/hashicorp/null", "3.2.2", "linux_amd64", 48*time.Hour)
+
+	userDir := writeCachedProvider(t, t.TempDir(), "registry.terraform.io/hashicorp/local", "2.5.1", "linux_amd64", 0)
+	linkDir := filepath.Join(cacheDir, "registry.terraform.io", "hashicorp", "local", "2.5.1", "linux_amd64")
+	require.NoError(t, os.MkdirAll(filepath.Dir(linkDir), 0755))
+	require.NoError(t, os.Symlink(userDir, linkDir))
+
+	cached, err := services.CachedProviders(cacheDir)
+	require.NoError(t, err)
+	require.Len(t, cached, 5)
+
+	var found []string
+	for _, provider := range cached {
+		found = append(found, provider.Address+" "+provider.Version+" "+provider.Platform)
+	}
+
+	// sorted by address, with the latest versions first
+	assert.Equal(t, []string{
+		"registry.opentofu.org/hashicorp/null 3.2.2 linux_amd64",
+		"registry.terraform.io/hashicorp/aws 5.10.0 darwin_arm64",
+		"registry.terraform.io/hashicorp/aws 5.10.0 linux_amd64",
+		"registry.terraform.io/hashicorp/aws 5.9.0 linux_amd64",
+		"registry.terraform.io/hashicorp/local 2.5.1 linux_amd64",
+	}, found)
+
+	assert.Equal(t, int64(len("provider binary")), cached[0].Size)
+	assert.WithinDuration(t, time.Now().Add(-48*time.Hour), cached[0].LastUsed, time.Minute)
+	assert.True(t, cached[4].Linked)
+	assert.Zero(t, cached[4].Size)
+
+	t.Run("remove", func(t *testing.T) {
+		require.NoError(t, cached[0].Remove(cacheDir))
+		require.NoError(t, cached[4].Remove(cacheDir))
+
+		// the directories left empty are removed as well
+		assert.NoDirExists(t, filepath.Join(cacheDir, "registry.opentofu.org"))
+		assert.NoDirExists(t, nullDir)
+		assert.NoFileExists(t, linkDir)
+		assert.DirExists(t, userDir)
+
+		cached, err := services.CachedProviders(cacheDir)
+		require.NoError(t, err)
+		assert.Len(t, cached, 3)
+	})
+}
+
+func TestCachedProvidersMissingCacheDir(t *testing.T) {
+	t.Parallel()
+
+	cached, err := services.CachedProviders(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, cached)
+}
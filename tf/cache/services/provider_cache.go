@@ -195,7 +195,17 @@ func (cache *ProviderCache) addRequestID(requestID string) {
 // 1. Checks if the required provider exists in the user plugins directory, located at %APPDATA%\terraform.d\plugins on Windows and ~/.terraform.d/plugins on other systems. If so, creates a symlink to this folder. (Some providers are not available for darwin_arm64, in this case we can use https://github.com/kreuzwerker/m1-terraform-provider-helper which compiles and saves providers to the user plugins directory)
 // 2. Downloads the provider from the original registry, unpacks and saves it into the cache directory.
 func (cache *ProviderCache) warmUp(ctx context.Context) error {
-	if util.FileExists(cache.packageDir) {
+	if info, err := os.Lstat(cache.packageDir); err == nil {
+		// Record the use of the package, reported as its last use by `provider-cache stats` and `provider-cache prune`.
+		// Links to the user plugins directory are not touched, since that would touch the linked directory.
+		if info.Mode()&os.ModeSymlink == 0 {
+			now := time.Now()
+
+			if err := os.Chtimes(cache.packageDir, now, now); err != nil {
+				cache.logger.Debugf("Failed to record the use of %s: %v", cache.packageDir, err)
+			}
+		}
+
 		return nil
 	}
 
@@ -413,12 +423,12 @@ func (service *ProviderService) Run(ctx context.Context) error {
 		return errors.New(err)
 	}
 
-	tempDir, err := util.GetTempDir()
+	tempDir, err := providerTempDir()
 	if err != nil {
 		return err
 	}
 
-	service.tempDir = filepath.Join(tempDir, "providers")
+	service.tempDir = tempDir
 
 	errs := &errors.MultiError{}
 	errGroup, ctx := errgroup.WithContext(ctx)
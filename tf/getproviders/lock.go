@@ -55,6 +55,40 @@ func UpdateLockfile(ctx context.Context, workingDir string, providers []Provider
 	return nil
 }
 
+// LockedProviders returns the versions of the providers locked in the dependency lock file of the given working
+// directory, by provider address. It returns nil if the working directory has no lock file.
+func LockedProviders(workingDir string) (map[string]string, error) {
+	filename := filepath.Join(workingDir, tf.TerraformLockFile)
+
+	if !util.FileExists(filename) {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	file, diags := hclwrite.ParseConfig(content, filename, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, errors.New(diags)
+	}
+
+	providers := make(map[string]string)
+
+	for _, block := range file.Body().Blocks() {
+		if block.Type() != "provider" || len(block.Labels()) != 1 {
+			continue
+		}
+
+		if versionAttr := block.Body().GetAttribute("version"); versionAttr != nil {
+			providers[block.Labels()[0]] = getAttributeValueAsUnquotedString(versionAttr)
+		}
+	}
+
+	return providers, nil
+}
+
 func updateLockfile(ctx context.Context, file *hclwrite.File, providers []Provider) error {
 	sort.Slice(providers, func(i, j int) bool {
 		return providers[i].Address() < providers[j].Address()
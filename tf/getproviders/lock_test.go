@@ -171,3 +171,35 @@ provider "registry.terraform.io/hashicorp/template" {
 		})
 	}
 }
+
+func TestLockedProviders(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	providers, err := getproviders.LockedProviders(workingDir)
+	require.NoError(t, err)
+	assert.Nil(t, providers)
+
+	lockfile := `
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "5.37.0"
+  constraints = "~> 5.0"
+  hashes = [
+    "h1:SHOEBOHEif46z7Bb86YZ5evCtAeK5A4gtHdT8RU5OhA=",
+  ]
+}
+
+provider "registry.opentofu.org/hashicorp/null" {
+  version = "3.2.2"
+}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, ".terraform.lock.hcl"), []byte(lockfile), 0644))
+
+	providers, err = getproviders.LockedProviders(workingDir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"registry.terraform.io/hashicorp/aws":  "5.37.0",
+		"registry.opentofu.org/hashicorp/null": "3.2.2",
+	}, providers)
+}
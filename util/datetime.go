@@ -4,6 +4,8 @@ package util
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -73,3 +75,48 @@ func ParseTimestamp(ts string) (time.Time, error) {
 
 	return t, nil
 }
+
+// ParseDuration parses a duration the same way as time.ParseDuration, with the addition of the `d` (day) and
+// `w` (week) units, e.g. `30d` or `1w12h`.
+func ParseDuration(duration string) (time.Duration, error) {
+	var (
+		total time.Duration
+		rest  = strings.TrimSpace(duration)
+	)
+
+	if rest == "" {
+		return 0, fmt.Errorf("invalid duration %q", duration)
+	}
+
+	for _, unit := range []struct {
+		suffix string
+		value  time.Duration
+	}{
+		{"w", 7 * 24 * time.Hour}, //nolint:mnd
+		{"d", 24 * time.Hour},     //nolint:mnd
+	} {
+		i := strings.Index(rest, unit.suffix)
+		if i == -1 {
+			continue
+		}
+
+		count, err := strconv.ParseFloat(rest[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", duration)
+		}
+
+		total += time.Duration(count * float64(unit.value))
+		rest = rest[i+1:]
+	}
+
+	if rest == "" {
+		return total, nil
+	}
+
+	value, err := time.ParseDuration(rest)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", duration)
+	}
+
+	return total + value, nil
+}
@@ -39,3 +39,38 @@ func TestParseTimestamp(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDuration(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		arg   string
+		value time.Duration
+		err   bool
+	}{
+		{"90m", 90 * time.Minute, false},
+		{"30d", 30 * 24 * time.Hour, false},
+		{"1w", 7 * 24 * time.Hour, false},
+		{"1w2d12h", 9*24*time.Hour + 12*time.Hour, false},
+		{"1.5d", 36 * time.Hour, false},
+		{"d", 0, true},
+		{"30days", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.arg, func(t *testing.T) {
+			t.Parallel()
+
+			value, err := util.ParseDuration(tc.arg)
+			if tc.err {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.value, value)
+		})
+	}
+}
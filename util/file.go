@@ -450,9 +450,37 @@ func CopyFile(source string, destination string) error {
 		return errors.New(err)
 	}
 
+	if err := RemoveHardLink(destination); err != nil {
+		return err
+	}
+
 	return WriteFileWithSamePermissions(source, destination, contents)
 }
 
+// RemoveHardLink removes the file at the given path if it has other hard links, so that writing the path afterwards
+// creates a new file. The working dirs of sources fetched through the CAS hard link their files to the blobs of the
+// store, which are shared by every working dir and must never be written through.
+func RemoveHardLink(path string) error {
+	count, err := LinkCount(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if count <= 1 {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
 // WriteFileWithSamePermissions writes a file to the given destination with the given contents
 // using the same permissions as the file at source.
 func WriteFileWithSamePermissions(source string, destination string, contents []byte) error {
@@ -0,0 +1,24 @@
+//go:build !windows
+// +build !windows
+
+package util
+
+import (
+	"os"
+	"syscall"
+)
+
+// LinkCount returns the number of hard links to the file at the given path.
+func LinkCount(path string) (uint64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 1, nil
+	}
+
+	return uint64(stat.Nlink), nil //nolint:unconvert
+}
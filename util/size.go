@@ -0,0 +1,67 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// byteSizeUnits are the units accepted by ParseByteSize, by their multiplier.
+var byteSizeUnits = map[string]int64{ //nolint:gochecknoglobals
+	"":    1,
+	"B":   1,
+	"KB":  1000,
+	"MB":  1000 * 1000,
+	"GB":  1000 * 1000 * 1000,
+	"TB":  1000 * 1000 * 1000 * 1000,
+	"KIB": 1 << 10,
+	"MIB": 1 << 20,
+	"GIB": 1 << 30,
+	"TIB": 1 << 40,
+}
+
+// ParseByteSize parses a size in bytes with an optional decimal (KB, MB, GB, TB) or binary (KiB, MiB, GiB, TiB)
+// unit, e.g. `500MB` or `10GiB`.
+func ParseByteSize(size string) (int64, error) {
+	size = strings.TrimSpace(size)
+
+	i := strings.IndexFunc(size, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i == -1 {
+		i = len(size)
+	}
+
+	number, unit := size[:i], strings.ToUpper(strings.TrimSpace(size[i:]))
+
+	multiplier, ok := byteSizeUnits[unit]
+	if !ok || number == "" {
+		return 0, errors.Errorf("invalid size %q, expected a number of bytes with an optional unit such as MB or GiB", size)
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid size %q: %w", size, err)
+	}
+
+	return int64(value * float64(multiplier)), nil
+}
+
+// FormatByteSize formats the given size in bytes with a binary unit, e.g. `1.5 MiB`.
+func FormatByteSize(size int64) string {
+	const unit = 1024
+
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
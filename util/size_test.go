@@ -0,0 +1,52 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseByteSize(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		size     string
+		expected int64
+		err      bool
+	}{
+		{size: "1024", expected: 1024},
+		{size: "10B", expected: 10},
+		{size: "500MB", expected: 500 * 1000 * 1000},
+		{size: "1.5 GiB", expected: 3 << 29},
+		{size: "2gib", expected: 2 << 30},
+		{size: "", err: true},
+		{size: "GB", err: true},
+		{size: "10XB", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.size, func(t *testing.T) {
+			t.Parallel()
+
+			size, err := util.ParseByteSize(tc.size)
+			if tc.err {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, size)
+		})
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "512 B", util.FormatByteSize(512))
+	assert.Equal(t, "1.5 KiB", util.FormatByteSize(1536))
+	assert.Equal(t, "2.0 GiB", util.FormatByteSize(2<<30))
+}